@@ -0,0 +1,101 @@
+// Command batch sends a multi page document as a batch, printing the progress while its jobs are processed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func main() {
+	service := flag.String("service", string(ultraocr.ServiceRG), "document service")
+	concurrency := flag.Int("concurrency", 4, "jobs waited concurrently")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("usage: batch -service SERVICE [-concurrency N] FILE")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	created, err := client.SendBatch(ctx, ultraocr.Service(*service), flag.Arg(0), nil, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("batch %s created", created.Id)
+
+	var status ultraocr.BatchStatusResponse
+	poller := client.NewPoller(common.RESOURCE_BATCH, created.Id)
+	err = poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		status, err = client.GetBatchStatus(ctx, created.Id)
+		if err != nil {
+			return false, err
+		}
+
+		log.Printf("batch %s: %s, %d jobs", created.Id, status.Status, len(status.Jobs))
+		return status.Status.IsTerminal(), nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if status.Status.IsError() {
+		log.Fatalf("batch %s failed: %s", created.Id, status.Error)
+	}
+
+	refs := make([]ultraocr.JobRef, len(status.Jobs))
+	for i, job := range status.Jobs {
+		refs[i] = ultraocr.JobRef{BatchID: created.Id, JobID: job.JobID}
+	}
+
+	done, failed := 0, 0
+	for result := range client.WaitForJobsDone(ctx, refs, *concurrency) {
+		if result.Err != nil || result.Result.Status.IsError() {
+			failed += 1
+			log.Printf("job %s failed: %v %s", result.JobID, result.Err, result.Result.Error)
+		} else {
+			done += 1
+		}
+
+		log.Printf("progress: %d/%d jobs done, %d failed", done+failed, len(refs), failed)
+	}
+
+	fmt.Printf("%d jobs done, %d failed\n", done, failed)
+}
+
+// newClient Creates a Client with the credentials and URLs of the environment.
+func newClient() (*ultraocr.Client, error) {
+	clientID, clientSecret := os.Getenv("ULTRAOCR_CLIENT_ID"), os.Getenv("ULTRAOCR_CLIENT_SECRET")
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("ULTRAOCR_CLIENT_ID and ULTRAOCR_CLIENT_SECRET are required")
+	}
+
+	client := ultraocr.NewClient()
+	client.SetAutoRefresh(clientID, clientSecret, 60)
+
+	if url := os.Getenv("ULTRAOCR_BASE_URL"); url != "" {
+		err := client.SetBaseURL(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if url := os.Getenv("ULTRAOCR_AUTH_BASE_URL"); url != "" {
+		err := client.SetAuthBaseURL(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &client, nil
+}
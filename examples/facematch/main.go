@@ -0,0 +1,79 @@
+// Command facematch sends a document with a selfie to match its face, printing the result.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func main() {
+	service := flag.String("service", string(ultraocr.ServiceCNH), "document service")
+	selfie := flag.String("selfie", "", "selfie image to match with the document")
+	extra := flag.String("extra", "", "optional extra document, like the document back")
+	flag.Parse()
+
+	if flag.NArg() != 1 || *selfie == "" {
+		log.Fatal("usage: facematch -service SERVICE -selfie SELFIE [-extra EXTRA] FILE")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	opts := ultraocr.JobOptions{Facematch: true, ExtraDocument: *extra != ""}
+	created, err := client.SendJobWithOptions(ctx, ultraocr.Service(*service), flag.Arg(0), *selfie, *extra, nil, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := client.WaitForJob(ctx, created.Id)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if result.Status.IsError() {
+		log.Fatalf("job %s failed: %s", result.JobID, result.Error)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result.Result.Document)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newClient Creates a Client with the credentials and URLs of the environment.
+func newClient() (*ultraocr.Client, error) {
+	clientID, clientSecret := os.Getenv("ULTRAOCR_CLIENT_ID"), os.Getenv("ULTRAOCR_CLIENT_SECRET")
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("ULTRAOCR_CLIENT_ID and ULTRAOCR_CLIENT_SECRET are required")
+	}
+
+	client := ultraocr.NewClient()
+	client.SetAutoRefresh(clientID, clientSecret, 60)
+
+	if url := os.Getenv("ULTRAOCR_BASE_URL"); url != "" {
+		err := client.SetBaseURL(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if url := os.Getenv("ULTRAOCR_AUTH_BASE_URL"); url != "" {
+		err := client.SetAuthBaseURL(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &client, nil
+}
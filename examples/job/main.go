@@ -0,0 +1,70 @@
+// Command job sends a single document and prints its result.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func main() {
+	service := flag.String("service", string(ultraocr.ServiceRG), "document service")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("usage: job -service SERVICE FILE")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := client.CreateAndWaitJob(context.Background(), ultraocr.Service(*service), flag.Arg(0), "", "", nil, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if result.Status.IsError() {
+		log.Fatalf("job %s failed: %s", result.JobID, result.Error)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result.Result.Document)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newClient Creates a Client with the credentials and URLs of the environment.
+func newClient() (*ultraocr.Client, error) {
+	clientID, clientSecret := os.Getenv("ULTRAOCR_CLIENT_ID"), os.Getenv("ULTRAOCR_CLIENT_SECRET")
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("ULTRAOCR_CLIENT_ID and ULTRAOCR_CLIENT_SECRET are required")
+	}
+
+	client := ultraocr.NewClient()
+	client.SetAutoRefresh(clientID, clientSecret, 60)
+
+	if url := os.Getenv("ULTRAOCR_BASE_URL"); url != "" {
+		err := client.SetBaseURL(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if url := os.Getenv("ULTRAOCR_AUTH_BASE_URL"); url != "" {
+		err := client.SetAuthBaseURL(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &client, nil
+}
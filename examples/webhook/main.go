@@ -0,0 +1,36 @@
+// Command webhook receives UltraOCR job and batch callbacks, printing the finished jobs and batches.
+// Send jobs with the JobOptions CallbackURL pointing to this server.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/webhook"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	secret := flag.String("secret", "", "secret to verify the callbacks signature")
+	flag.Parse()
+
+	handler := webhook.NewHandler(*secret)
+	handler.OnJobDone(func(ctx context.Context, event webhook.JobDone) error {
+		log.Printf("job %s (batch %s): %s %s", event.JobID, event.BatchID, event.Status, event.Error)
+		return nil
+	})
+	handler.OnBatchDone(func(ctx context.Context, event webhook.BatchDone) error {
+		log.Printf("batch %s: %s, %d jobs", event.BatchID, event.Status, len(event.Jobs))
+		return nil
+	})
+	handler.ErrorHandler = func(r *http.Request, err error) {
+		log.Printf("callback from %s failed: %v", r.RemoteAddr, err)
+	}
+
+	http.Handle("/callbacks/ultraocr", handler)
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -0,0 +1,117 @@
+package ultraocr
+
+import (
+	"context"
+	"sync"
+)
+
+// WaitForJobDoneAsync Waits for the job status be done or error in background, like WaitForJobDone.
+// The returned channel receives a single JobResult and is closed, so it can be used on select statements.
+// Requires the batch and job ID.
+func (client *Client) WaitForJobDoneAsync(ctx context.Context, batchID, jobID string) <-chan JobResult {
+	ch := make(chan JobResult, 1)
+
+	go func() {
+		defer close(ch)
+
+		result, err := client.WaitForJobDone(ctx, batchID, jobID)
+		ch <- JobResult{
+			BatchID: batchID,
+			JobID:   jobID,
+			Result:  result,
+			Err:     err,
+		}
+	}()
+
+	return ch
+}
+
+// WaitForBatchDoneAsync Waits for the batch status be done or error in background, like WaitForBatchDone.
+// The returned channel receives a single BatchResult and is closed, so it can be used on select statements.
+// Requires the batch and an info if the utility will also wait the jobs to be done.
+func (client *Client) WaitForBatchDoneAsync(ctx context.Context, ID string, waitJobs bool) <-chan BatchResult {
+	ch := make(chan BatchResult, 1)
+
+	go func() {
+		defer close(ch)
+
+		status, err := client.WaitForBatchDone(ctx, ID, waitJobs)
+		ch <- BatchResult{
+			BatchID: ID,
+			Status:  status,
+			Err:     err,
+		}
+	}()
+
+	return ch
+}
+
+// MergeJobResults Fans in many job result channels into one, closed after all of them are closed.
+func MergeJobResults(channels ...<-chan JobResult) <-chan JobResult {
+	out := make(chan JobResult, len(channels))
+	done := make(chan struct{})
+
+	for _, ch := range channels {
+		go func() {
+			for result := range ch {
+				out <- result
+			}
+			done <- struct{}{}
+		}()
+	}
+
+	go func() {
+		for range channels {
+			<-done
+		}
+		close(out)
+	}()
+
+	return out
+}
+
+// WaitForJobsDone Waits for many jobs to be done or error, polling up to maxConcurrency jobs at a time
+// (zero or less polls all of them at once). The returned channel receives each JobResult as it
+// completes and is closed after all of them. Results are stored on the Client ResultSink, if any,
+// failing the JobResult when it can't be stored.
+func (client *Client) WaitForJobsDone(ctx context.Context, jobs []JobRef, maxConcurrency int) <-chan JobResult {
+	out := make(chan JobResult, len(jobs))
+	if maxConcurrency <= 0 || maxConcurrency > len(jobs) {
+		maxConcurrency = len(jobs)
+	}
+
+	refs := make(chan JobRef)
+	var wg sync.WaitGroup
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ref := range refs {
+				result, err := client.WaitForJobDone(ctx, ref.BatchID, ref.JobID)
+				if err == nil {
+					err = client.storeResult(ctx, result)
+				}
+
+				out <- JobResult{
+					BatchID: ref.BatchID,
+					JobID:   ref.JobID,
+					Result:  result,
+					Err:     err,
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, ref := range jobs {
+			refs <- ref
+		}
+		close(refs)
+
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
@@ -0,0 +1,144 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestWaitForJobDoneAsync(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 10 * time.Minute
+	client := newFakeClient(clock, api)
+	client.SetTimeout(int(time.Hour.Seconds()))
+
+	done := api.AddJob("rg", common.STATUS_DONE)
+	failed := api.AddJob("rg", common.STATUS_ERROR)
+
+	results := MergeJobResults(
+		client.WaitForJobDoneAsync(context.Background(), done, done),
+		client.WaitForJobDoneAsync(context.Background(), failed, failed),
+	)
+
+	got := []string{}
+	for result := range results {
+		if result.Err != nil {
+			t.Fatalf("client.WaitForJobDoneAsync() error = %v", result.Err)
+		}
+		if result.Result.JobID != result.JobID {
+			t.Errorf("client.WaitForJobDoneAsync() job = %v, want %v", result.Result.JobID, result.JobID)
+		}
+		got = append(got, string(result.Result.Status))
+	}
+
+	sort.Strings(got)
+	want := []string{common.STATUS_DONE, common.STATUS_ERROR}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeJobResults() statuses = %v, want %v", got, want)
+	}
+}
+
+func TestWaitForBatchDoneAsync(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 10 * time.Minute
+	client := newFakeClient(clock, api)
+	client.SetTimeout(int(time.Hour.Seconds()))
+
+	created, err := client.SendBatch(context.Background(), "rg", "async_test.go", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendBatch() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	select {
+	case result := <-client.WaitForBatchDoneAsync(ctx, created.Id, true):
+		if result.Err != nil {
+			t.Fatalf("client.WaitForBatchDoneAsync() error = %v", result.Err)
+		}
+		if result.Status.Status != common.STATUS_DONE {
+			t.Errorf("client.WaitForBatchDoneAsync() status = %v, want %v", result.Status.Status, common.STATUS_DONE)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("client.WaitForBatchDoneAsync() did not finish")
+	}
+}
+
+func TestWaitForJobsDone(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 10 * time.Minute
+	client := newFakeClient(clock, api)
+	client.SetTimeout(int(time.Hour.Seconds()))
+
+	tests := []struct {
+		name           string
+		jobs           int
+		maxConcurrency int
+	}{
+		{name: "no jobs", jobs: 0, maxConcurrency: 2},
+		{name: "bounded", jobs: 5, maxConcurrency: 2},
+		{name: "unbounded", jobs: 3, maxConcurrency: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := []JobRef{}
+			want := map[string]bool{}
+			for i := 0; i < tt.jobs; i++ {
+				id := api.AddJob("rg", common.STATUS_DONE)
+				refs = append(refs, JobRef{BatchID: id, JobID: id})
+				want[id] = true
+			}
+
+			got := map[string]bool{}
+			for result := range client.WaitForJobsDone(context.Background(), refs, tt.maxConcurrency) {
+				if result.Err != nil {
+					t.Fatalf("client.WaitForJobsDone() error = %v", result.Err)
+				}
+				got[result.Result.JobID] = true
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("client.WaitForJobsDone() jobs = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWaitForBatchDoneConcurrentJobs(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.JobsPerBatch = 20
+	client := newFakeClient(clock, api)
+	client.SetJobsConcurrency(4)
+
+	created, err := client.SendBatch(context.Background(), "rg", "async_test.go", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendBatch() error = %v", err)
+	}
+
+	res, err := client.WaitForBatchDone(context.Background(), created.Id, true)
+	if err != nil {
+		t.Fatalf("client.WaitForBatchDone() error = %v", err)
+	}
+	if len(res.Jobs) != api.JobsPerBatch {
+		t.Errorf("client.WaitForBatchDone() jobs = %v, want %v", len(res.Jobs), api.JobsPerBatch)
+	}
+
+	failed := res.Jobs[7].JobID
+	api.SetJobStatus(failed, "processing")
+	client.SetTimeout(60)
+	_, err = client.WaitForBatchDone(context.Background(), created.Id, true)
+	if !errors.Is(err, common.ErrTimeout) {
+		t.Errorf("client.WaitForBatchDone() error = %v, want %v", err, common.ErrTimeout)
+	}
+}
@@ -0,0 +1,188 @@
+package ultraocr
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetAuditSink Changes the AuditSink recording the submissions and finished result retrievals
+// (Default none).
+func (client *Client) SetAuditSink(sink AuditSink) {
+	client.Audit = sink
+}
+
+// audit Records the action on the Client AuditSink, if any.
+func (client *Client) audit(ctx context.Context, record AuditRecord) error {
+	if client.Audit == nil {
+		return nil
+	}
+
+	unlock := client.lockAuth()
+	record.Actor = client.ClientID
+	unlock()
+
+	record.Time = client.clock().Now()
+	record.RequestID, _ = RequestIDFromContext(ctx)
+
+	err := client.Audit.Record(ctx, record)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s %s: %w", common.ErrAuditSink, record.Action, record.Resource, record.ID, err)
+	}
+
+	return nil
+}
+
+// auditSubmit Records the submission of a job or batch. The job or batch was created, so failures
+// wrap ErrAuditFailed, telling callers not to submit it again.
+func (client *Client) auditSubmit(ctx context.Context, resource, ID string, service Service, metadata any) error {
+	err := client.audit(ctx, AuditRecord{
+		Action:       common.AUDIT_ACTION_SUBMIT,
+		Service:      service,
+		Resource:     resource,
+		ID:           ID,
+		MetadataHash: metadataHash(metadata),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s %s was created: %w", common.ErrAuditFailed, resource, ID, err)
+	}
+
+	return nil
+}
+
+// auditResult Records the retrieval of a finished job result.
+func (client *Client) auditResult(ctx context.Context, result JobResultResponse) error {
+	if !result.Status.IsTerminal() {
+		return nil
+	}
+
+	return client.audit(ctx, AuditRecord{
+		Action:       common.AUDIT_ACTION_RESULT,
+		Service:      result.Service,
+		Resource:     common.RESOURCE_JOB,
+		ID:           result.JobID,
+		Status:       result.Status,
+		MetadataHash: metadataHash(result.ClientData),
+	})
+}
+
+// metadataHash Returns the hex SHA-256 of the metadata JSON, empty without metadata.
+// Maps are encoded with sorted keys, so equal metadata has equal hashes.
+func metadataHash(metadata any) string {
+	switch m := metadata.(type) {
+	case nil:
+		return ""
+	case map[string]any:
+		if len(m) == 0 {
+			return ""
+		}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// auditEntry AuditRecord line of a JSONAuditSink, chained to the previous line by its hash.
+type auditEntry struct {
+	AuditRecord
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// hash Returns the hex SHA-256 of the previous hash and the record JSON.
+func (e auditEntry) hash() (string, error) {
+	data, err := json.Marshal(e.AuditRecord)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(e.PrevHash))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// JSONAuditSink AuditSink writing each record as a JSON line with the hash of the previous line
+// and its own, so a changed, removed or reordered line breaks the chain (see VerifyAuditLog).
+type JSONAuditSink struct {
+	W io.Writer
+
+	mu   sync.Mutex
+	last string
+}
+
+// NewJSONAuditSink Creates an AuditSink writing on w. To append to an existing log, pass the hash
+// of its last line, as returned by VerifyAuditLog, or an empty one for a new log.
+func NewJSONAuditSink(w io.Writer, lastHash string) *JSONAuditSink {
+	return &JSONAuditSink{W: w, last: lastHash}
+}
+
+// Record Writes the record line.
+func (s *JSONAuditSink) Record(ctx context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := auditEntry{AuditRecord: record, PrevHash: s.last}
+
+	var err error
+	entry.Hash, err = entry.hash()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.W.Write(append(data, '\n'))
+	if err != nil {
+		return err
+	}
+
+	s.last = entry.Hash
+	return nil
+}
+
+// VerifyAuditLog Checks the hash chain of a JSONAuditSink log, returning the hash of its last line.
+// Fails with ErrAuditTampered on the first line not matching its hash or the previous one.
+func VerifyAuditLog(r io.Reader) (lastHash string, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		var entry auditEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return "", fmt.Errorf("%w: line %d: %w", common.ErrAuditTampered, line, err)
+		}
+
+		if entry.PrevHash != lastHash {
+			return "", fmt.Errorf("%w: line %d: previous hash mismatch", common.ErrAuditTampered, line)
+		}
+
+		hash, err := entry.hash()
+		if err != nil || hash != entry.Hash {
+			return "", fmt.Errorf("%w: line %d: hash mismatch", common.ErrAuditTampered, line)
+		}
+
+		lastHash = entry.Hash
+	}
+
+	return lastHash, scanner.Err()
+}
@@ -0,0 +1,291 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+type auditFunc func(ctx context.Context, record AuditRecord) error
+
+func (f auditFunc) Record(ctx context.Context, record AuditRecord) error {
+	return f(ctx, record)
+}
+
+func TestAuditSink(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ultraocrtest.NewAutoClock(start)
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 2 * time.Second
+	client := newFakeClient(clock, api)
+
+	var log bytes.Buffer
+	client.SetAuditSink(NewJSONAuditSink(&log, ""))
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	metadata := map[string]any{"customer": "42"}
+	created, err := client.SubmitJob(ctx, JobRequest{Service: ServiceRG, Document: StringSource("doc", "doc"), Metadata: metadata})
+	if err != nil {
+		t.Fatalf("client.SubmitJob() error = %v", err)
+	}
+
+	_, err = client.WaitForJob(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log has %d lines, want 2 (only finished results are audited):\n%s", len(lines), log.String())
+	}
+
+	var records []AuditRecord
+	for _, line := range lines {
+		var record AuditRecord
+		err := json.Unmarshal([]byte(line), &record)
+		if err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+
+		records = append(records, record)
+	}
+
+	if records[0].Action != common.AUDIT_ACTION_SUBMIT || records[1].Action != common.AUDIT_ACTION_RESULT {
+		t.Errorf("actions = %q, %q, want submit, result", records[0].Action, records[1].Action)
+	}
+
+	for _, record := range records {
+		if record.Actor != "id" || record.ID != created.Id || record.Service != ServiceRG || record.RequestID != "req-1" {
+			t.Errorf("record = %+v, want actor id, ID %s, service rg and request ID req-1", record, created.Id)
+		}
+	}
+
+	if records[0].MetadataHash != metadataHash(map[string]any{"customer": "42"}) || records[0].MetadataHash == "" {
+		t.Errorf("metadata hash = %q, want the metadata hash", records[0].MetadataHash)
+	}
+
+	if records[1].Status != common.STATUS_DONE {
+		t.Errorf("result status = %q, want %q", records[1].Status, common.STATUS_DONE)
+	}
+
+	last, err := VerifyAuditLog(strings.NewReader(log.String()))
+	if err != nil || last == "" {
+		t.Errorf("VerifyAuditLog() = %q, %v, want the last hash", last, err)
+	}
+
+	t.Run("append", func(t *testing.T) {
+		var more bytes.Buffer
+		sink := NewJSONAuditSink(&more, last)
+		err := sink.Record(context.Background(), AuditRecord{Action: common.AUDIT_ACTION_SUBMIT, ID: "other"})
+		if err != nil {
+			t.Fatalf("sink.Record() error = %v", err)
+		}
+
+		_, err = VerifyAuditLog(strings.NewReader(log.String() + more.String()))
+		if err != nil {
+			t.Errorf("VerifyAuditLog() error = %v", err)
+		}
+	})
+
+	tests := []struct {
+		name string
+		log  string
+	}{
+		{name: "changed", log: strings.Replace(log.String(), `"actor":"id"`, `"actor":"other"`, 1)},
+		{name: "removed", log: lines[1] + "\n"},
+		{name: "reordered", log: lines[1] + "\n" + lines[0] + "\n"},
+		{name: "invalid", log: "{\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyAuditLog(strings.NewReader(tt.log))
+			if !errors.Is(err, common.ErrAuditTampered) {
+				t.Errorf("VerifyAuditLog() error = %v, want ErrAuditTampered", err)
+			}
+		})
+	}
+}
+
+func TestAuditSinkError(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+
+	sinkErr := errors.New("disk full")
+	client.SetAuditSink(auditFunc(func(ctx context.Context, record AuditRecord) error {
+		return sinkErr
+	}))
+
+	tests := []struct {
+		name          string
+		submit        func() (CreatedResponse, error)
+		wantSubmitted bool
+	}{
+		{
+			name: "submitted but not audited",
+			submit: func() (CreatedResponse, error) {
+				return client.SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG, Document: StringSource("batch", "batch")})
+			},
+			wantSubmitted: true,
+		},
+		{
+			name: "single step submitted but not audited",
+			submit: func() (CreatedResponse, error) {
+				return client.SendJobSingleStep(context.Background(), ServiceRG, "aGVsbG8=", "", "", nil, nil)
+			},
+			wantSubmitted: true,
+		},
+		{
+			name: "not submitted",
+			submit: func() (CreatedResponse, error) {
+				return client.SubmitJob(context.Background(), JobRequest{Service: ServiceRG})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := tt.submit()
+			if err == nil {
+				t.Fatal("submit error = nil, want an error")
+			}
+
+			submitted := errors.Is(err, common.ErrAuditFailed)
+			if submitted != tt.wantSubmitted || (created.Id != "") != tt.wantSubmitted {
+				t.Errorf("submit = %+v, %v, want submitted %v", created, err, tt.wantSubmitted)
+			}
+
+			if submitted && (!errors.Is(err, common.ErrAuditSink) || !errors.Is(err, sinkErr) || !strings.Contains(err.Error(), created.Id)) {
+				t.Errorf("submit error = %v, want the sink error and the created ID", err)
+			}
+		})
+	}
+
+	t.Run("queue", func(t *testing.T) {
+		dir := t.TempDir()
+		doc := filepath.Join(dir, "doc.pdf")
+		_ = os.WriteFile(doc, []byte("%PDF-1.4"), 0o600)
+
+		var sent atomic.Int32
+		client := client
+		client.SetStore(NewFileStore(filepath.Join(dir, "store")))
+		client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/ocr/job/") || req.Method == http.MethodPut {
+				sent.Add(1)
+			}
+			return api.Do(req)
+		}})
+
+		queue := NewJobQueue(&client, "audit")
+		queue.MaxAttempts = 2
+		job, err := queue.Add(context.Background(), QueuedJobRequest{Service: ServiceRG, FilePath: doc})
+		if err != nil {
+			t.Fatalf("JobQueue.Add() error = %v", err)
+		}
+
+		err = queue.Run(context.Background())
+		if err != nil {
+			t.Fatalf("JobQueue.Run() error = %v", err)
+		}
+
+		job, err = queue.Get(context.Background(), job.ID)
+		// a single creation and a single upload
+		if err != nil || job.JobID == "" || sent.Load() != 2 {
+			t.Errorf("JobQueue job = %+v, %v, sent %d requests, want 2", job, err, sent.Load())
+		}
+	})
+
+	jobID := api.AddJob("rg", "processing")
+	_, err := client.GetJobResult(context.Background(), jobID, jobID)
+	if err != nil {
+		t.Errorf("client.GetJobResult() error = %v, want unfinished results not audited", err)
+	}
+}
+
+func TestAuditResultRetrievals(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	jobID := api.AddJob("rg", common.STATUS_DONE)
+	processingID := api.AddJob("rg", "processing")
+
+	client := newFakeClient(clock, api)
+	client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/ocr/job/results") {
+			page := fmt.Sprintf(`{"jobs": [{"job_ksuid": %q, "status": "done"}, {"job_ksuid": %q, "status": "processing"}]}`, jobID, processingID)
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(page))}, nil
+		}
+
+		return api.Do(req)
+	}})
+
+	var records []AuditRecord
+	client.SetAuditSink(auditFunc(func(ctx context.Context, record AuditRecord) error {
+		records = append(records, record)
+		return nil
+	}))
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{name: "GetJobResult", call: func(ctx context.Context) error {
+			_, err := client.GetJobResult(ctx, jobID, jobID)
+			return err
+		}},
+		{name: "GetJobResultRaw", call: func(ctx context.Context) error {
+			_, err := client.GetJobResultRaw(ctx, jobID, jobID)
+			return err
+		}},
+		{name: "GetJobResultFields", call: func(ctx context.Context) error {
+			_, err := client.GetJobResultFields(ctx, jobID, jobID, "Nome")
+			return err
+		}},
+		{name: "GetFromStatusURL", call: func(ctx context.Context) error {
+			_, err := client.GetFromStatusURL(ctx, client.BaseURL+"/ocr/job/result/"+jobID)
+			return err
+		}},
+		{name: "WaitForJob", call: func(ctx context.Context) error {
+			_, err := client.WaitForJob(ctx, jobID)
+			return err
+		}},
+		{name: "GetJobs", call: func(ctx context.Context) error {
+			_, err := client.GetJobs(ctx, "2024-01-01", "2024-01-02")
+			return err
+		}},
+		{name: "GetJobsStream", call: func(ctx context.Context) error {
+			jobs, errs := client.GetJobsStream(ctx, "2024-01-01", "2024-01-02")
+			for range jobs {
+			}
+			return <-errs
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records = nil
+
+			err := tt.call(context.Background())
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+
+			if len(records) != 1 || records[0].ID != jobID || records[0].Action != common.AUDIT_ACTION_RESULT {
+				t.Errorf("records = %+v, want one result record of the finished job %s", records, jobID)
+			}
+		})
+	}
+}
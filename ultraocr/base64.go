@@ -0,0 +1,169 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// EncodeFileBase64 Reads a file encoded as base64, as taken by the base64 functions.
+func EncodeFileBase64(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", common.ErrReadFile
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// StripDataURI Returns the base64 data of a data URI, like "data:image/png;base64,iVBOR...",
+// sent by front-ends. Other data is returned unchanged.
+func StripDataURI(data string) string {
+	if !strings.HasPrefix(data, "data:") {
+		return data
+	}
+
+	header, encoded, found := strings.Cut(data, ",")
+	if !found || !strings.HasSuffix(header, ";base64") {
+		return data
+	}
+
+	return encoded
+}
+
+type base64Source struct {
+	src Source
+}
+
+// Base64Source Creates a source encoding another source as base64 while it is read, so the
+// encoded document is never held in memory. Its size is the encoded size, when known.
+func Base64Source(src Source) Source {
+	return base64Source{src: src}
+}
+
+func (s base64Source) Name() string {
+	return s.src.Name()
+}
+
+func (s base64Source) Size() int64 {
+	size := s.src.Size()
+	if size < 0 {
+		return -1
+	}
+
+	return int64(base64.StdEncoding.EncodedLen(int(size)))
+}
+
+// Open Opens the source, encoding it on a goroutine piped to the returned reader.
+func (s base64Source) Open() (io.ReadCloser, error) {
+	body, err := s.src.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		defer body.Close()
+
+		encoder := base64.NewEncoder(base64.StdEncoding, w)
+		_, err := io.Copy(encoder, body)
+		if err == nil {
+			err = encoder.Close()
+		}
+
+		w.CloseWithError(err)
+	}()
+
+	return r, nil
+}
+
+type readerSource struct {
+	name string
+	r    io.Reader
+	size int64
+	once *sync.Once
+}
+
+// ReaderSource Creates a source from a reader. The reader is read once, so later opens, like
+// upload retries or the checks reading the document before the upload, fail with ErrReadFile.
+// The size is known for readers with a Len method, like bytes.Reader, and for files.
+func ReaderSource(name string, r io.Reader) Source {
+	return readerSource{name: name, r: r, size: readerSize(r), once: &sync.Once{}}
+}
+
+// readerSize Returns the unread size of the reader, -1 when unknown.
+func readerSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil {
+			return -1
+		}
+
+		return info.Size()
+	default:
+		return -1
+	}
+}
+
+func (s readerSource) Name() string {
+	return s.name
+}
+
+func (s readerSource) Size() int64 {
+	return s.size
+}
+
+func (s readerSource) Open() (io.ReadCloser, error) {
+	opened := false
+	s.once.Do(func() { opened = true })
+	if !opened {
+		return nil, fmt.Errorf("%w: %s was already read", common.ErrReadFile, s.name)
+	}
+
+	return io.NopCloser(s.r), nil
+}
+
+// SendJobBase64Reader Sends a job on base64 format, encoding the files while they are uploaded,
+// so large files are not held in memory as raw bytes and as a base64 string.
+// Requires the service, the files (facematch and extra file if requested on params) readers
+// and the required metadata and query params. The readers are read once, so failed uploads are not retried.
+func (client *Client) SendJobBase64Reader(ctx context.Context,
+	service Service,
+	file,
+	facematchFile,
+	extraFile io.Reader,
+	metadata map[string]any,
+	params map[string]string,
+) (CreatedResponse, error) {
+	p := map[string]string{
+		common.KEY_BASE64: common.FLAG_TRUE,
+	}
+	maps.Copy(p, params)
+
+	return client.submitJob(ctx, JobRequest{
+		Service:       service,
+		Document:      base64ReaderSource("document", file),
+		Selfie:        base64ReaderSource("selfie", facematchFile),
+		ExtraDocument: base64ReaderSource("extra_document", extraFile),
+		Metadata:      metadata,
+	}, p)
+}
+
+// base64ReaderSource Creates a source encoding the reader, nil for nil readers.
+func base64ReaderSource(name string, r io.Reader) Source {
+	if r == nil {
+		return nil
+	}
+
+	return Base64Source(ReaderSource(name, r))
+}
@@ -0,0 +1,154 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestBase64Source(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      Source
+		wantSize int64
+		want     string
+		wantErr  bool
+	}{
+		{name: "string", src: StringSource("doc", "hello"), wantSize: 8, want: "aGVsbG8="},
+		{name: "empty", src: BytesSource("doc", nil), wantSize: 0, want: ""},
+		{name: "reader with len", src: ReaderSource("doc", strings.NewReader("hello")), wantSize: 8, want: "aGVsbG8="},
+		{name: "reader", src: ReaderSource("doc", iotest.HalfReader(strings.NewReader("hello"))), wantSize: -1, want: "aGVsbG8="},
+		{name: "failed read", src: ReaderSource("doc", iotest.ErrReader(errors.New("error"))), wantSize: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := Base64Source(tt.src)
+			if got := src.Size(); got != tt.wantSize {
+				t.Errorf("Base64Source.Size() = %v, want %v", got, tt.wantSize)
+			}
+
+			r, err := src.Open()
+			if err != nil {
+				t.Fatalf("Base64Source.Open() error = %v", err)
+			}
+
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Base64Source read error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("Base64Source read = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReaderSourceOnce(t *testing.T) {
+	src := ReaderSource("doc", strings.NewReader("hello"))
+	r, err := src.Open()
+	if err != nil {
+		t.Fatalf("ReaderSource.Open() error = %v", err)
+	}
+	r.Close()
+
+	_, err = src.Open()
+	if !errors.Is(err, common.ErrReadFile) {
+		t.Errorf("ReaderSource.Open() again error = %v, want %v", err, common.ErrReadFile)
+	}
+}
+
+func TestSendJobBase64Reader(t *testing.T) {
+	recorder := &submissionRecorder{}
+	client := recorder.client()
+	params := map[string]string{common.KEY_FACEMATCH: common.FLAG_TRUE}
+
+	_, err := client.SendJobBase64Reader(context.Background(), ServiceRG,
+		strings.NewReader("hello"), iotest.OneByteReader(strings.NewReader("selfie")), nil, nil, params)
+	if err != nil {
+		t.Fatalf("client.SendJobBase64Reader() error = %v", err)
+	}
+	if recorder.params.Get(common.KEY_BASE64) != common.FLAG_TRUE {
+		t.Errorf("client.SendJobBase64Reader() params = %v, want base64", recorder.params)
+	}
+	want := map[string]string{"/document": "aGVsbG8=", "/selfie": "c2VsZmll"}
+	if !reflect.DeepEqual(recorder.uploads, want) {
+		t.Errorf("client.SendJobBase64Reader() uploads = %v, want %v", recorder.uploads, want)
+	}
+}
+
+func TestStripDataURI(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "image", data: "data:image/png;base64,aGVsbG8=", want: "aGVsbG8="},
+		{name: "pdf", data: "data:application/pdf;base64,aGVsbG8=", want: "aGVsbG8="},
+		{name: "plain base64", data: "aGVsbG8=", want: "aGVsbG8="},
+		{name: "not base64", data: "data:text/plain,hello", want: "data:text/plain,hello"},
+		{name: "empty", data: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripDataURI(tt.data); got != tt.want {
+				t.Errorf("StripDataURI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeFileBase64(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.txt")
+	_ = os.WriteFile(path, []byte("hello"), 0o600)
+
+	got, err := EncodeFileBase64(path)
+	if err != nil || got != "aGVsbG8=" {
+		t.Errorf("EncodeFileBase64() = %v, %v, want aGVsbG8=", got, err)
+	}
+
+	_, err = EncodeFileBase64(path + "1")
+	if !errors.Is(err, common.ErrReadFile) {
+		t.Errorf("EncodeFileBase64() error = %v, want %v", err, common.ErrReadFile)
+	}
+}
+
+func TestDataURISubmissions(t *testing.T) {
+	var body map[string]any
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"id":"123"}`))}, nil
+			},
+		},
+	}
+
+	_, err := client.SendJobSingleStep(context.Background(), ServiceRG, "data:image/jpeg;base64,aGVsbG8=", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendJobSingleStep() error = %v", err)
+	}
+	if body["data"] != "aGVsbG8=" {
+		t.Errorf("client.SendJobSingleStep() data = %v, want aGVsbG8=", body["data"])
+	}
+
+	recorder := &submissionRecorder{}
+	client = recorder.client()
+	err = client.UploadFileBase64(context.Background(), "https://bucket/document", "data:image/png;base64,aGVsbG8=")
+	if err != nil {
+		t.Fatalf("client.UploadFileBase64() error = %v", err)
+	}
+	if recorder.uploads["/document"] != "aGVsbG8=" {
+		t.Errorf("client.UploadFileBase64() uploaded %q, want aGVsbG8=", recorder.uploads["/document"])
+	}
+}
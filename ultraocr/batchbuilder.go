@@ -0,0 +1,218 @@
+package ultraocr
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// BatchBuilder Builds a batch from many documents, packed on a zip archive with an entry per
+// document, in the order they were added, and the metadata of each document on the same position.
+// The entries are prefixed with their position, so documents with the same name don't collide.
+// Batches over MaxDocuments (default 100) or MaxBytes (default 100MiB) are split on Send.
+type BatchBuilder struct {
+	Service      Service
+	Options      JobOptions
+	MaxDocuments int
+	MaxBytes     int64
+
+	documents []Source
+	metadata  []map[string]any
+}
+
+// NewBatchBuilder Creates an empty batch builder for the service.
+func NewBatchBuilder(service Service) *BatchBuilder {
+	return &BatchBuilder{Service: service}
+}
+
+// AddSource Adds a document with its metadata (nil for none).
+func (b *BatchBuilder) AddSource(src Source, metadata map[string]any) *BatchBuilder {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	b.documents = append(b.documents, src)
+	b.metadata = append(b.metadata, metadata)
+	return b
+}
+
+// AddFile Adds a document from the file path with its metadata (nil for none).
+func (b *BatchBuilder) AddFile(path string, metadata map[string]any) *BatchBuilder {
+	return b.AddSource(FileSource(path), metadata)
+}
+
+// AddReader Adds a document read from the reader with its metadata (nil for none).
+// The reader is read once, when the batch is written.
+func (b *BatchBuilder) AddReader(name string, r io.Reader, metadata map[string]any) *BatchBuilder {
+	return b.AddSource(ReaderSource(name, r), metadata)
+}
+
+// Len Returns how many documents were added.
+func (b *BatchBuilder) Len() int {
+	return len(b.documents)
+}
+
+// Metadata Returns the metadata of the documents, in the order they were added.
+func (b *BatchBuilder) Metadata() []map[string]any {
+	return b.metadata
+}
+
+// WriteTo Writes the batch zip archive.
+func (b *BatchBuilder) WriteTo(w io.Writer) (int64, error) {
+	if len(b.documents) == 0 {
+		return 0, fmt.Errorf("%w: empty batch", common.ErrMissingDocument)
+	}
+
+	counter := &countingWriter{w: w}
+	archive := zip.NewWriter(counter)
+	for i, src := range b.documents {
+		entry, err := archive.Create(fmt.Sprintf("%04d_%s", i+1, src.Name()))
+		if err != nil {
+			return counter.n, fmt.Errorf("%w: %w", common.ErrReadFile, err)
+		}
+
+		err = copySource(entry, src)
+		if err != nil {
+			return counter.n, err
+		}
+	}
+
+	err := archive.Close()
+	if err != nil {
+		return counter.n, fmt.Errorf("%w: %w", common.ErrReadFile, err)
+	}
+
+	return counter.n, nil
+}
+
+// Send Writes the batch to a temporary file and sends it, like SendBatch, removing the file after.
+// The file allows the upload to know its size and to be retried. With the Base64 option, the
+// archive is encoded while uploaded.
+// Batches over the limits are split and sent as many batches, returning a composite ID, understood
+// by WaitForBatchDone, and the response of each batch on Batches. When a batch fails, the response
+// has the batches already sent.
+func (b *BatchBuilder) Send(ctx context.Context, client *Client) (CreatedResponse, error) {
+	parts := b.split()
+	if len(parts) == 1 {
+		return b.send(ctx, client)
+	}
+
+	created := CreatedResponse{}
+	ids := []string{}
+	for i, part := range parts {
+		res, err := part.send(ctx, client)
+		if err != nil {
+			return created, fmt.Errorf("batch %d of %d: %w", i+1, len(parts), err)
+		}
+
+		ids = append(ids, res.Id)
+		created.Batches = append(created.Batches, res)
+		created.Id = CompositeBatchID(ids...)
+	}
+
+	return created, nil
+}
+
+// split Splits the documents on builders within the limits, keeping their order.
+// Each part idempotency key has its position appended, so the parts are not taken as duplicates.
+func (b *BatchBuilder) split() []*BatchBuilder {
+	maxDocuments := b.MaxDocuments
+	if maxDocuments <= 0 {
+		maxDocuments = common.BATCH_MAX_DOCUMENTS
+	}
+
+	maxBytes := b.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = common.BATCH_MAX_BYTES
+	}
+
+	parts := []*BatchBuilder{}
+	var part *BatchBuilder
+	var size int64
+	for i, src := range b.documents {
+		docSize := max(src.Size(), 0)
+		if part == nil || part.Len() >= maxDocuments || (part.Len() > 0 && size+docSize > maxBytes) {
+			part = &BatchBuilder{Service: b.Service, Options: b.Options}
+			if b.Options.IdempotencyKey != "" {
+				part.Options.IdempotencyKey = fmt.Sprintf("%s-%d", b.Options.IdempotencyKey, len(parts)+1)
+			}
+			parts = append(parts, part)
+			size = 0
+		}
+
+		part.AddSource(src, b.metadata[i])
+		size += docSize
+	}
+
+	if len(parts) == 0 {
+		return []*BatchBuilder{b}
+	}
+
+	return parts
+}
+
+// send Sends the documents as a single batch.
+func (b *BatchBuilder) send(ctx context.Context, client *Client) (CreatedResponse, error) {
+	f, err := os.CreateTemp("", "ultraocr-batch-*.zip")
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	defer os.Remove(f.Name())
+
+	_, err = b.WriteTo(f)
+	if err != nil {
+		f.Close()
+		return CreatedResponse{}, err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	document := FileSource(f.Name())
+	if b.Options.Base64 {
+		document = Base64Source(document)
+	}
+
+	return client.SubmitBatch(ctx, BatchRequest{
+		Service:  b.Service,
+		Document: document,
+		Metadata: b.metadata,
+		Options:  b.Options,
+	})
+}
+
+// copySource Copies the source content to the writer.
+func copySource(w io.Writer, src Source) error {
+	body, err := src.Open()
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	_, err = io.Copy(w, body)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", common.ErrReadFile, src.Name(), err)
+	}
+
+	return nil
+}
+
+// countingWriter Counts the bytes written.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,98 @@
+package ultraocr
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// readBatchZip Returns the entries of a batch archive with their content.
+func readBatchZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	entries := map[string]string{}
+	for _, file := range archive.File {
+		r, _ := file.Open()
+		content, _ := io.ReadAll(r)
+		r.Close()
+		entries[file.Name] = string(content)
+	}
+
+	return entries
+}
+
+func TestBatchBuilder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.pdf")
+	_ = os.WriteFile(path, []byte("file"), 0o600)
+
+	builder := NewBatchBuilder(ServiceRG).
+		AddFile(path, map[string]any{"id": 1}).
+		AddReader("doc.pdf", strings.NewReader("reader"), nil).
+		AddSource(BytesSource("photo.jpg", []byte("bytes")), map[string]any{"id": 3})
+
+	if builder.Len() != 3 {
+		t.Errorf("BatchBuilder.Len() = %v, want 3", builder.Len())
+	}
+	wantMetadata := []map[string]any{{"id": 1}, {}, {"id": 3}}
+	if !reflect.DeepEqual(builder.Metadata(), wantMetadata) {
+		t.Errorf("BatchBuilder.Metadata() = %v, want %v", builder.Metadata(), wantMetadata)
+	}
+
+	var buf bytes.Buffer
+	n, err := builder.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("BatchBuilder.WriteTo() error = %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("BatchBuilder.WriteTo() = %v, wrote %v bytes", n, buf.Len())
+	}
+	want := map[string]string{"0001_doc.pdf": "file", "0002_doc.pdf": "reader", "0003_photo.jpg": "bytes"}
+	if got := readBatchZip(t, buf.Bytes()); !reflect.DeepEqual(got, want) {
+		t.Errorf("BatchBuilder.WriteTo() entries = %v, want %v", got, want)
+	}
+
+	_, err = NewBatchBuilder(ServiceRG).WriteTo(io.Discard)
+	if !errors.Is(err, common.ErrMissingDocument) {
+		t.Errorf("BatchBuilder.WriteTo() error = %v, want %v", err, common.ErrMissingDocument)
+	}
+
+	_, err = NewBatchBuilder(ServiceRG).AddFile(path+"1", nil).WriteTo(io.Discard)
+	if !errors.Is(err, common.ErrReadFile) {
+		t.Errorf("BatchBuilder.WriteTo() error = %v, want %v", err, common.ErrReadFile)
+	}
+}
+
+func TestBatchBuilderSend(t *testing.T) {
+	recorder := &submissionRecorder{}
+	client := recorder.client()
+
+	res, err := NewBatchBuilder(ServiceRG).
+		AddSource(StringSource("a.pdf", "a"), nil).
+		AddSource(StringSource("b.pdf", "b"), nil).
+		Send(context.Background(), client)
+	if err != nil {
+		t.Fatalf("BatchBuilder.Send() error = %v", err)
+	}
+	if res.Id != "123" {
+		t.Errorf("BatchBuilder.Send() = %v, want id 123", res)
+	}
+
+	want := map[string]string{"0001_a.pdf": "a", "0002_b.pdf": "b"}
+	if got := readBatchZip(t, []byte(recorder.uploads["/document"])); !reflect.DeepEqual(got, want) {
+		t.Errorf("BatchBuilder.Send() uploaded entries = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package ultraocr
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// CompositeBatchID Joins the IDs of batches split from a single batch on a composite ID,
+// understood by WaitForBatchDone.
+func CompositeBatchID(ids ...string) string {
+	return strings.Join(ids, common.BATCH_ID_SEPARATOR)
+}
+
+// SplitBatchID Returns the batch IDs of a composite ID, or the ID itself for a single batch.
+func SplitBatchID(id string) []string {
+	return strings.Split(id, common.BATCH_ID_SEPARATOR)
+}
+
+// isCompositeBatchID Checks if the ID joins many batches.
+func isCompositeBatchID(id string) bool {
+	return strings.Contains(id, common.BATCH_ID_SEPARATOR)
+}
+
+// waitCompositeBatch Waits the batches of a composite ID concurrently under one Client timeout,
+// canceling the others on the first failure. Their status are merged: the jobs of all
+// batches, in order, and the error or cancelled status of any batch, otherwise done.
+func (client *Client) waitCompositeBatch(ctx context.Context, id string, waitJobs bool) (BatchStatusResponse, error) {
+	ids := SplitBatchID(id)
+	err := validateIDs(ids...)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	// the parts share one deadline, so the Client timeout bounds the whole composite wait
+	poller := client.NewPoller(common.RESOURCE_BATCH, id)
+	if timeout := poller.timeout(ctx); timeout > 0 {
+		ctx = withPollDeadline(ctx, poller.clock().Now().Add(timeout))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	statuses := make([]BatchStatusResponse, len(ids))
+	for i, batchID := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			status, err := client.WaitForBatchDone(ctx, batchID, waitJobs)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+
+			statuses[i] = status
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return BatchStatusResponse{}, firstErr
+	}
+
+	merged := BatchStatusResponse{
+		BatchID:   id,
+		Status:    StatusDone,
+		CreatedAt: statuses[0].CreatedAt,
+		Service:   statuses[0].Service,
+		Jobs:      []BatchStatusJobs{},
+	}
+	for _, status := range statuses {
+		switch {
+		case status.Status.IsError():
+			merged.Status = StatusError
+		case status.Status.IsCancelled() && !merged.Status.IsError():
+			merged.Status = StatusCancelled
+		}
+
+		merged.Jobs = append(merged.Jobs, status.Jobs...)
+	}
+
+	return merged, nil
+}
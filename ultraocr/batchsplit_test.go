@@ -0,0 +1,135 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestBatchBuilderSplit(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxDocuments int
+		maxBytes     int64
+		sizes        []int
+		wantBatches  int
+	}{
+		{name: "within limits", sizes: []int{1, 1, 1}, wantBatches: 1},
+		{name: "by documents", maxDocuments: 2, sizes: []int{1, 1, 1, 1, 1}, wantBatches: 3},
+		{name: "by bytes", maxBytes: 10, sizes: []int{6, 4, 1, 9, 3}, wantBatches: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			api := ultraocrtest.NewFakeAPI(clock)
+			api.ProcessingTime = 10 * time.Second
+			api.JobsPerBatch = 2
+			client := newFakeClient(clock, api)
+
+			builder := NewBatchBuilder(ServiceRG)
+			builder.MaxDocuments = tt.maxDocuments
+			builder.MaxBytes = tt.maxBytes
+			for _, size := range tt.sizes {
+				builder.AddSource(BytesSource("doc.pdf", make([]byte, size)), nil)
+			}
+
+			created, err := builder.Send(context.Background(), &client)
+			if err != nil {
+				t.Fatalf("BatchBuilder.Send() error = %v", err)
+			}
+
+			ids := SplitBatchID(created.Id)
+			if len(ids) != tt.wantBatches {
+				t.Fatalf("BatchBuilder.Send() batches = %v, want %v", ids, tt.wantBatches)
+			}
+			if tt.wantBatches > 1 && len(created.Batches) != tt.wantBatches {
+				t.Errorf("BatchBuilder.Send() responses = %v, want %v", len(created.Batches), tt.wantBatches)
+			}
+
+			status, err := client.WaitForBatchDone(context.Background(), created.Id, true)
+			if err != nil {
+				t.Fatalf("client.WaitForBatchDone() error = %v", err)
+			}
+			if status.Status != StatusDone || len(status.Jobs) != 2*tt.wantBatches {
+				t.Errorf("client.WaitForBatchDone() = %v with %d jobs, want done with %d", status.Status, len(status.Jobs), 2*tt.wantBatches)
+			}
+		})
+	}
+}
+
+func TestBatchBuilderSplitIdempotencyKey(t *testing.T) {
+	builder := NewBatchBuilder(ServiceRG)
+	builder.MaxDocuments = 1
+	builder.Options.IdempotencyKey = "upload-7"
+	builder.AddSource(BytesSource("a.pdf", []byte("a")), nil).AddSource(BytesSource("b.pdf", []byte("b")), nil)
+
+	keys := []string{}
+	for _, part := range builder.split() {
+		keys = append(keys, part.Options.IdempotencyKey)
+	}
+	if want := []string{"upload-7-1", "upload-7-2"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("BatchBuilder.split() idempotency keys = %v, want %v", keys, want)
+	}
+}
+
+func TestCompositeBatchStatus(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 10 * time.Second
+	client := newFakeClient(clock, api)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		created, err := client.SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG, Document: StringSource("doc", "doc")})
+		if err != nil {
+			t.Fatalf("client.SubmitBatch() error = %v", err)
+		}
+		ids = append(ids, created.Id)
+	}
+
+	err := client.CancelBatch(context.Background(), ids[1])
+	if err != nil {
+		t.Fatalf("client.CancelBatch() error = %v", err)
+	}
+
+	status, err := client.WaitForBatchDone(context.Background(), CompositeBatchID(ids...), false)
+	if err != nil {
+		t.Fatalf("client.WaitForBatchDone() error = %v", err)
+	}
+	if status.Status != StatusCancelled || status.BatchID != ids[0]+common.BATCH_ID_SEPARATOR+ids[1] {
+		t.Errorf("client.WaitForBatchDone() = %v %v, want cancelled composite", status.BatchID, status.Status)
+	}
+
+	_, err = client.WaitForBatchDone(context.Background(), ids[0]+",invalid", false)
+	if err == nil {
+		t.Errorf("client.WaitForBatchDone() with an invalid ID error = nil")
+	}
+}
+
+func TestCompositeBatchTimeout(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+	client.SetTimeout(100)
+
+	// each batch alone finishes within the timeout, but the second not within the composite one
+	var ids []string
+	for _, processing := range []time.Duration{80 * time.Second, 160 * time.Second} {
+		api.ProcessingTime = processing
+		created, err := client.SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG, Document: StringSource("doc", "doc")})
+		if err != nil {
+			t.Fatalf("client.SubmitBatch() error = %v", err)
+		}
+		ids = append(ids, created.Id)
+	}
+
+	_, err := client.WaitForBatchDone(context.Background(), CompositeBatchID(ids...), false)
+	if !errors.Is(err, common.ErrTimeout) {
+		t.Errorf("client.WaitForBatchDone() error = %v, want %v", err, common.ErrTimeout)
+	}
+}
@@ -0,0 +1,28 @@
+package brazil
+
+import (
+	"regexp"
+	"time"
+)
+
+var dateRegex = regexp.MustCompile(`^\s*(\d{2})[/.-](\d{2})[/.-](\d{4})\s*$`)
+
+// IsDate Checks if a value looks like a dd/mm/yyyy date.
+func IsDate(value string) bool {
+	return dateRegex.MatchString(value)
+}
+
+// ParseDate Parses a dd/mm/yyyy date (also accepting "-" and "." separators) as UTC.
+func ParseDate(value string) (time.Time, error) {
+	parts := dateRegex.FindStringSubmatch(value)
+	if parts == nil {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	date, err := time.Parse("02/01/2006", parts[1]+"/"+parts[2]+"/"+parts[3])
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	return date, nil
+}
@@ -0,0 +1,154 @@
+// Package brazil implements normalizers and validators for Brazilian formats found on UltraOCR results.
+package brazil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Brazilian documents errors.
+var (
+	ErrInvalidCPF  = errors.New("invalid CPF")
+	ErrInvalidCNPJ = errors.New("invalid CNPJ")
+	ErrInvalidRG   = errors.New("invalid RG")
+	ErrInvalidDate = errors.New("invalid date")
+)
+
+const (
+	cpfLength   = 11
+	cnpjLength  = 14
+	rgMinLength = 5
+	rgMaxLength = 14
+	rgSPLength  = 9
+)
+
+// OnlyDigits Removes every non digit character of a value.
+func OnlyDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// ValidCPF Checks the length and check digits of a CPF, formatted or not.
+func ValidCPF(value string) bool {
+	digits := OnlyDigits(value)
+	if len(digits) != cpfLength || repeated(digits) {
+		return false
+	}
+
+	first := checkDigit(digits[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2})
+	second := checkDigit(digits[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+
+	return digits[9] == first && digits[10] == second
+}
+
+// FormatCPF Formats a valid CPF as 000.000.000-00.
+func FormatCPF(value string) (string, error) {
+	if !ValidCPF(value) {
+		return "", ErrInvalidCPF
+	}
+
+	d := OnlyDigits(value)
+	return fmt.Sprintf("%s.%s.%s-%s", d[:3], d[3:6], d[6:9], d[9:]), nil
+}
+
+// ValidCNPJ Checks the length and check digits of a CNPJ, formatted or not.
+func ValidCNPJ(value string) bool {
+	digits := OnlyDigits(value)
+	if len(digits) != cnpjLength || repeated(digits) {
+		return false
+	}
+
+	first := checkDigit(digits[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	second := checkDigit(digits[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+
+	return digits[12] == first && digits[13] == second
+}
+
+// FormatCNPJ Formats a valid CNPJ as 00.000.000/0000-00.
+func FormatCNPJ(value string) (string, error) {
+	if !ValidCNPJ(value) {
+		return "", ErrInvalidCNPJ
+	}
+
+	d := OnlyDigits(value)
+	return fmt.Sprintf("%s.%s.%s/%s-%s", d[:2], d[2:5], d[5:8], d[8:12], d[12:]), nil
+}
+
+// ValidRG Checks the length of a RG, formatted or not.
+// RG numbers are issued by each state with its own rules, so only the length is checked,
+// use ValidRGSP to also check the São Paulo check digit.
+func ValidRG(value string) bool {
+	rg := rgCharacters(value)
+	return len(rg) >= rgMinLength && len(rg) <= rgMaxLength
+}
+
+// ValidRGSP Checks the length and check digit of a RG issued by São Paulo.
+func ValidRGSP(value string) bool {
+	rg := rgCharacters(value)
+	if len(rg) != rgSPLength {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 8; i++ {
+		if rg[i] < '0' || rg[i] > '9' {
+			return false
+		}
+		sum += int(rg[i]-'0') * (i + 2)
+	}
+
+	var want byte
+	switch digit := 11 - sum%11; digit {
+	case 10:
+		want = 'X'
+	case 11:
+		want = '0'
+	default:
+		want = byte('0' + digit)
+	}
+
+	return rg[8] == want
+}
+
+// rgCharacters Keeps the digits and the "X" check digit of a RG.
+func rgCharacters(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if (r >= '0' && r <= '9') || r == 'X' {
+			b.WriteRune(r)
+		}
+	}
+
+	rg := b.String()
+	if strings.Contains(strings.TrimSuffix(rg, "X"), "X") {
+		return ""
+	}
+
+	return rg
+}
+
+// checkDigit Computes a modulo 11 check digit using the given weights.
+func checkDigit(digits string, weights []int) byte {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+
+	return byte('0' + 11 - rest)
+}
+
+func repeated(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
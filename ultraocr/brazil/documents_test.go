@@ -0,0 +1,94 @@
+package brazil
+
+import (
+	"testing"
+)
+
+func TestFormatCPF(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "digits only",
+			value: "52998224725",
+			want:  "529.982.247-25",
+		},
+		{
+			name:  "already formatted",
+			value: "529.982.247-25",
+			want:  "529.982.247-25",
+		},
+		{
+			name:    "invalid check digit",
+			value:   "52998224724",
+			wantErr: true,
+		},
+		{
+			name:    "repeated digits",
+			value:   "11111111111",
+			wantErr: true,
+		},
+		{
+			name:    "invalid length",
+			value:   "5299822472",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatCPF(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatCPF() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FormatCPF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCNPJ(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "digits only",
+			value: "11222333000181",
+			want:  "11.222.333/0001-81",
+		},
+		{
+			name:  "already formatted",
+			value: "11.222.333/0001-81",
+			want:  "11.222.333/0001-81",
+		},
+		{
+			name:    "invalid check digit",
+			value:   "11222333000182",
+			wantErr: true,
+		},
+		{
+			name:    "invalid length",
+			value:   "1122233300018",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatCNPJ(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatCNPJ() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FormatCNPJ() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package brazil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+// Keys used on result fields.
+const (
+	KeyValue      = "value"
+	KeyNormalized = "normalized"
+	KeyValid      = "valid"
+)
+
+// NormalizeDates Creates a transformer that parses every dd/mm/yyyy field value,
+// saving the time.Time on the field "normalized" key.
+func NormalizeDates() ultraocr.ResultTransformer {
+	return func(result *ultraocr.JobResultResponse) error {
+		walkFields(result.Result.Document, func(name string, field map[string]any) {
+			value, ok := field[KeyValue].(string)
+			if !ok || !IsDate(value) {
+				return
+			}
+
+			date, err := ParseDate(value)
+			if err == nil {
+				field[KeyNormalized] = date
+			}
+		})
+
+		return nil
+	}
+}
+
+// NormalizeDocuments Creates a transformer that formats every valid CPF and CNPJ field value,
+// saving the formatted document on the field "normalized" key.
+// Fields are recognized by name, see DocumentKind.
+func NormalizeDocuments() ultraocr.ResultTransformer {
+	return func(result *ultraocr.JobResultResponse) error {
+		walkFields(result.Result.Document, func(name string, field map[string]any) {
+			value, ok := field[KeyValue].(string)
+			if !ok {
+				return
+			}
+
+			var formatted string
+			var err error
+			switch DocumentKind(name) {
+			case KindCPF:
+				formatted, err = FormatCPF(value)
+			case KindCNPJ:
+				formatted, err = FormatCNPJ(value)
+			default:
+				return
+			}
+
+			if err == nil {
+				field[KeyNormalized] = formatted
+			}
+		})
+
+		return nil
+	}
+}
+
+// Document kinds recognized by field name.
+const (
+	KindUnknown = ""
+	KindCPF     = "cpf"
+	KindCNPJ    = "cnpj"
+	KindRG      = "rg"
+)
+
+// DocumentKind Recognizes the document kind of a field by its name words,
+// e.g. "CPF", "CompanyCNPJ", "numero_rg" or "RGNumber".
+func DocumentKind(name string) string {
+	for _, word := range splitWords(name) {
+		switch word {
+		case KindCPF, KindCNPJ, KindRG:
+			return word
+		}
+	}
+
+	return KindUnknown
+}
+
+// splitWords Splits a field name on separators and camel case boundaries, in lower case.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := []string{}
+	start := -1
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+
+	return words
+}
+
+// walkFields Calls fn for every field (an object with a "value" key) found on a result document.
+func walkFields(doc any, fn func(name string, field map[string]any)) {
+	switch doc := doc.(type) {
+	case []any:
+		for _, item := range doc {
+			walkFields(item, fn)
+		}
+	case []map[string]any:
+		for _, item := range doc {
+			walkFields(item, fn)
+		}
+	case map[string]any:
+		for name, item := range doc {
+			if field, ok := item.(map[string]any); ok {
+				if _, isField := field[KeyValue]; isField {
+					fn(name, field)
+					continue
+				}
+			}
+
+			walkFields(item, fn)
+		}
+	}
+}
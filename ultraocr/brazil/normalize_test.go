@@ -0,0 +1,88 @@
+package brazil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "slashes",
+			value: "31/12/2023",
+			want:  time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "dashes",
+			value: "01-02-2024",
+			want:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid day",
+			value:   "32/12/2023",
+			wantErr: true,
+		},
+		{
+			name:    "iso format",
+			value:   "2023-12-31",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizers(t *testing.T) {
+	var result ultraocr.JobResultResponse
+	err := json.Unmarshal([]byte(`{"result":{"Document":[{"Page":1,"Data":{
+		"BirthDate":{"conf":99,"value":"31/12/1990"},
+		"CPF":{"conf":99,"value":"52998224725"},
+		"CompanyCNPJ":{"conf":99,"value":"11222333000181"},
+		"OtherCPF":{"conf":99,"value":"123"},
+		"Name":{"conf":99,"value":"Maria"}
+	}}]}}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, transformer := range []ultraocr.ResultTransformer{NormalizeDates(), NormalizeDocuments()} {
+		err = transformer(&result)
+		if err != nil {
+			t.Fatalf("transformer() error = %v", err)
+		}
+	}
+
+	data := result.Result.Document.([]any)[0].(map[string]any)["Data"].(map[string]any)
+	want := map[string]any{
+		"BirthDate":   time.Date(1990, 12, 31, 0, 0, 0, 0, time.UTC),
+		"CPF":         "529.982.247-25",
+		"CompanyCNPJ": "11.222.333/0001-81",
+		"OtherCPF":    nil,
+		"Name":        nil,
+	}
+	for name, normalized := range want {
+		got := data[name].(map[string]any)[KeyNormalized]
+		if !reflect.DeepEqual(got, normalized) {
+			t.Errorf("%s normalized = %v, want %v", name, got, normalized)
+		}
+	}
+}
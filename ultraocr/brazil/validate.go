@@ -0,0 +1,72 @@
+package brazil
+
+import (
+	"sort"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+// FieldValidity Validation of a document field found on a result.
+type FieldValidity struct {
+	Name  string
+	Kind  string
+	Value string
+	Valid bool
+}
+
+// ValidDocument Validates a document value of the given kind.
+func ValidDocument(kind, value string) bool {
+	switch kind {
+	case KindCPF:
+		return ValidCPF(value)
+	case KindCNPJ:
+		return ValidCNPJ(value)
+	case KindRG:
+		return ValidRG(value)
+	default:
+		return false
+	}
+}
+
+// ValidateResult Validates every CPF, CNPJ and RG field found on a result, sorted by field name.
+func ValidateResult(result ultraocr.JobResultResponse) []FieldValidity {
+	validities := []FieldValidity{}
+	walkFields(result.Result.Document, func(name string, field map[string]any) {
+		kind := DocumentKind(name)
+		value, ok := field[KeyValue].(string)
+		if kind == KindUnknown || !ok {
+			return
+		}
+
+		validities = append(validities, FieldValidity{
+			Name:  name,
+			Kind:  kind,
+			Value: value,
+			Valid: ValidDocument(kind, value),
+		})
+	})
+
+	sort.SliceStable(validities, func(i, j int) bool {
+		return validities[i].Name < validities[j].Name
+	})
+
+	return validities
+}
+
+// AnnotateValidity Creates a transformer that saves the validity of every CPF, CNPJ and RG field
+// on the field "valid" key.
+func AnnotateValidity() ultraocr.ResultTransformer {
+	return func(result *ultraocr.JobResultResponse) error {
+		walkFields(result.Result.Document, func(name string, field map[string]any) {
+			kind := DocumentKind(name)
+			value, ok := field[KeyValue].(string)
+			if kind == KindUnknown || !ok {
+				return
+			}
+
+			field[KeyValid] = ValidDocument(kind, value)
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package brazil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func TestValidRGSP(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{
+			name:  "formatted",
+			value: "24.678.131-2",
+			want:  true,
+		},
+		{
+			name:  "check digit X",
+			value: "10000006x",
+			want:  true,
+		},
+		{
+			name:  "invalid check digit",
+			value: "246781313",
+		},
+		{
+			name:  "invalid length",
+			value: "2467813",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidRGSP(tt.value); got != tt.want {
+				t.Errorf("ValidRGSP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentKind(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "CPF", want: KindCPF},
+		{name: "cpfTitular", want: KindCPF},
+		{name: "CompanyCNPJ", want: KindCNPJ},
+		{name: "numero_rg", want: KindRG},
+		{name: "RGNumber", want: KindRG},
+		{name: "OrgaoEmissor", want: KindUnknown},
+		{name: "Name", want: KindUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DocumentKind(tt.name); got != tt.want {
+				t.Errorf("DocumentKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResult(t *testing.T) {
+	result := ultraocr.JobResultResponse{
+		Result: ultraocr.Result{
+			Document: []map[string]any{
+				{
+					"Page": 1,
+					"Data": map[string]any{
+						"CPF":          map[string]any{"value": "529.982.247-25"},
+						"CNPJ":         map[string]any{"value": "11222333000182"},
+						"RG":           map[string]any{"value": "12.345.678-9"},
+						"OrgaoEmissor": map[string]any{"value": "SSP"},
+					},
+				},
+			},
+		},
+	}
+
+	want := []FieldValidity{
+		{Name: "CNPJ", Kind: KindCNPJ, Value: "11222333000182", Valid: false},
+		{Name: "CPF", Kind: KindCPF, Value: "529.982.247-25", Valid: true},
+		{Name: "RG", Kind: KindRG, Value: "12.345.678-9", Valid: true},
+	}
+	if got := ValidateResult(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateResult() = %v, want %v", got, want)
+	}
+
+	err := AnnotateValidity()(&result)
+	if err != nil {
+		t.Fatalf("AnnotateValidity() error = %v", err)
+	}
+
+	data := result.Result.Document.([]map[string]any)[0]["Data"].(map[string]any)
+	if data["CPF"].(map[string]any)[KeyValid] != true {
+		t.Errorf("CPF valid = %v, want true", data["CPF"].(map[string]any)[KeyValid])
+	}
+	if data["CNPJ"].(map[string]any)[KeyValid] != false {
+		t.Errorf("CNPJ valid = %v, want false", data["CNPJ"].(map[string]any)[KeyValid])
+	}
+	if _, ok := data["OrgaoEmissor"].(map[string]any)[KeyValid]; ok {
+		t.Errorf("OrgaoEmissor must not be annotated")
+	}
+}
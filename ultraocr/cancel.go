@@ -0,0 +1,42 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CancelJob Cancels a job not finished yet, so its status becomes cancelled.
+// Requires the job ID.
+func (client *Client) CancelJob(ctx context.Context, jobID string) error {
+	err := ValidateID(jobID)
+	if err != nil {
+		return err
+	}
+
+	return client.cancel(ctx, fmt.Sprintf("%s/ocr/job/cancel/%s", client.BaseURL, jobID))
+}
+
+// CancelBatch Cancels a batch not finished yet and its pending jobs, so their status becomes cancelled.
+// Requires the batch ID.
+func (client *Client) CancelBatch(ctx context.Context, batchID string) error {
+	err := ValidateID(batchID)
+	if err != nil {
+		return err
+	}
+
+	return client.cancel(ctx, fmt.Sprintf("%s/ocr/batch/cancel/%s", client.BaseURL, batchID))
+}
+
+func (client *Client) cancel(ctx context.Context, url string) error {
+	response, err := client.post(ctx, url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.status != http.StatusOK && response.status != http.StatusNoContent {
+		return response.apiError()
+	}
+
+	return nil
+}
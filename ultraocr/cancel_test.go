@@ -0,0 +1,75 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestCancelJob(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = time.Hour
+	client := newFakeClient(clock, api)
+
+	created, err := client.SendJobSingleStep(context.Background(), "rg", "ZmlsZQ==", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendJobSingleStep() error = %v", err)
+	}
+
+	err = client.CancelJob(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.CancelJob() error = %v", err)
+	}
+
+	result, err := client.WaitForJob(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+	if !result.Status.IsCancelled() {
+		t.Errorf("client.WaitForJob() status = %v, want %v", result.Status, StatusCancelled)
+	}
+
+	err = client.CancelJob(context.Background(), created.Id)
+	if !errors.Is(err, common.ErrInvalidStatusCode) {
+		t.Errorf("client.CancelJob() finished job error = %v, want %v", err, common.ErrInvalidStatusCode)
+	}
+
+	err = client.CancelJob(context.Background(), "../job")
+	if !errors.Is(err, common.ErrInvalidID) {
+		t.Errorf("client.CancelJob() error = %v, want %v", err, common.ErrInvalidID)
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = time.Hour
+	api.JobsPerBatch = 3
+	client := newFakeClient(clock, api)
+
+	created, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_BATCH, nil, nil)
+	if err != nil {
+		t.Fatalf("client.GenerateSignedUrl() error = %v", err)
+	}
+
+	err = client.CancelBatch(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.CancelBatch() error = %v", err)
+	}
+
+	status, err := client.WaitForBatchDone(context.Background(), created.Id, true)
+	if err != nil {
+		t.Fatalf("client.WaitForBatchDone() error = %v", err)
+	}
+	if !status.Status.IsCancelled() {
+		t.Errorf("client.WaitForBatchDone() status = %v, want %v", status.Status, StatusCancelled)
+	}
+	if summary := status.Summary(); summary.Counts[StatusCancelled] != 3 || summary.Finished() != 3 {
+		t.Errorf("BatchStatusResponse.Summary() = %+v, want 3 cancelled jobs", summary)
+	}
+}
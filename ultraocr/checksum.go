@@ -0,0 +1,74 @@
+package ultraocr
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetUploadChecksums Changes if the uploads compute the MD5 and SHA-256 checksums of the sources,
+// returned to compare with the storage. The source is read once more to compute them (Default false).
+// No header is sent unless set by SetUploadChecksumHeaders.
+func (client *Client) SetUploadChecksums(checksums bool) {
+	client.UploadChecksums = checksums
+}
+
+// SetUploadChecksumHeaders Changes which checksum headers the uploads send with UploadChecksums
+// (common.HEADER_CONTENT_MD5 and common.HEADER_CHECKSUM_SHA256), so the storage rejects corrupted uploads.
+// Presigned URLs only accept headers signed with them: set only the headers the backend signs (Default none).
+func (client *Client) SetUploadChecksumHeaders(headers ...string) {
+	client.UploadChecksumHeaders = headers
+}
+
+// UploadSourceWithChecksum Uploads a source to the signed URL as UploadSource, returning its checksum.
+// The checksum is empty when UploadChecksums is disabled.
+func (client *Client) UploadSourceWithChecksum(ctx context.Context, url string, src Source) (Checksum, error) {
+	return client.upload(ctx, url, src)
+}
+
+// sourceChecksum Reads the whole source computing its checksum.
+func sourceChecksum(src Source) (Checksum, error) {
+	body, err := src.Open()
+	if err != nil {
+		return Checksum{}, err
+	}
+
+	defer body.Close()
+
+	return readerChecksum(body)
+}
+
+// readerChecksum Reads the reader until EOF computing its checksum.
+func readerChecksum(r io.Reader) (Checksum, error) {
+	md5Hash := md5.New()
+	sha256Hash := sha256.New()
+
+	_, err := io.Copy(io.MultiWriter(md5Hash, sha256Hash), r)
+	if err != nil {
+		return Checksum{}, common.ErrReadFile
+	}
+
+	return Checksum{
+		MD5:    base64.StdEncoding.EncodeToString(md5Hash.Sum(nil)),
+		SHA256: base64.StdEncoding.EncodeToString(sha256Hash.Sum(nil)),
+	}, nil
+}
+
+// setHeaders Sets the given checksum headers on the request, if the checksum was computed.
+// Other headers are ignored, unsigned headers would make the storage reject the upload.
+func (checksum Checksum) setHeaders(req *http.Request, headers []string) {
+	for _, header := range headers {
+		switch {
+		case strings.EqualFold(header, common.HEADER_CONTENT_MD5) && checksum.MD5 != "":
+			req.Header.Set(common.HEADER_CONTENT_MD5, checksum.MD5)
+		case strings.EqualFold(header, common.HEADER_CHECKSUM_SHA256) && checksum.SHA256 != "":
+			req.Header.Set(common.HEADER_CHECKSUM_SHA256, checksum.SHA256)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package ultraocr
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+var helloChecksum = Checksum{
+	MD5:    "XUFAKrxLKna5cZ2REBfFkg==",
+	SHA256: "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=",
+}
+
+func TestUploadChecksums(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		ranged      bool
+		headers     []string
+		want        Checksum
+		wantMD5s    []string
+		wantSHA256s []string
+	}{
+		{name: "disabled", headers: []string{common.HEADER_CONTENT_MD5}, wantMD5s: []string{""}, wantSHA256s: []string{""}},
+		{name: "not signed", enabled: true, want: helloChecksum, wantMD5s: []string{""}, wantSHA256s: []string{""}},
+		{
+			name:        "single",
+			enabled:     true,
+			headers:     []string{common.HEADER_CONTENT_MD5, common.HEADER_CHECKSUM_SHA256},
+			want:        helloChecksum,
+			wantMD5s:    []string{helloChecksum.MD5},
+			wantSHA256s: []string{helloChecksum.SHA256},
+		},
+		{
+			name:        "signed sha256 only",
+			enabled:     true,
+			headers:     []string{"x-amz-checksum-sha256", "Content-Length"},
+			want:        helloChecksum,
+			wantMD5s:    []string{""},
+			wantSHA256s: []string{helloChecksum.SHA256},
+		},
+		{
+			name:        "ranged parts",
+			enabled:     true,
+			ranged:      true,
+			headers:     []string{common.HEADER_CONTENT_MD5},
+			want:        helloChecksum,
+			wantMD5s:    []string{"QinWkbB7EzQdpT8Xq58kFg==", "2VZ5dSE0otnrYdvXuRxLzA=="},
+			wantSHA256s: []string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			md5s := map[string]string{}
+			sha256s := map[string]string{}
+			client := NewClient()
+			client.SetUploadChecksums(tt.enabled)
+			client.SetUploadChecksumHeaders(tt.headers...)
+			if tt.ranged {
+				client.SetRangedUpload(RangedUpload{PartSize: 4})
+			}
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					mu.Lock()
+					md5s[req.Header.Get("Content-Range")] = req.Header.Get(common.HEADER_CONTENT_MD5)
+					sha256s[req.Header.Get("Content-Range")] = req.Header.Get(common.HEADER_CHECKSUM_SHA256)
+					mu.Unlock()
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			})
+
+			got, err := client.UploadSourceWithChecksum(context.Background(), "https://bucket.example.com/upload", StringSource("doc", "hello"))
+			if err != nil {
+				t.Fatalf("client.UploadSourceWithChecksum() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("client.UploadSourceWithChecksum() = %+v, want %+v", got, tt.want)
+			}
+
+			ranges := []string{""}
+			if tt.ranged {
+				ranges = []string{"bytes 0-3/5", "bytes 4-4/5"}
+			}
+			for i, contentRange := range ranges {
+				if md5s[contentRange] != tt.wantMD5s[i] {
+					t.Errorf("Content-MD5 of %q = %q, want %q", contentRange, md5s[contentRange], tt.wantMD5s[i])
+				}
+				if sha256s[contentRange] != tt.wantSHA256s[i] {
+					t.Errorf("x-amz-checksum-sha256 of %q = %q, want %q", contentRange, sha256s[contentRange], tt.wantSHA256s[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSubmitChecksums(t *testing.T) {
+	recorder := &submissionRecorder{}
+	client := recorder.client()
+	client.SetUploadChecksums(true)
+
+	job, err := client.SubmitJob(context.Background(), JobRequest{
+		Service:  ServiceRG,
+		Document: StringSource("doc", "hello"),
+		Selfie:   StringSource("selfie", "hello"),
+		Options:  JobOptions{Facematch: true},
+	})
+	if err != nil {
+		t.Fatalf("client.SubmitJob() error = %v", err)
+	}
+	want := map[string]Checksum{"document": helloChecksum, "selfie": helloChecksum}
+	if !reflect.DeepEqual(job.Checksums, want) {
+		t.Errorf("client.SubmitJob() checksums = %+v, want %+v", job.Checksums, want)
+	}
+
+	batch, err := client.SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG, Document: StringSource("doc", "hello")})
+	if err != nil {
+		t.Fatalf("client.SubmitBatch() error = %v", err)
+	}
+	want = map[string]Checksum{"document": helloChecksum}
+	if !reflect.DeepEqual(batch.Checksums, want) {
+		t.Errorf("client.SubmitBatch() checksums = %+v, want %+v", batch.Checksums, want)
+	}
+}
@@ -0,0 +1,126 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// dateRange A start and end date in 2006-01-02 format.
+type dateRange struct {
+	start string
+	end   string
+}
+
+// splitDateRange Splits the interval in chunks of days. Consecutive chunks share their boundary day,
+// so no job is lost whether the API end date is inclusive or not.
+func splitDateRange(start, end string, days int) ([]dateRange, error) {
+	from, err := time.Parse(common.DATE_FORMAT, start)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start %q", common.ErrInvalidDateRange, start)
+	}
+
+	to, err := time.Parse(common.DATE_FORMAT, end)
+	if err != nil {
+		return nil, fmt.Errorf("%w: end %q", common.ErrInvalidDateRange, end)
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: %s after %s", common.ErrInvalidDateRange, start, end)
+	}
+
+	chunks := []dateRange{}
+	for {
+		next := from.AddDate(0, 0, days)
+		if !next.Before(to) {
+			chunks = append(chunks, dateRange{start: from.Format(common.DATE_FORMAT), end: end})
+			return chunks, nil
+		}
+
+		chunks = append(chunks, dateRange{start: from.Format(common.DATE_FORMAT), end: next.Format(common.DATE_FORMAT)})
+		from = next
+	}
+}
+
+// GetJobsInChunks Gets the jobs in a time interval like GetJobsWithFilter, splitting it in chunks of days
+// got concurrently, so long intervals don't time out or hit the API range limits.
+// The jobs are returned in the chunks order, without duplicates.
+func (client *Client) GetJobsInChunks(ctx context.Context, start, end string, opts JobsChunkOptions) ([]JobResultResponse, error) {
+	days := opts.Days
+	if days <= 0 {
+		days = common.DEFAULT_CHUNK_DAYS
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = client.jobsConcurrency()
+	}
+
+	chunks, err := splitDateRange(start, end, days)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]JobResultResponse, len(chunks))
+	errs := make(chan error, len(chunks))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			jobs, err := client.GetJobsWithFilter(ctx, chunk.start, chunk.end, opts.Filter)
+			if err != nil {
+				errs <- err
+				cancel()
+				return
+			}
+
+			pages[i] = jobs
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	jobs := []JobResultResponse{}
+	seen := map[string]bool{}
+	for _, page := range pages {
+		for _, job := range page {
+			if seen[job.JobID] {
+				continue
+			}
+
+			seen[job.JobID] = true
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,140 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestSplitDateRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		days    int
+		want    []dateRange
+		wantErr error
+	}{
+		{
+			name:  "single day",
+			start: "2024-01-01",
+			end:   "2024-01-01",
+			days:  7,
+			want:  []dateRange{{"2024-01-01", "2024-01-01"}},
+		},
+		{
+			name:  "smaller than chunk",
+			start: "2024-01-01",
+			end:   "2024-01-05",
+			days:  7,
+			want:  []dateRange{{"2024-01-01", "2024-01-05"}},
+		},
+		{
+			name:  "chunks",
+			start: "2024-01-01",
+			end:   "2024-01-20",
+			days:  7,
+			want:  []dateRange{{"2024-01-01", "2024-01-08"}, {"2024-01-08", "2024-01-15"}, {"2024-01-15", "2024-01-20"}},
+		},
+		{
+			name:  "exact chunks",
+			start: "2024-02-27",
+			end:   "2024-03-02",
+			days:  2,
+			want:  []dateRange{{"2024-02-27", "2024-02-29"}, {"2024-02-29", "2024-03-02"}},
+		},
+		{
+			name:    "invalid date",
+			start:   "01/01/2024",
+			end:     "2024-01-20",
+			days:    7,
+			wantErr: common.ErrInvalidDateRange,
+		},
+		{
+			name:    "reversed",
+			start:   "2024-01-20",
+			end:     "2024-01-01",
+			days:    7,
+			wantErr: common.ErrInvalidDateRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitDateRange(tt.start, tt.end, tt.days)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("splitDateRange() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitDateRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetJobsInChunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     JobsChunkOptions
+		failAt   string
+		wantJobs []string
+		wantErr  error
+	}{
+		{
+			name:     "days",
+			opts:     JobsChunkOptions{Days: 10, Concurrency: 2},
+			wantJobs: []string{"2024-01-01", "2024-01-11", "2024-01-21", "2024-01-31"},
+		},
+		{
+			name:     "default days",
+			wantJobs: []string{"2024-01-01", "2024-01-08", "2024-01-15", "2024-01-22", "2024-01-29", "2024-01-31"},
+		},
+		{
+			name:    "error",
+			opts:    JobsChunkOptions{Days: 10},
+			failAt:  "2024-01-11",
+			wantErr: common.ErrInvalidStatusCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						start := req.URL.Query().Get("startDate")
+						end := req.URL.Query().Get("endtDate")
+						if start == tt.failAt {
+							return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+						}
+
+						// Each chunk has a job on each boundary day, shared with the neighbouring chunks.
+						body := fmt.Sprintf(`{"jobs":[{"job_ksuid":%q},{"job_ksuid":%q}]}`, start, end)
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+						}, nil
+					},
+				},
+			}
+
+			got, err := client.GetJobsInChunks(context.Background(), "2024-01-01", "2024-01-31", tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.GetJobsInChunks() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var jobs []string
+			for _, job := range got {
+				jobs = append(jobs, job.JobID)
+			}
+			if !reflect.DeepEqual(jobs, tt.wantJobs) {
+				t.Errorf("client.GetJobsInChunks() jobs = %v, want %v", jobs, tt.wantJobs)
+			}
+		})
+	}
+}
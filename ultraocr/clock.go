@@ -0,0 +1,44 @@
+package ultraocr
+
+import (
+	"context"
+	"time"
+)
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SetClock Changes the Client source of time (Default the system clock).
+func (client *Client) SetClock(clock Clock) {
+	client.Clock = clock
+}
+
+func (client *Client) clock() Clock {
+	if client.Clock == nil {
+		return realClock{}
+	}
+
+	return client.Clock
+}
+
+// sleep Waits the duration on the Client clock, returning the context error if it is done first.
+func (client *Client) sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, client.clock(), d)
+}
+
+// sleep Waits the duration on the clock, returning the context error if it is done first.
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(d):
+		return nil
+	}
+}
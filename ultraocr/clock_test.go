@@ -0,0 +1,202 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func newFakeClient(clock Clock, api *ultraocrtest.FakeAPI) Client {
+	client := NewClient()
+	client.SetClock(clock)
+	client.SetHttpClient(api)
+	client.SetAutoRefresh("id", "secret", 60)
+
+	return client
+}
+
+func TestTimeTravel(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("token expiration", func(t *testing.T) {
+		clock := ultraocrtest.NewFakeClock(start)
+		api := ultraocrtest.NewFakeAPI(clock)
+		client := newFakeClient(clock, api)
+		jobID := api.AddJob("rg", common.STATUS_DONE)
+
+		for i := 0; i < 3; i++ {
+			_, err := client.GetJobResult(context.Background(), jobID, jobID)
+			if err != nil {
+				t.Fatalf("client.GetJobResult() error = %v", err)
+			}
+			clock.Advance(50 * time.Minute)
+		}
+
+		if api.AuthCount() != 2 {
+			t.Errorf("authentications = %v, want 2", api.AuthCount())
+		}
+	})
+
+	t.Run("skewed clock", func(t *testing.T) {
+		server := ultraocrtest.NewFakeClock(start)
+		api := ultraocrtest.NewFakeAPI(server)
+		// the local clock is an hour behind the server one
+		clock := ultraocrtest.NewFakeClock(start.Add(-time.Hour))
+		client := newFakeClient(clock, api)
+		jobID := api.AddJob("rg", common.STATUS_DONE)
+
+		for i := 0; i < 3; i++ {
+			_, err := client.GetJobResult(context.Background(), jobID, jobID)
+			if err != nil {
+				t.Fatalf("client.GetJobResult() error = %v", err)
+			}
+			if i == 0 && !client.ExpiresAt.Equal(clock.Now().Add(time.Hour)) {
+				t.Errorf("client.ExpiresAt = %v, want an hour from the local clock %v", client.ExpiresAt, clock.Now())
+			}
+			clock.Advance(50 * time.Minute)
+			server.Advance(50 * time.Minute)
+		}
+
+		if api.AuthCount() != 2 {
+			t.Errorf("authentications = %v, want 2", api.AuthCount())
+		}
+	})
+
+	t.Run("hours long job", func(t *testing.T) {
+		clock := ultraocrtest.NewAutoClock(start)
+		api := ultraocrtest.NewFakeAPI(clock)
+		api.ProcessingTime = 3 * time.Hour
+		client := newFakeClient(clock, api)
+		client.SetInterval(60)
+		client.SetTimeout(int((4 * time.Hour).Seconds()))
+
+		res, err := client.CreateAndWaitJob(context.Background(), "rg", "clock_test.go", "", "", nil, nil)
+		if err != nil {
+			t.Fatalf("client.CreateAndWaitJob() error = %v", err)
+		}
+		if res.Status != common.STATUS_DONE {
+			t.Errorf("client.CreateAndWaitJob() status = %v, want %v", res.Status, common.STATUS_DONE)
+		}
+		// tokens expire in 60 minutes, so the client authenticates at 0, 61 and 122 minutes
+		if api.AuthCount() != 3 {
+			t.Errorf("authentications = %v, want 3", api.AuthCount())
+		}
+	})
+
+	t.Run("wait timeout", func(t *testing.T) {
+		clock := ultraocrtest.NewAutoClock(start)
+		api := ultraocrtest.NewFakeAPI(clock)
+		api.ProcessingTime = 48 * time.Hour
+		client := newFakeClient(clock, api)
+		client.SetTimeout(int((24 * time.Hour).Seconds()))
+		client.SetInterval(int(time.Hour.Seconds()))
+
+		res, err := client.SendBatch(context.Background(), "rg", "clock_test.go", nil, nil)
+		if err != nil {
+			t.Fatalf("client.SendBatch() error = %v", err)
+		}
+
+		_, err = client.WaitForBatchDone(context.Background(), res.Id, true)
+		if !errors.Is(err, common.ErrTimeout) {
+			t.Errorf("client.WaitForBatchDone() error = %v, want %v", err, common.ErrTimeout)
+		}
+	})
+}
+
+func TestWaitContextCancel(t *testing.T) {
+	t.Run("cancel while sleeping", func(t *testing.T) {
+		clock := ultraocrtest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		api := ultraocrtest.NewFakeAPI(clock)
+		api.ProcessingTime = time.Hour
+		client := newFakeClient(clock, api)
+		client.SetInterval(60)
+		jobID := api.AddJob("rg", common.STATUS_DONE)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			_, err := client.WaitForJobDone(ctx, jobID, jobID)
+			errs <- err
+		}()
+
+		clock.BlockUntil(1)
+		cancel()
+
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("client.WaitForJobDone() error = %v, want %v", err, context.Canceled)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("client.WaitForJobDone() did not return after cancel")
+		}
+	})
+
+	t.Run("canceled before waiting", func(t *testing.T) {
+		api := ultraocrtest.NewFakeAPI(nil)
+		client := newFakeClient(nil, api)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.WaitForBatchDone(ctx, "0ujsszwN8NRY24YaXiTIE2VWDTS", false)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("client.WaitForBatchDone() error = %v, want %v", err, context.Canceled)
+		}
+		if api.RequestCount() != 0 {
+			t.Errorf("client.WaitForBatchDone() requests = %v, want 0", api.RequestCount())
+		}
+	})
+}
+
+func TestWaitContextDeadline(t *testing.T) {
+	tests := []struct {
+		name               string
+		useContextDeadline bool
+		timeout            int
+		ctxTimeout         time.Duration
+		wantErr            error
+	}{
+		{
+			name:       "client timeout first",
+			timeout:    0,
+			ctxTimeout: 5 * time.Second,
+			wantErr:    common.ErrTimeout,
+		},
+		{
+			name:       "context deadline first",
+			timeout:    60,
+			ctxTimeout: 50 * time.Millisecond,
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name:               "context deadline instead of client timeout",
+			useContextDeadline: true,
+			timeout:            0,
+			ctxTimeout:         5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := ultraocrtest.NewFakeAPI(nil)
+			api.ProcessingTime = 200 * time.Millisecond
+			client := newFakeClient(nil, api)
+			client.SetTimeout(tt.timeout)
+			_ = client.SetPollStrategy(FixedPolling{Interval: 10 * time.Millisecond})
+			client.SetUseContextDeadline(tt.useContextDeadline)
+			jobID := api.AddJob("rg", common.STATUS_DONE)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.ctxTimeout)
+			defer cancel()
+
+			_, err := client.WaitForJobDone(ctx, jobID, jobID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("client.WaitForJobDone() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
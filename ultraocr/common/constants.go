@@ -1,19 +1,85 @@
 // Package common implements constants and errors.
 package common
 
+import "time"
+
 // SDK Constants.
 const (
-	POOLING_INTERVAL        = 1
-	API_TIMEOUT             = 30
-	UPLOAD_TIMEOUT          = 120
-	DEFAULT_EXPIRATION_TIME = 60
-	BASE_URL                = "https://ultraocr.apis.nuveo.ai/v2"
-	AUTH_BASE_URL           = "https://auth.apis.nuveo.ai/v2"
-	STATUS_DONE             = "done"
-	STATUS_ERROR            = "error"
-	RESOURCE_JOB            = "job"
-	RESOURCE_BATCH          = "batch"
-	KEY_FACEMATCH           = "facematch"
-	KEY_EXTRA               = "extra-document"
-	FLAG_TRUE               = "true"
+	POOLING_INTERVAL         = 1
+	API_TIMEOUT              = 30
+	UPLOAD_TIMEOUT           = 120
+	DEFAULT_EXPIRATION_TIME  = 60
+	DEFAULT_JOBS_CONCURRENCY = 10
+	DEFAULT_SUBMITTER_QUEUE  = 100
+	DEFAULT_QUEUE_ATTEMPTS   = 3
+	DEFAULT_QUEUE_BACKOFF    = time.Second
+	DEFAULT_QUEUE_LEASE      = 30 * time.Minute
+	DEFAULT_PART_SIZE        = 8 << 20
+	SINGLE_STEP_LIMIT        = 6 << 20
+	DEFAULT_PREPROCESS_BYTES = 4 << 20
+	DEFAULT_JPEG_QUALITY     = 85
+	MIN_JPEG_QUALITY         = 50
+	MIN_PREPROCESS_SIDE      = 320
+	DEFAULT_PART_PARALLELISM = 4
+	DEFAULT_UPLOAD_ATTEMPTS  = 3
+	DEFAULT_UPLOAD_BACKOFF   = 500 * time.Millisecond
+	DEFAULT_RATE_ATTEMPTS    = 3
+	DEFAULT_RATE_BACKOFF     = time.Second
+	DEFAULT_RATE_MAX_DELAY   = time.Minute
+	BASE_URL                 = "https://ultraocr.apis.nuveo.ai/v2"
+	AUTH_BASE_URL            = "https://auth.apis.nuveo.ai/v2"
+	STATUS_DONE              = "done"
+	STATUS_ERROR             = "error"
+	STATUS_CANCELLED         = "cancelled"
+	QUEUE_STATE_PENDING      = "pending"
+	QUEUE_STATE_UPLOADING    = "uploading"
+	QUEUE_STATE_SUBMITTED    = "submitted"
+	QUEUE_STATE_DONE         = "done"
+	QUEUE_STATE_FAILED       = "failed"
+	AUDIT_ACTION_SUBMIT      = "submit"
+	AUDIT_ACTION_RESULT      = "result"
+	ENV_CLIENT_ID            = "ULTRAOCR_CLIENT_ID"
+	ENV_CLIENT_SECRET        = "ULTRAOCR_CLIENT_SECRET"
+	CREDENTIALS_ID_KEY       = "client_id"
+	CREDENTIALS_SECRET_KEY   = "client_secret"
+	RESOURCE_JOB             = "job"
+	RESOURCE_BATCH           = "batch"
+	PHASE_SIGNED_URL         = "signed url"
+	PHASE_UPLOAD             = "upload"
+	PHASE_WAIT               = "wait"
+	PHASE_REQUEST            = "request"
+	KEY_FACEMATCH            = "facematch"
+	KEY_EXTRA                = "extra-document"
+	KEY_BASE64               = "base64"
+	KEY_CALLBACK_URL         = "callback-url"
+	KEY_IDEMPOTENCY_KEY      = "idempotency-key"
+	KEY_STATUS               = "status"
+	KEY_SERVICE              = "service"
+	KEY_VALIDATION_STATUS    = "validationStatus"
+	KEY_CLIENT_DATA          = "clientData"
+	FLAG_TRUE                = "true"
+	CONTENT_TYPE_PDF         = "application/pdf"
+	CONTENT_TYPE_JPEG        = "image/jpeg"
+	CONTENT_TYPE_PNG         = "image/png"
+	CONTENT_TYPE_TIFF        = "image/tiff"
+	DATE_FORMAT              = "2006-01-02"
+	MONTH_FORMAT             = "2006-01"
+	DEFAULT_CHUNK_DAYS       = 7
+	BATCH_MAX_DOCUMENTS      = 100
+	BATCH_MAX_BYTES          = 100 << 20
+	BATCH_ID_SEPARATOR       = ","
+	SQL_SINK_QUERY           = "INSERT INTO ultraocr_results (job_id, service, status, created_at, result) VALUES (?, ?, ?, ?, ?)"
+	HEADER_REQUEST_ID        = "X-Request-Id"
+	HEADER_RETRY_AFTER       = "Retry-After"
+	HEADER_CONTENT_MD5       = "Content-MD5"
+	HEADER_CHECKSUM_SHA256   = "X-Amz-Checksum-Sha256"
+	DEBUG_BODY_LIMIT         = 4096
+	REDACTED                 = "REDACTED"
+	MANIFEST_VERSION         = 1
+	TRACKER_STATE_VERSION    = 1
+	KSUID_LENGTH             = 27
+	TOKEN_STORE_LOCK_RETRY   = 10 * time.Millisecond
+	DEFAULT_HEALTH_THRESHOLD = 3
+	DEFAULT_HEALTH_BACKOFF   = 30 * time.Second
+	TOKEN_STORE_LOCK_STALE   = 30 * time.Second
 )
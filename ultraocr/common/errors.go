@@ -1,15 +1,173 @@
 // Package common implements constants and errors.
 package common
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // SDK Errors.
 var (
-	ErrMountingRequest    = errors.New("failed to mount request")
-	ErrDoingRequest       = errors.New("failed to request")
-	ErrInvalidStatusCode  = errors.New("invalid status code")
-	ErrParsingRequestBody = errors.New("failed to parse request body")
-	ErrParsingResponse    = errors.New("failed to parse response body")
-	ErrReadFile           = errors.New("failed to read file")
-	ErrTimeout            = errors.New("pooling timeout")
+	ErrMountingRequest     = errors.New("failed to mount request")
+	ErrDoingRequest        = errors.New("failed to request")
+	ErrInvalidStatusCode   = errors.New("invalid status code")
+	ErrParsingRequestBody  = errors.New("failed to parse request body")
+	ErrParsingResponse     = errors.New("failed to parse response body")
+	ErrReadFile            = errors.New("failed to read file")
+	ErrTimeout             = errors.New("pooling timeout")
+	ErrMaxAttempts         = errors.New("pooling max attempts reached")
+	ErrInvalidSelfie       = errors.New("invalid facematch selfie")
+	ErrInvalidManifest     = errors.New("invalid batch manifest")
+	ErrManifestMismatch    = errors.New("file does not match the batch manifest")
+	ErrTokenStore          = errors.New("failed to access token store")
+	ErrInvalidMetadata     = errors.New("invalid metadata")
+	ErrInvalidCallbackURL  = errors.New("invalid callback URL")
+	ErrInvalidBaseURL      = errors.New("invalid base URL")
+	ErrUploadRedirected    = errors.New("upload redirected")
+	ErrInvalidStatusURL    = errors.New("invalid status URL")
+	ErrJobDeadline         = errors.New("job deadline exceeded")
+	ErrInvalidID           = errors.New("invalid ID")
+	ErrStore               = errors.New("failed to access store")
+	ErrCheckpointNotFound  = errors.New("checkpoint not found")
+	ErrNotTracked          = errors.New("not tracked")
+	ErrInvalidTrackerState = errors.New("invalid tracker state")
+	ErrInvalidKey          = errors.New("invalid encryption key")
+	ErrDecrypt             = errors.New("failed to decrypt")
+	ErrDecodingDocument    = errors.New("failed to decode result document")
+	ErrMissingDocument     = errors.New("missing document")
+	ErrIncompleteDownload  = errors.New("incomplete download")
+	ErrInvalidDateRange    = errors.New("invalid date range")
+	ErrResultSink          = errors.New("failed to store result")
+	ErrUnavailableService  = errors.New("service not available")
+	ErrUnsupportedOption   = errors.New("option not supported by the service")
+	ErrUnsupportedType     = errors.New("unsupported document type")
+	ErrPayloadTooLarge     = errors.New("payload too large for a single step job")
+	ErrPreprocess          = errors.New("failed to preprocess document")
+	ErrInvalidSourceURL    = errors.New("invalid source URL")
+	ErrInvalidPollStrategy = errors.New("invalid poll strategy")
+	ErrSubmitterClosed     = errors.New("submitter closed")
+	ErrQueuedJobNotFound   = errors.New("queued job not found")
+	ErrJobFailed           = errors.New("job finished with error")
+	ErrBatchNotFinished    = errors.New("batch not finished")
+	ErrAuditSink           = errors.New("failed to record audit")
+	ErrAuditTampered       = errors.New("audit log tampered")
+	ErrAuditFailed         = errors.New("submitted but not audited")
+	ErrCredentials         = errors.New("failed to get credentials")
+	ErrRateLimited         = errors.New("rate limited")
 )
+
+// maxErrorBodySize Limits how much of the response body is shown on error messages.
+const maxErrorBodySize = 512
+
+// APIError Error returned when the API answers with an unexpected status code.
+// It matches ErrInvalidStatusCode on errors.Is.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	RequestURL string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %d", ErrInvalidStatusCode, e.StatusCode)
+	if e.RequestURL != "" {
+		msg = fmt.Sprintf("%s on %s", msg, e.RequestURL)
+	}
+
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request id %s)", msg, e.RequestID)
+	}
+
+	if len(e.Body) > 0 {
+		body := e.Body
+		if len(body) > maxErrorBodySize {
+			body = body[:maxErrorBodySize]
+		}
+		msg = fmt.Sprintf("%s: %s", msg, body)
+	}
+
+	return msg
+}
+
+// Is Reports the APIError as an ErrInvalidStatusCode.
+func (e *APIError) Is(target error) bool {
+	return target == ErrInvalidStatusCode
+}
+
+// RedirectError Error returned when an upload is answered with a redirect, which is never followed
+// to not re-send the document. It matches ErrUploadRedirected and ErrInvalidStatusCode on errors.Is.
+type RedirectError struct {
+	StatusCode int
+	Location   string
+	RequestURL string
+}
+
+func (e *RedirectError) Error() string {
+	msg := fmt.Sprintf("%s with status code %d", ErrUploadRedirected, e.StatusCode)
+	if e.RequestURL != "" {
+		msg = fmt.Sprintf("%s on %s", msg, e.RequestURL)
+	}
+
+	if e.Location != "" {
+		msg = fmt.Sprintf("%s to %s", msg, e.Location)
+	}
+
+	return msg
+}
+
+// Is Reports the RedirectError as an ErrUploadRedirected and ErrInvalidStatusCode.
+func (e *RedirectError) Is(target error) bool {
+	return target == ErrUploadRedirected || target == ErrInvalidStatusCode
+}
+
+// DeadlineError Error returned when a job pipeline exceeds its job deadline, with the phase it was on.
+// It matches ErrJobDeadline and context.DeadlineExceeded on errors.Is.
+type DeadlineError struct {
+	Phase    string
+	Deadline time.Duration
+	Err      error
+}
+
+func (e *DeadlineError) Error() string {
+	return fmt.Sprintf("%s: %s budget exceeded on %s phase: %s", ErrJobDeadline, e.Deadline, e.Phase, e.Err)
+}
+
+// Is Reports the DeadlineError as an ErrJobDeadline and context.DeadlineExceeded.
+func (e *DeadlineError) Is(target error) bool {
+	return target == ErrJobDeadline || target == context.DeadlineExceeded
+}
+
+// Unwrap Returns the error of the phase.
+func (e *DeadlineError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitError Error returned when the API answers 429 Too Many Requests after the retries, or with
+// a Retry-After longer than the retries wait. RetryAfter is the last delay asked by the API (zero if unknown).
+// It matches ErrRateLimited and ErrInvalidStatusCode on errors.Is, and the APIError on errors.As.
+type RateLimitError struct {
+	Attempts   int
+	RetryAfter time.Duration
+	Err        *APIError
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%s after %d attempts", ErrRateLimited, e.Attempts)
+	if e.RetryAfter > 0 {
+		msg = fmt.Sprintf("%s, retry after %s", msg, e.RetryAfter)
+	}
+
+	return fmt.Sprintf("%s: %s", msg, e.Err)
+}
+
+// Is Reports the RateLimitError as an ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Unwrap Returns the APIError of the last attempt.
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,113 @@
+package ultraocr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// contentTypeHead How many bytes of a document are read to detect its type, enough for the
+// signatures below even when base64 encoded.
+const contentTypeHead = 24
+
+// documentSignatures Leading bytes of the document types supported by the API.
+var documentSignatures = []struct {
+	prefix      []byte
+	contentType string
+}{
+	{[]byte("%PDF-"), common.CONTENT_TYPE_PDF},
+	{[]byte{0xFF, 0xD8, 0xFF}, common.CONTENT_TYPE_JPEG},
+	{[]byte("\x89PNG\r\n\x1a\n"), common.CONTENT_TYPE_PNG},
+	{[]byte("II*\x00"), common.CONTENT_TYPE_TIFF},
+	{[]byte("MM\x00*"), common.CONTENT_TYPE_TIFF},
+}
+
+// SetCheckContentType Changes if the documents types are checked before submissions, rejecting types
+// other than PDF, JPEG, PNG and TIFF with ErrUnsupportedType before any request (Default false).
+func (client *Client) SetCheckContentType(check bool) {
+	client.CheckContentType = check
+}
+
+// DetectContentType Returns the MIME type of a document from its first bytes, empty if it is not
+// a PDF, JPEG, PNG or TIFF.
+func DetectContentType(head []byte) string {
+	for _, signature := range documentSignatures {
+		if bytes.HasPrefix(head, signature.prefix) {
+			return signature.contentType
+		}
+	}
+
+	return ""
+}
+
+// sourceHead Reads the first bytes of the source, decoded if it is base64 data.
+func sourceHead(src Source, encoded bool) ([]byte, error) {
+	body, err := src.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	head := make([]byte, contentTypeHead)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, common.ErrReadFile
+	}
+
+	head = head[:n]
+	if !encoded {
+		return head, nil
+	}
+
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(head)))
+	n, _ = base64.StdEncoding.Decode(decoded, head[:len(head)/4*4])
+	return decoded[:n], nil
+}
+
+// checkContentType Rejects sources of unsupported types when CheckContentType is on.
+func (client *Client) checkContentType(src Source, encoded bool) error {
+	if !client.CheckContentType || src == nil {
+		return nil
+	}
+
+	head, err := sourceHead(src, encoded)
+	if err != nil {
+		return err
+	}
+
+	if DetectContentType(head) == "" {
+		return fmt.Errorf("%w: %s is %s, want PDF, JPEG, PNG or TIFF", common.ErrUnsupportedType, src.Name(), http.DetectContentType(head))
+	}
+
+	return nil
+}
+
+// uploadBody Returns the reader to upload from the opened source body and its Content-Type.
+// Streamed sources, not implementing RangeSource, have their type peeked from the body instead
+// of being opened twice. Other sources keep their body, like files sent by the HTTP client as is.
+func uploadBody(src Source, body io.Reader) (io.Reader, string) {
+	if _, ok := src.(RangeSource); ok {
+		return body, uploadContentType(src)
+	}
+
+	reader := bufio.NewReader(body)
+	head, _ := reader.Peek(contentTypeHead)
+
+	return reader, DetectContentType(head)
+}
+
+// uploadContentType Returns the Content-Type of an upload, empty for unknown types and base64 data.
+func uploadContentType(src Source) string {
+	head, err := sourceHead(src, false)
+	if err != nil {
+		return ""
+	}
+
+	return DetectContentType(head)
+}
@@ -0,0 +1,159 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+var (
+	pdfData  = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	jpegData = []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F'}
+	pngData  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	tiffData = []byte("II*\x00\x08\x00\x00\x00")
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{name: "pdf", head: pdfData, want: common.CONTENT_TYPE_PDF},
+		{name: "jpeg", head: jpegData, want: common.CONTENT_TYPE_JPEG},
+		{name: "png", head: pngData, want: common.CONTENT_TYPE_PNG},
+		{name: "tiff little endian", head: tiffData, want: common.CONTENT_TYPE_TIFF},
+		{name: "tiff big endian", head: []byte("MM\x00*\x00\x00\x00\x08"), want: common.CONTENT_TYPE_TIFF},
+		{name: "text", head: []byte("hello")},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentType(tt.head); got != tt.want {
+				t.Errorf("DetectContentType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckContentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   bool
+		req     JobRequest
+		wantErr error
+	}{
+		{
+			name: "disabled",
+			req:  JobRequest{Service: ServiceRG, Document: StringSource("doc.txt", "text")},
+		},
+		{
+			name:  "supported",
+			check: true,
+			req:   JobRequest{Service: ServiceRG, Document: BytesSource("doc.pdf", pdfData)},
+		},
+		{
+			name:  "supported base64",
+			check: true,
+			req: JobRequest{
+				Service:  ServiceRG,
+				Document: StringSource("doc", base64.StdEncoding.EncodeToString(pngData)),
+				Options:  JobOptions{Base64: true},
+			},
+		},
+		{
+			name:    "unsupported",
+			check:   true,
+			req:     JobRequest{Service: ServiceRG, Document: StringSource("doc.txt", "text")},
+			wantErr: common.ErrUnsupportedType,
+		},
+		{
+			name:  "unsupported extra",
+			check: true,
+			req: JobRequest{
+				Service:       ServiceRG,
+				Document:      BytesSource("doc.jpg", jpegData),
+				ExtraDocument: StringSource("extra.txt", "text"),
+				Options:       JobOptions{ExtraDocument: true},
+			},
+			wantErr: common.ErrUnsupportedType,
+		},
+		{
+			name:  "unrequested extra not checked",
+			check: true,
+			req: JobRequest{
+				Service:       ServiceRG,
+				Document:      BytesSource("doc.tiff", tiffData),
+				ExtraDocument: StringSource("extra.txt", "text"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &submissionRecorder{}
+			client := recorder.client()
+			client.SetCheckContentType(tt.check)
+
+			_, err := client.SubmitJob(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.SubmitJob() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && recorder.params != nil {
+				t.Errorf("client.SubmitJob() requested the API before rejecting the document")
+			}
+		})
+	}
+
+	client := NewClient()
+	client.SetCheckContentType(true)
+	_, err := client.SendJobSingleStep(context.Background(), ServiceRG, "ZmlsZQ==", "", "", nil, nil)
+	if !errors.Is(err, common.ErrUnsupportedType) {
+		t.Errorf("client.SendJobSingleStep() error = %v, want %v", err, common.ErrUnsupportedType)
+	}
+}
+
+func TestUploadContentType(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    Source
+		ranged bool
+		want   string
+	}{
+		{name: "pdf", src: BytesSource("doc.pdf", pdfData), want: common.CONTENT_TYPE_PDF},
+		{name: "ranged png", src: BytesSource("doc.png", pngData), ranged: true, want: common.CONTENT_TYPE_PNG},
+		{name: "base64", src: StringSource("doc", base64.StdEncoding.EncodeToString(pdfData))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got []string
+			client := NewClient()
+			if tt.ranged {
+				client.SetRangedUpload(RangedUpload{PartSize: 4})
+			}
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					mu.Lock()
+					got = append(got, req.Header.Get("Content-Type"))
+					mu.Unlock()
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			})
+
+			err := client.UploadSource(context.Background(), "https://bucket.example.com/upload", tt.src)
+			if err != nil {
+				t.Fatalf("client.UploadSource() error = %v", err)
+			}
+			for _, contentType := range got {
+				if contentType != tt.want {
+					t.Errorf("Content-Type = %q, want %q", contentType, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetCredentialsProvider Changes Client to auto refresh token, with the credentials fetched from the
+// provider on every refresh. Requires the token expiration time (in minutes).
+func (client *Client) SetCredentialsProvider(provider CredentialsProvider, expires int) {
+	client.Credentials = provider
+	client.Expires = expires
+	client.AutoRefresh = true
+	client.ExpiresAt = time.Time{}
+}
+
+// loadCredentials Fetches the credentials from the Client provider, if any, keeping them on the Client.
+// Must be called holding the auth lock.
+func (client *Client) loadCredentials(ctx context.Context) error {
+	if client.Credentials == nil {
+		return nil
+	}
+
+	credentials, err := client.Credentials.Credentials(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrCredentials, err)
+	}
+
+	if credentials.ClientID == "" || credentials.ClientSecret == "" {
+		return fmt.Errorf("%w: empty client ID or secret", common.ErrCredentials)
+	}
+
+	client.ClientID = credentials.ClientID
+	client.ClientSecret = credentials.ClientSecret
+	return nil
+}
+
+// CredentialsFunc Adapts a function to the CredentialsProvider interface.
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials Calls the function.
+func (f CredentialsFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// EnvCredentials CredentialsProvider reading the environment variables IDVar and SecretVar
+// (default ULTRAOCR_CLIENT_ID and ULTRAOCR_CLIENT_SECRET).
+type EnvCredentials struct {
+	IDVar     string
+	SecretVar string
+}
+
+// Credentials Reads the environment variables.
+func (e EnvCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	idVar, secretVar := e.IDVar, e.SecretVar
+	if idVar == "" {
+		idVar = common.ENV_CLIENT_ID
+	}
+
+	if secretVar == "" {
+		secretVar = common.ENV_CLIENT_SECRET
+	}
+
+	credentials := Credentials{ClientID: os.Getenv(idVar), ClientSecret: os.Getenv(secretVar)}
+	if credentials.ClientID == "" || credentials.ClientSecret == "" {
+		return Credentials{}, fmt.Errorf("%s or %s not set", idVar, secretVar)
+	}
+
+	return credentials, nil
+}
+
+// FileCredentials CredentialsProvider reading a JSON file with "client_id" and "client_secret",
+// like a mounted Kubernetes secret. The file is read on each call, so replacing it rotates them.
+type FileCredentials struct {
+	Path string
+}
+
+// Credentials Reads the file.
+func (f FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var credentials Credentials
+	err = json.Unmarshal(data, &credentials)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("%s: %w", f.Path, err)
+	}
+
+	return credentials, nil
+}
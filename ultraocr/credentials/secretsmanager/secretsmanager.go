@@ -0,0 +1,74 @@
+// Package secretsmanager implements a CredentialsProvider for AWS Secrets Manager secrets.
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// ErrInvalidSecret Error returned when the secret hasn't the credentials.
+var ErrInvalidSecret = errors.New("invalid secrets manager secret")
+
+// API Reads the SecretString of a secret, by its name or ARN.
+type API interface {
+	GetSecretValue(ctx context.Context, secretID string) (string, error)
+}
+
+// APIFunc Adapts a function to the API interface, like a call to the AWS SDK GetSecretValue.
+type APIFunc func(ctx context.Context, secretID string) (string, error)
+
+// GetSecretValue Calls the function.
+func (f APIFunc) GetSecretValue(ctx context.Context, secretID string) (string, error) {
+	return f(ctx, secretID)
+}
+
+// Provider CredentialsProvider reading the IDKey and SecretKey fields (default client_id and
+// client_secret) of a JSON secret. The secret is read on each call, so a rotated secret is used
+// on the next token refresh.
+type Provider struct {
+	API       API
+	SecretID  string
+	IDKey     string
+	SecretKey string
+}
+
+// New Creates a Provider reading the secret with the default fields.
+func New(api API, secretID string) *Provider {
+	return &Provider{API: api, SecretID: secretID}
+}
+
+// Credentials Reads the secret.
+func (p *Provider) Credentials(ctx context.Context) (ultraocr.Credentials, error) {
+	value, err := p.API.GetSecretValue(ctx, p.SecretID)
+	if err != nil {
+		return ultraocr.Credentials{}, err
+	}
+
+	var fields map[string]any
+	err = json.Unmarshal([]byte(value), &fields)
+	if err != nil {
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %s is not a JSON object", ErrInvalidSecret, p.SecretID)
+	}
+
+	idKey, secretKey := p.IDKey, p.SecretKey
+	if idKey == "" {
+		idKey = common.CREDENTIALS_ID_KEY
+	}
+
+	if secretKey == "" {
+		secretKey = common.CREDENTIALS_SECRET_KEY
+	}
+
+	ID, _ := fields[idKey].(string)
+	secret, _ := fields[secretKey].(string)
+	if ID == "" || secret == "" {
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %s has no %s or %s", ErrInvalidSecret, p.SecretID, idKey, secretKey)
+	}
+
+	return ultraocr.Credentials{ClientID: ID, ClientSecret: secret}, nil
+}
@@ -0,0 +1,56 @@
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func TestProvider(t *testing.T) {
+	errNotFound := errors.New("ResourceNotFoundException")
+	secrets := map[string]string{
+		"ultraocr": `{"client_id": "id", "client_secret": "secret"}`,
+		"custom":   `{"id": "custom-id", "secret": "custom-secret"}`,
+		"plain":    "secret",
+	}
+	api := APIFunc(func(ctx context.Context, secretID string) (string, error) {
+		value, ok := secrets[secretID]
+		if !ok {
+			return "", errNotFound
+		}
+
+		return value, nil
+	})
+
+	tests := []struct {
+		name     string
+		provider *Provider
+		want     ultraocr.Credentials
+		wantErr  error
+	}{
+		{name: "default fields", provider: New(api, "ultraocr"), want: ultraocr.Credentials{ClientID: "id", ClientSecret: "secret"}},
+		{
+			name:     "custom fields",
+			provider: &Provider{API: api, SecretID: "custom", IDKey: "id", SecretKey: "secret"},
+			want:     ultraocr.Credentials{ClientID: "custom-id", ClientSecret: "custom-secret"},
+		},
+		{name: "missing fields", provider: New(api, "custom"), wantErr: ErrInvalidSecret},
+		{name: "not JSON", provider: New(api, "plain"), wantErr: ErrInvalidSecret},
+		{name: "not found", provider: New(api, "missing"), wantErr: errNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.Credentials(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Provider.Credentials() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Provider.Credentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package vault implements a CredentialsProvider reading the UltraOCR credentials from a HashiCorp
+// Vault KV secret. It doesn't depend on the Vault client: the secret is read through the Vault HTTP API.
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// ErrInvalidSecret Error returned when the secret can't be read or hasn't the credentials.
+var ErrInvalidSecret = errors.New("invalid vault secret")
+
+// Provider CredentialsProvider reading the IDKey and SecretKey fields (default client_id and
+// client_secret) of the secret on Path of the Mount KV engine (default "secret", version 2).
+// Address, Token and Namespace default to the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE variables.
+// The secret is read on each call, so a rotated secret is used on the next token refresh.
+type Provider struct {
+	Address    string
+	Token      string
+	Namespace  string
+	Mount      string
+	Path       string
+	KVVersion  int
+	IDKey      string
+	SecretKey  string
+	HttpClient ultraocr.HttpClient
+}
+
+// New Creates a Provider reading the secret path with the defaults.
+func New(path string) *Provider {
+	return &Provider{Path: path}
+}
+
+// Credentials Reads the secret.
+func (p *Provider) Credentials(ctx context.Context) (ultraocr.Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url(), nil)
+	if err != nil {
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %w", ErrInvalidSecret, err)
+	}
+
+	req.Header.Set("X-Vault-Token", valueOr(p.Token, os.Getenv("VAULT_TOKEN")))
+	if namespace := valueOr(p.Namespace, os.Getenv("VAULT_NAMESPACE")); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	client := p.HttpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return ultraocr.Credentials{}, err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return ultraocr.Credentials{}, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(body, &vaultErr)
+
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %s: status %d: %s",
+			ErrInvalidSecret, p.Path, res.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+
+	var secret struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err = json.Unmarshal(body, &secret)
+	if err != nil {
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %s: %w", ErrInvalidSecret, p.Path, err)
+	}
+
+	data := secret.Data
+	if p.KVVersion != 1 {
+		var versioned struct {
+			Data json.RawMessage `json:"data"`
+		}
+		err = json.Unmarshal(data, &versioned)
+		if err != nil {
+			return ultraocr.Credentials{}, fmt.Errorf("%w: %s: %w", ErrInvalidSecret, p.Path, err)
+		}
+
+		data = versioned.Data
+	}
+
+	var fields map[string]any
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %s: %w", ErrInvalidSecret, p.Path, err)
+	}
+
+	ID, _ := fields[valueOr(p.IDKey, common.CREDENTIALS_ID_KEY)].(string)
+	secretValue, _ := fields[valueOr(p.SecretKey, common.CREDENTIALS_SECRET_KEY)].(string)
+	if ID == "" || secretValue == "" {
+		return ultraocr.Credentials{}, fmt.Errorf("%w: %s has no client ID or secret", ErrInvalidSecret, p.Path)
+	}
+
+	return ultraocr.Credentials{ClientID: ID, ClientSecret: secretValue}, nil
+}
+
+// url Returns the secret URL on the KV engine version.
+func (p *Provider) url() string {
+	address := strings.TrimSuffix(valueOr(p.Address, os.Getenv("VAULT_ADDR")), "/")
+	mount := strings.Trim(valueOr(p.Mount, "secret"), "/")
+	path := strings.Trim(p.Path, "/")
+
+	if p.KVVersion == 1 {
+		return fmt.Sprintf("%s/v1/%s/%s", address, mount, path)
+	}
+
+	return fmt.Sprintf("%s/v1/%s/data/%s", address, mount, path)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,67 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func TestProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/ultraocr":
+			_, _ = w.Write([]byte(`{"data": {"data": {"client_id": "id", "client_secret": "secret"}, "metadata": {"version": 2}}}`))
+		case "/v1/kv/ultraocr":
+			_, _ = w.Write([]byte(`{"data": {"id": "id-v1", "secret": "secret-v1"}}`))
+		case "/v1/secret/data/empty":
+			_, _ = w.Write([]byte(`{"data": {"data": {}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	tests := []struct {
+		name     string
+		provider *Provider
+		want     ultraocr.Credentials
+		wantErr  error
+	}{
+		{name: "kv v2", provider: New("ultraocr"), want: ultraocr.Credentials{ClientID: "id", ClientSecret: "secret"}},
+		{
+			name:     "kv v1",
+			provider: &Provider{Address: server.URL + "/", Mount: "kv", Path: "ultraocr", KVVersion: 1, IDKey: "id", SecretKey: "secret"},
+			want:     ultraocr.Credentials{ClientID: "id-v1", ClientSecret: "secret-v1"},
+		},
+		{name: "missing fields", provider: New("empty"), wantErr: ErrInvalidSecret},
+		{name: "not found", provider: New("missing"), wantErr: ErrInvalidSecret},
+		{name: "forbidden", provider: &Provider{Token: "other", Path: "ultraocr"}, wantErr: ErrInvalidSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.Credentials(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Provider.Credentials() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Provider.Credentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
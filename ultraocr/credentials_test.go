@@ -0,0 +1,108 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestCredentialsProvider(t *testing.T) {
+	clock := ultraocrtest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	secrets := []string{}
+	client := NewClient()
+	client.SetClock(clock)
+	client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/token") {
+			var body map[string]any
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			secrets = append(secrets, body["ClientID"].(string)+":"+body["ClientSecret"].(string))
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"token": "token"}`))}, nil
+		}
+
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"status": "done"}`))}, nil
+	}})
+
+	rotation := 0
+	client.SetCredentialsProvider(CredentialsFunc(func(ctx context.Context) (Credentials, error) {
+		rotation++
+		return Credentials{ClientID: "id", ClientSecret: "secret-" + strconv.Itoa(rotation)}, nil
+	}), 60)
+
+	ID := "0ujsszwN8NRY24YaXiTIE2VWDTS"
+	for _, advance := range []time.Duration{0, time.Minute, time.Hour} {
+		clock.Advance(advance)
+
+		_, err := client.GetJobResult(context.Background(), ID, ID)
+		if err != nil {
+			t.Fatalf("client.GetJobResult() error = %v", err)
+		}
+	}
+
+	want := []string{"id:secret-1", "id:secret-2"}
+	if !reflect.DeepEqual(secrets, want) {
+		t.Errorf("authentications = %v, want %v", secrets, want)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		providerErr := errors.New("vault sealed")
+		client.SetCredentialsProvider(CredentialsFunc(func(ctx context.Context) (Credentials, error) {
+			return Credentials{}, providerErr
+		}), 60)
+
+		_, err := client.GetJobResult(context.Background(), ID, ID)
+		if !errors.Is(err, common.ErrCredentials) || !errors.Is(err, providerErr) {
+			t.Errorf("client.GetJobResult() error = %v, want ErrCredentials wrapping the provider error", err)
+		}
+	})
+}
+
+func TestCredentialsAdapters(t *testing.T) {
+	t.Setenv(common.ENV_CLIENT_ID, "env-id")
+	t.Setenv(common.ENV_CLIENT_SECRET, "env-secret")
+	t.Setenv("CUSTOM_ID", "custom-id")
+
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	err := os.WriteFile(path, []byte(`{"client_id": "file-id", "client_secret": "file-secret"}`), 0o600)
+	if err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		provider CredentialsProvider
+		want     Credentials
+		wantErr  bool
+	}{
+		{name: "env", provider: EnvCredentials{}, want: Credentials{ClientID: "env-id", ClientSecret: "env-secret"}},
+		{name: "env custom", provider: EnvCredentials{IDVar: "CUSTOM_ID"}, want: Credentials{ClientID: "custom-id", ClientSecret: "env-secret"}},
+		{name: "env missing", provider: EnvCredentials{SecretVar: "MISSING_SECRET"}, wantErr: true},
+		{name: "file", provider: FileCredentials{Path: path}, want: Credentials{ClientID: "file-id", ClientSecret: "file-secret"}},
+		{name: "file missing", provider: FileCredentials{Path: path + ".missing"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.Credentials(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Credentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Credentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
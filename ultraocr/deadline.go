@@ -0,0 +1,67 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// errJobBudget Cause of the context canceled by the job deadline.
+var errJobBudget = errors.New("job deadline budget exceeded")
+
+// jobBudgetKey Context key of the job budget.
+type jobBudgetKey struct{}
+
+// jobBudget Phase of a job pipeline running under a job deadline.
+type jobBudget struct {
+	mu    sync.Mutex
+	phase string
+}
+
+// WithJobDeadline Creates a Client with the same settings, budgeting a total time for each
+// CreateAndWaitJob and CreateAndWaitBatch across the signed URL generation, upload and wait.
+// When the budget is exceeded they fail with a *common.DeadlineError, with the phase it was exceeded on.
+func (client *Client) WithJobDeadline(d time.Duration) Client {
+	unlock := client.lockAuth()
+	derived := *client
+	unlock()
+
+	derived.JobDeadline = d
+	return derived
+}
+
+// withJobBudget Runs a job pipeline under the Client job deadline, if any.
+func (client *Client) withJobBudget(ctx context.Context, run func(ctx context.Context) error) error {
+	if client.JobDeadline <= 0 {
+		return run(ctx)
+	}
+
+	budget := &jobBudget{}
+	ctx, cancel := context.WithTimeoutCause(context.WithValue(ctx, jobBudgetKey{}, budget), client.JobDeadline, errJobBudget)
+	defer cancel()
+
+	err := run(ctx)
+	if err != nil && errors.Is(context.Cause(ctx), errJobBudget) {
+		budget.mu.Lock()
+		defer budget.mu.Unlock()
+
+		return &common.DeadlineError{Phase: budget.phase, Deadline: client.JobDeadline, Err: err}
+	}
+
+	return err
+}
+
+// markPhase Records the phase of the job pipeline running under a job deadline.
+func markPhase(ctx context.Context, phase string) {
+	budget, ok := ctx.Value(jobBudgetKey{}).(*jobBudget)
+	if !ok {
+		return
+	}
+
+	budget.mu.Lock()
+	budget.phase = phase
+	budget.mu.Unlock()
+}
@@ -0,0 +1,136 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+// blockingClient Blocks the requests matching the method and path until they are canceled,
+// passing the others to the API.
+type blockingClient struct {
+	api    HttpClient
+	method string
+	path   string
+}
+
+func (c blockingClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == c.method && strings.Contains(req.URL.Path, c.path) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+
+	return c.api.Do(req)
+}
+
+func TestWithJobDeadline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.pdf")
+	err := os.WriteFile(path, []byte("document"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		deadline  time.Duration
+		block     *blockingClient
+		batch     bool
+		wantPhase string
+		wantErr   error
+	}{
+		{
+			name:     "within the budget",
+			deadline: time.Minute,
+		},
+		{
+			name:      "signed url",
+			deadline:  50 * time.Millisecond,
+			block:     &blockingClient{method: http.MethodPost, path: "/ocr/job/"},
+			wantPhase: common.PHASE_SIGNED_URL,
+			wantErr:   common.ErrJobDeadline,
+		},
+		{
+			name:      "upload",
+			deadline:  50 * time.Millisecond,
+			block:     &blockingClient{method: http.MethodPut},
+			wantPhase: common.PHASE_UPLOAD,
+			wantErr:   common.ErrJobDeadline,
+		},
+		{
+			name:      "wait",
+			deadline:  50 * time.Millisecond,
+			wantPhase: common.PHASE_WAIT,
+			wantErr:   common.ErrJobDeadline,
+		},
+		{
+			name:      "batch wait",
+			deadline:  50 * time.Millisecond,
+			batch:     true,
+			wantPhase: common.PHASE_WAIT,
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := ultraocrtest.NewFakeAPI(nil)
+			if tt.wantPhase == common.PHASE_WAIT {
+				api.ProcessingTime = time.Hour
+			}
+
+			client := newFakeClient(nil, api)
+			_ = client.SetPollStrategy(FixedPolling{Interval: 10 * time.Millisecond})
+			if tt.block != nil {
+				tt.block.api = api
+				client.SetHttpClient(tt.block)
+			}
+
+			budgeted := client.WithJobDeadline(tt.deadline)
+			if tt.batch {
+				_, err = budgeted.CreateAndWaitBatch(context.Background(), "rg", path, nil, nil, false)
+			} else {
+				_, err = budgeted.CreateAndWaitJob(context.Background(), "rg", path, "", "", nil, nil)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateAndWait() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var deadlineErr *common.DeadlineError
+			if errors.As(err, &deadlineErr) && deadlineErr.Phase != tt.wantPhase {
+				t.Errorf("DeadlineError.Phase = %v, want %v", deadlineErr.Phase, tt.wantPhase)
+			}
+			if client.JobDeadline != 0 {
+				t.Errorf("client.JobDeadline = %v, want the original Client unchanged", client.JobDeadline)
+			}
+		})
+	}
+}
+
+func TestJobDeadlineParentContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.pdf")
+	err := os.WriteFile(path, []byte("document"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := ultraocrtest.NewFakeAPI(nil)
+	api.ProcessingTime = time.Hour
+	client := newFakeClient(nil, api)
+	_ = client.SetPollStrategy(FixedPolling{Interval: 10 * time.Millisecond})
+	budgeted := client.WithJobDeadline(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = budgeted.CreateAndWaitJob(ctx, "rg", path, "", "", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, common.ErrJobDeadline) {
+		t.Errorf("CreateAndWaitJob() error = %v, want only %v", err, context.DeadlineExceeded)
+	}
+}
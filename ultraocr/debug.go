@@ -0,0 +1,170 @@
+package ultraocr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// sensitiveHeaders Headers replaced by REDACTED on debug entries.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// sensitiveFields Top level JSON body fields replaced by REDACTED on debug entries, like the base64 documents.
+var sensitiveFields = []string{"data", common.KEY_FACEMATCH, "extra", "ClientSecret", "token"}
+
+// debugBuffer Ring buffer with the last requests of a Client.
+type debugBuffer struct {
+	mu      sync.Mutex
+	entries []DebugEntry
+	next    int
+	full    bool
+}
+
+// SetDebugBuffer Keeps the last size requests and responses in memory, dumpable with DebugSnapshot.
+// Credentials, tokens and signed URLs query strings are never kept. Zero or less disables it.
+// When enabled, the API responses are read to memory before being decoded.
+func (client *Client) SetDebugBuffer(size int) {
+	if size <= 0 {
+		client.debug = nil
+		return
+	}
+
+	client.debug = &debugBuffer{entries: make([]DebugEntry, size)}
+}
+
+// DebugSnapshot Returns the requests kept on the debug buffer, from the oldest to the newest.
+func (client *Client) DebugSnapshot() []DebugEntry {
+	if client.debug == nil {
+		return nil
+	}
+
+	return client.debug.snapshot()
+}
+
+// sendDebug Does the request, recording it on the debug buffer if enabled.
+// Bodies are only captured with captureBodies, so uploads and authentications don't leak documents or secrets.
+func (client *Client) sendDebug(req *http.Request, captureBodies bool) (*http.Response, error) {
+	if client.debug == nil {
+		return client.doHTTP(req)
+	}
+
+	entry := DebugEntry{
+		Time:           client.clock().Now(),
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestHeaders: client.redactHeaders(req.Header),
+	}
+
+	if !captureBodies {
+		entry.URL = stripQuery(entry.URL)
+	}
+
+	if captureBodies && req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		entry.RequestBody = client.redactBody(data, bearerToken(req))
+	}
+
+	res, err := client.doHTTP(req)
+	entry.Duration = client.clock().Now().Sub(entry.Time)
+
+	if err != nil {
+		entry.Err = err.Error()
+		client.debug.add(entry)
+		return res, err
+	}
+
+	entry.StatusCode = res.StatusCode
+	entry.RequestID = responseRequestID(res)
+	entry.ResponseHeaders = client.redactHeaders(res.Header)
+
+	if captureBodies {
+		data, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(data))
+		entry.ResponseBody = client.redactBody(data, bearerToken(req))
+	}
+
+	client.debug.add(entry)
+	return res, nil
+}
+
+func (b *debugBuffer) add(entry DebugEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+func (b *debugBuffer) snapshot() []DebugEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.full {
+		return append([]DebugEntry{}, b.entries[:b.next]...)
+	}
+
+	return append(append([]DebugEntry{}, b.entries[b.next:]...), b.entries[:b.next]...)
+}
+
+// sanitizeHeaders Returns a copy of the headers with the sensitive and extra headers redacted.
+func sanitizeHeaders(header http.Header, extra ...string) http.Header {
+	sanitized := header.Clone()
+	if sanitized == nil {
+		return http.Header{}
+	}
+
+	for _, key := range slices.Concat(sensitiveHeaders, extra) {
+		if sanitized.Get(key) != "" {
+			sanitized.Set(key, common.REDACTED)
+		}
+	}
+
+	return sanitized
+}
+
+// sanitizeBody Redacts the sensitive and extra fields of a JSON object body and truncates it to DEBUG_BODY_LIMIT.
+func sanitizeBody(data []byte, extra ...string) string {
+	var obj map[string]any
+	if json.Unmarshal(data, &obj) == nil {
+		redacted := false
+		for _, key := range slices.Concat(sensitiveFields, extra) {
+			if _, ok := obj[key]; ok {
+				obj[key] = common.REDACTED
+				redacted = true
+			}
+		}
+
+		if redacted {
+			data, _ = json.Marshal(obj)
+		}
+	}
+
+	if len(data) <= common.DEBUG_BODY_LIMIT {
+		return string(data)
+	}
+
+	return string(data[:common.DEBUG_BODY_LIMIT]) + "..."
+}
+
+// doHTTP Does the request with the HttpClient, keeping the request on the response, as
+// HttpClient implementations may not set it. Errors are redacted, see redactError.
+func (client *Client) doHTTP(req *http.Request) (*http.Response, error) {
+	res, err := client.httpClient().Do(req)
+	err = client.redactError(err, bearerToken(req))
+	if res != nil && res.Request == nil {
+		res.Request = req
+	}
+
+	return res, err
+}
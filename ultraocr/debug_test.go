@@ -0,0 +1,95 @@
+package ultraocr
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestDebugSnapshot(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+
+	if got := client.DebugSnapshot(); got != nil {
+		t.Errorf("client.DebugSnapshot() disabled = %v, want nil", got)
+	}
+
+	client.SetDebugBuffer(3)
+
+	_, err := client.SendJobSingleStep(context.Background(), "rg", "c2VjcmV0", "", "", map[string]any{"a": "1"}, nil)
+	if err != nil {
+		t.Fatalf("client.SendJobSingleStep() error = %v", err)
+	}
+
+	got := client.DebugSnapshot()
+	if len(got) != 2 {
+		t.Fatalf("client.DebugSnapshot() entries = %v, want 2", len(got))
+	}
+
+	auth, send := got[0], got[1]
+	if auth.RequestBody != "" || auth.ResponseBody != "" || auth.StatusCode != 200 {
+		t.Errorf("client.DebugSnapshot() authentication = %+v, want without bodies", auth)
+	}
+	if send.RequestHeaders.Get("Authorization") != common.REDACTED {
+		t.Errorf("client.DebugSnapshot() Authorization = %v, want %v", send.RequestHeaders.Get("Authorization"), common.REDACTED)
+	}
+	if strings.Contains(send.RequestBody, "c2VjcmV0") || !strings.Contains(send.RequestBody, `"metadata":{"a":"1"}`) {
+		t.Errorf("client.DebugSnapshot() request body = %v, want data redacted", send.RequestBody)
+	}
+	if !strings.Contains(send.ResponseBody, "status_url") {
+		t.Errorf("client.DebugSnapshot() response body = %v, want the response", send.ResponseBody)
+	}
+
+	ids := []string{}
+	for i := 0; i < 4; i++ {
+		jobID := api.AddJob("rg", common.STATUS_DONE)
+		ids = append(ids, jobID)
+		_, _ = client.GetJobResult(context.Background(), jobID, jobID)
+	}
+
+	got = client.DebugSnapshot()
+	urls := []string{}
+	for _, entry := range got {
+		urls = append(urls, entry.URL[strings.LastIndex(entry.URL, "/")+1:])
+	}
+	if want := ids[1:]; !reflect.DeepEqual(urls, want) {
+		t.Errorf("client.DebugSnapshot() ring = %v, want %v", urls, want)
+	}
+}
+
+func TestSanitizeBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "redacted fields",
+			body: `{"ClientSecret":"secret","ClientID":"id"}`,
+			want: `{"ClientID":"id","ClientSecret":"REDACTED"}`,
+		},
+		{
+			name: "not an object",
+			body: `[1,2]`,
+			want: `[1,2]`,
+		},
+		{
+			name: "truncated",
+			body: strings.Repeat("a", common.DEBUG_BODY_LIMIT+1),
+			want: strings.Repeat("a", common.DEBUG_BODY_LIMIT) + "...",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("sanitizeBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
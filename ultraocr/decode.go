@@ -0,0 +1,307 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// fieldTree Selected document fields, a nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+func newFieldTree(fields []string) fieldTree {
+	tree := fieldTree{}
+	for _, field := range fields {
+		node := tree
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			sub, ok := node[part]
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+
+			if ok && sub == nil {
+				break
+			}
+
+			if !ok {
+				sub = fieldTree{}
+				node[part] = sub
+			}
+			node = sub
+		}
+	}
+
+	return tree
+}
+
+// GetJobResultFields Gets the job result decoding only the requested document fields.
+// The response is decoded as a stream, so the skipped fields are never allocated, useful
+// for services with huge documents (like full page OCR with coordinates).
+// Fields are paths on the result Document, like "Nome" or "Endereco.Cidade", and lists are traversed.
+// Requires the batch and job ID.
+func (client *Client) GetJobResultFields(
+	ctx context.Context,
+	batchID,
+	jobID string,
+	fields ...string,
+) (JobResultResponse, error) {
+	err := validateIDs(batchID, jobID)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	release, err := client.acquirePoll(ctx)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	defer release()
+
+	url := fmt.Sprintf("%s/ocr/job/result/%s/%s", client.BaseURL, batchID, jobID)
+
+	res, err := client.do(ctx, url, http.MethodGet, nil, nil)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return JobResultResponse{}, client.newAPIError(res, url)
+	}
+
+	result, err := DecodeJobResult(res.Body, fields...)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	err = client.transformResult(&result)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	if result.JobID == "" {
+		result.JobID = jobID
+	}
+
+	return result, client.auditResult(ctx, result)
+}
+
+// DecodeResult Decodes the result document into a T, like a struct of the service fields or a slice of
+// them for documents with many pages. Unknown fields fail with ErrDecodingDocument, so typos and API
+// changes are noticed.
+func DecodeResult[T any](r JobResultResponse) (T, error) {
+	var v T
+	err := r.DecodeDocumentInto(&v)
+	return v, err
+}
+
+// DecodeDocumentInto Decodes the result document into the value pointed by v, see DecodeResult.
+func (r JobResultResponse) DecodeDocumentInto(v any) error {
+	if r.Result.Document == nil {
+		return fmt.Errorf("%w: no document", common.ErrDecodingDocument)
+	}
+
+	data, err := json.Marshal(r.Result.Document)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrDecodingDocument, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err = dec.Decode(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrDecodingDocument, err)
+	}
+
+	return nil
+}
+
+// DecodeJobResult Decodes a job result from a stream, keeping only the requested document fields.
+// Without fields, the whole document is decoded.
+func DecodeJobResult(r io.Reader, fields ...string) (JobResultResponse, error) {
+	dec := json.NewDecoder(r)
+	if len(fields) == 0 {
+		var res JobResultResponse
+		if err := dec.Decode(&res); err != nil {
+			return JobResultResponse{}, common.ErrParsingResponse
+		}
+
+		return res, nil
+	}
+
+	res, err := decodeJobResult(dec, newFieldTree(fields))
+	if err != nil {
+		return JobResultResponse{}, fmt.Errorf("%w: %w", common.ErrParsingResponse, err)
+	}
+
+	return res, nil
+}
+
+func decodeJobResult(dec *json.Decoder, tree fieldTree) (JobResultResponse, error) {
+	var res JobResultResponse
+	others := map[string]json.RawMessage{}
+
+	err := decodeObject(dec, func(key string) error {
+		if key == "result" {
+			return decodeResult(dec, tree, &res.Result)
+		}
+
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		others[key] = raw
+		return err
+	})
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	// Fields missing on the JSON are kept, so the filtered result is preserved.
+	data, _ := json.Marshal(others)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	return res, nil
+}
+
+func decodeResult(dec *json.Decoder, tree fieldTree, result *Result) error {
+	others := map[string]json.RawMessage{}
+
+	err := decodeObject(dec, func(key string) error {
+		if key == "Document" {
+			document, err := filterValue(dec, tree)
+			result.Document = document
+			return err
+		}
+
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		others[key] = raw
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	data, _ := json.Marshal(others)
+	return json.Unmarshal(data, result)
+}
+
+// decodeObject Reads an object calling fn for each key, fn must consume the key value.
+// A null value is accepted as an empty object.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	if t != json.Delim('{') {
+		return fmt.Errorf("expected object, found %v", t)
+	}
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		err = fn(t.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// filterValue Decodes the next value keeping only the fields on the tree.
+func filterValue(dec *json.Decoder, tree fieldTree) (any, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		obj := map[string]any{}
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key := t.(string)
+			sub, ok := tree[key]
+			switch {
+			case !ok:
+				err = skipValue(dec)
+			case sub == nil:
+				var value any
+				err = dec.Decode(&value)
+				obj[key] = value
+			default:
+				obj[key], err = filterValue(dec, sub)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []any{}
+		for dec.More() {
+			value, err := filterValue(dec, tree)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		_, err = dec.Token()
+		return list, err
+	default:
+		return nil, nil
+	}
+}
+
+// skipValue Consumes the next value without keeping it.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth += 1
+		case json.Delim('}'), json.Delim(']'):
+			depth -= 1
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
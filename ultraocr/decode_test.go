@@ -0,0 +1,211 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+const decodeResultBody = `{
+	"job_ksuid": "123",
+	"service": "ocr",
+	"status": "done",
+	"result": {
+		"Time": "1.00",
+		"Quantity": 1,
+		"Document": [
+			{"Text": "page 1", "Words": [{"Text": "a", "Box": [1, 2]}], "Address": {"City": "SP", "Street": "X"}},
+			{"Text": "page 2", "Words": [], "Address": null}
+		]
+	}
+}`
+
+func TestDecodeJobResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		fields  []string
+		want    JobResultResponse
+		wantErr bool
+	}{
+		{
+			name:   "selected fields",
+			body:   decodeResultBody,
+			fields: []string{"Text", "Address.City"},
+			want: JobResultResponse{
+				JobID:   "123",
+				Service: "ocr",
+				Status:  "done",
+				Result: Result{
+					Time:     "1.00",
+					Quantity: 1,
+					Document: []any{
+						map[string]any{"Text": "page 1", "Address": map[string]any{"City": "SP"}},
+						map[string]any{"Text": "page 2", "Address": nil},
+					},
+				},
+			},
+		},
+		{
+			name:   "parent field wins",
+			body:   decodeResultBody,
+			fields: []string{"Address", "Address.City"},
+			want: JobResultResponse{
+				JobID:   "123",
+				Service: "ocr",
+				Status:  "done",
+				Result: Result{
+					Time:     "1.00",
+					Quantity: 1,
+					Document: []any{
+						map[string]any{"Address": map[string]any{"City": "SP", "Street": "X"}},
+						map[string]any{"Address": nil},
+					},
+				},
+			},
+		},
+		{
+			name: "all fields",
+			body: `{"job_ksuid":"123","result":{"Document":{"A":"1"}}}`,
+			want: JobResultResponse{
+				JobID:  "123",
+				Result: Result{Document: map[string]any{"A": "1"}},
+			},
+		},
+		{
+			name:   "without result",
+			body:   `{"job_ksuid":"123","status":"processing","result":null}`,
+			fields: []string{"A"},
+			want:   JobResultResponse{JobID: "123", Status: "processing"},
+		},
+		{
+			name:    "invalid body",
+			body:    `{"job_ksuid":"123","result":{"Document":[}`,
+			fields:  []string{"A"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeJobResult(strings.NewReader(tt.body), tt.fields...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeJobResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, common.ErrParsingResponse) {
+				t.Errorf("DecodeJobResult() error = %v, want %v", err, common.ErrParsingResponse)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeJobResult() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetJobResultFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    JobResultResponse
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			status: 200,
+			body:   `{"job_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","result":{"Document":{"A":"1","B":"2"}}}`,
+			want: JobResultResponse{
+				JobID:  "0ujsszwN8NRY24YaXiTIE2VWDTS",
+				Result: Result{Document: map[string]any{"A": "1"}},
+			},
+		},
+		{
+			name:    "invalid status code",
+			status:  404,
+			body:    `{"message":"not found"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						return &http.Response{
+							StatusCode: tt.status,
+							Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+						}, nil
+					},
+				},
+				Token:     "123",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+
+			got, err := client.GetJobResultFields(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS", "A")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("client.GetJobResultFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.GetJobResultFields() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+type decodedPage struct {
+	Text    string
+	Words   []map[string]any
+	Address *struct {
+		City   string
+		Street string
+	}
+}
+
+func TestDecodeResult(t *testing.T) {
+	result, err := DecodeJobResult(strings.NewReader(decodeResultBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := DecodeResult[[]decodedPage](result)
+	if err != nil {
+		t.Fatalf("DecodeResult() error = %v", err)
+	}
+	if len(pages) != 2 || pages[0].Text != "page 1" || pages[0].Address.City != "SP" || pages[1].Address != nil {
+		t.Errorf("DecodeResult() = %+v", pages)
+	}
+
+	tests := []struct {
+		name   string
+		result JobResultResponse
+	}{
+		{
+			name:   "unknown field",
+			result: JobResultResponse{Result: Result{Document: []any{map[string]any{"Text": "a", "Txet": "b"}}}},
+		},
+		{
+			name:   "wrong type",
+			result: JobResultResponse{Result: Result{Document: []any{map[string]any{"Text": 1}}}},
+		},
+		{
+			name:   "no document",
+			result: JobResultResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages []decodedPage
+			err := tt.result.DecodeDocumentInto(&pages)
+			if !errors.Is(err, common.ErrDecodingDocument) {
+				t.Errorf("DecodeDocumentInto() error = %v, want %v", err, common.ErrDecodingDocument)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DeleteJobData Erases the documents and result of a finished job from the API, e.g. after the data
+// retention window expires. The job result can't be got anymore.
+// Requires the job ID.
+func (client *Client) DeleteJobData(ctx context.Context, jobID string) error {
+	err := ValidateID(jobID)
+	if err != nil {
+		return err
+	}
+
+	return client.deleteData(ctx, fmt.Sprintf("%s/ocr/job/data/%s", client.BaseURL, jobID))
+}
+
+// DeleteBatchData Erases the documents and results of a finished batch and its jobs from the API,
+// as DeleteJobData.
+// Requires the batch ID.
+func (client *Client) DeleteBatchData(ctx context.Context, batchID string) error {
+	err := ValidateID(batchID)
+	if err != nil {
+		return err
+	}
+
+	return client.deleteData(ctx, fmt.Sprintf("%s/ocr/batch/data/%s", client.BaseURL, batchID))
+}
+
+func (client *Client) deleteData(ctx context.Context, url string) error {
+	response, err := client.request(ctx, url, http.MethodDelete, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.status != http.StatusOK && response.status != http.StatusNoContent {
+		return response.apiError()
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestDeleteData(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 10 * time.Second
+	api.JobsPerBatch = 2
+	client := newFakeClient(clock, api)
+
+	batch, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_BATCH, nil, nil)
+	if err != nil {
+		t.Fatalf("client.GenerateSignedUrl() error = %v", err)
+	}
+	job, err := client.SendJobSingleStep(context.Background(), "rg", "ZmlsZQ==", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendJobSingleStep() error = %v", err)
+	}
+
+	err = client.DeleteJobData(context.Background(), job.Id)
+	if !errors.Is(err, common.ErrInvalidStatusCode) {
+		t.Errorf("client.DeleteJobData() processing job error = %v, want %v", err, common.ErrInvalidStatusCode)
+	}
+
+	status, err := client.WaitForBatchDone(context.Background(), batch.Id, true)
+	if err != nil {
+		t.Fatalf("client.WaitForBatchDone() error = %v", err)
+	}
+	_, err = client.WaitForJob(context.Background(), job.Id)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+
+	err = client.DeleteJobData(context.Background(), job.Id)
+	if err != nil {
+		t.Fatalf("client.DeleteJobData() error = %v", err)
+	}
+	err = client.DeleteBatchData(context.Background(), batch.Id)
+	if err != nil {
+		t.Fatalf("client.DeleteBatchData() error = %v", err)
+	}
+
+	for _, ref := range []JobRef{{BatchID: job.Id, JobID: job.Id}, {BatchID: batch.Id, JobID: status.Jobs[0].JobID}} {
+		var apiErr *common.APIError
+		_, err = client.GetJobResult(context.Background(), ref.BatchID, ref.JobID)
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+			t.Errorf("client.GetJobResult() deleted job error = %v, want 404", err)
+		}
+	}
+
+	err = client.DeleteBatchData(context.Background(), "../batch")
+	if !errors.Is(err, common.ErrInvalidID) {
+		t.Errorf("client.DeleteBatchData() error = %v, want %v", err, common.ErrInvalidID)
+	}
+}
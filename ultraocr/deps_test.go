@@ -0,0 +1,56 @@
+package ultraocr
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const modulePath = "github.com/nuveo/ultraocr-sdk-go"
+
+// TestDependencyFree Checks the module packages only import the standard library and the module itself.
+// Integrations with third party services take interfaces implemented by the callers' clients instead.
+func TestDependencyFree(t *testing.T) {
+	root := ".."
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			first, _, _ := strings.Cut(importPath, "/")
+			if strings.Contains(first, ".") && !strings.HasPrefix(importPath, modulePath) {
+				t.Errorf("%s imports %s, only the standard library is allowed", path, importPath)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk the module: %v", err)
+	}
+}
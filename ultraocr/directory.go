@@ -0,0 +1,104 @@
+package ultraocr
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// supportedExtensions Extensions of the document types supported by the API.
+var supportedExtensions = []string{".pdf", ".jpg", ".jpeg", ".png", ".tif", ".tiff"}
+
+// ProcessDirectory Submits every supported file of the directory as a job and waits the results,
+// up to the options concurrency at a time. Returns an outcome per file, in the directory order,
+// failing only when the directory can't be read.
+func (client *Client) ProcessDirectory(
+	ctx context.Context,
+	dir string,
+	service Service,
+	opts DirectoryOptions,
+) ([]FileResult, error) {
+	paths, err := directoryFiles(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = client.jobsConcurrency()
+	}
+
+	results := make([]FileResult, len(paths))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = FileResult{Path: path, Err: ctx.Err()}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			results[i] = client.processFile(ctx, path, service, opts)
+		}()
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// processFile Submits the file and waits its result.
+func (client *Client) processFile(ctx context.Context, path string, service Service, opts DirectoryOptions) FileResult {
+	created, err := client.SubmitJob(ctx, JobRequest{
+		Service:  service,
+		Document: FileSource(path),
+		Metadata: opts.Metadata,
+		Options:  opts.Options,
+	})
+	if err != nil {
+		return FileResult{Path: path, JobID: created.Id, Err: err}
+	}
+
+	result, err := client.WaitForJob(ctx, created.Id)
+	return FileResult{Path: path, JobID: created.Id, Result: result, Err: err}
+}
+
+// directoryFiles Lists the files of the directory with the options extensions, sorted by path.
+func directoryFiles(dir string, opts DirectoryOptions) ([]string, error) {
+	extensions := opts.Extensions
+	if extensions == nil {
+		extensions = supportedExtensions
+	}
+
+	paths := []string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && !opts.Recursive {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if slices.Contains(extensions, strings.ToLower(filepath.Ext(path))) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	return paths, err
+}
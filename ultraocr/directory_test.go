@@ -0,0 +1,61 @@
+package ultraocr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestProcessDirectory(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "sub"), 0o755)
+	for _, name := range []string{"a.pdf", "b.JPG", "notes.txt", "sub/c.png"} {
+		_ = os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600)
+	}
+
+	tests := []struct {
+		name      string
+		opts      DirectoryOptions
+		wantFiles []string
+	}{
+		{name: "flat", wantFiles: []string{"a.pdf", "b.JPG"}},
+		{name: "recursive", opts: DirectoryOptions{Recursive: true, Concurrency: 1}, wantFiles: []string{"a.pdf", "b.JPG", "sub/c.png"}},
+		{name: "extensions", opts: DirectoryOptions{Extensions: []string{".txt"}}, wantFiles: []string{"notes.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			api := ultraocrtest.NewFakeAPI(clock)
+			api.ProcessingTime = 5 * time.Second
+			client := newFakeClient(clock, api)
+
+			results, err := client.ProcessDirectory(context.Background(), dir, ServiceRG, tt.opts)
+			if err != nil {
+				t.Fatalf("client.ProcessDirectory() error = %v", err)
+			}
+
+			files := []string{}
+			for _, result := range results {
+				rel, _ := filepath.Rel(dir, result.Path)
+				files = append(files, filepath.ToSlash(rel))
+				if result.Err != nil || result.JobID == "" || !result.Result.Status.IsDone() {
+					t.Errorf("client.ProcessDirectory() %s = %+v, want done", rel, result)
+				}
+			}
+			if !reflect.DeepEqual(files, tt.wantFiles) {
+				t.Errorf("client.ProcessDirectory() files = %v, want %v", files, tt.wantFiles)
+			}
+		})
+	}
+
+	client := NewClient()
+	_, err := client.ProcessDirectory(context.Background(), filepath.Join(dir, "missing"), ServiceRG, DirectoryOptions{})
+	if err == nil {
+		t.Errorf("client.ProcessDirectory() missing directory error = nil")
+	}
+}
@@ -0,0 +1,221 @@
+package ultraocr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// resultWriter Writes the raw job results of a download, knowing the ones already written.
+type resultWriter interface {
+	has(jobID string) bool
+	write(jobID string, result json.RawMessage) error
+	close() error
+}
+
+// DownloadBatchResults Writes the raw result of every finished job of a batch on a directory, created if
+// needed, for offline analysis. Jobs already on the directory are skipped, so a failed or partial
+// download is resumed calling it again. Failures of single jobs don't stop the download, they are
+// reported on BatchDownload.Failed with an ErrIncompleteDownload error.
+func (client *Client) DownloadBatchResults(
+	ctx context.Context,
+	batchID,
+	dir string,
+	opts BatchDownloadOptions,
+) (BatchDownload, error) {
+	status, err := client.GetBatchStatus(ctx, batchID)
+	if err != nil {
+		return BatchDownload{}, err
+	}
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return BatchDownload{}, err
+	}
+
+	var w resultWriter
+	if opts.NDJSON {
+		w, err = openNDJSONWriter(filepath.Join(dir, batchID+".ndjson"))
+		if err != nil {
+			return BatchDownload{}, err
+		}
+	} else {
+		w = dirWriter{dir: dir}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = client.jobsConcurrency()
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	download := BatchDownload{Failed: map[string]error{}}
+	semaphore := make(chan struct{}, concurrency)
+
+	for _, job := range status.Jobs {
+		if !job.Status.IsTerminal() {
+			download.Pending += 1
+			continue
+		}
+
+		if w.has(job.JobID) {
+			download.Skipped += 1
+			continue
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			download.Failed[job.JobID] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result, err := client.GetJobResultRaw(ctx, batchID, job.JobID)
+			if err == nil {
+				err = w.write(job.JobID, result)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				download.Failed[job.JobID] = err
+			} else {
+				download.Downloaded += 1
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	err = w.close()
+	if err != nil {
+		return download, err
+	}
+
+	if len(download.Failed) > 0 {
+		return download, fmt.Errorf("%w: %d of %d jobs failed", common.ErrIncompleteDownload, len(download.Failed), len(status.Jobs))
+	}
+
+	return download, nil
+}
+
+// dirWriter Writes each result on a "<job ID>.json" file of a directory.
+type dirWriter struct {
+	dir string
+}
+
+func (w dirWriter) path(jobID string) string {
+	return filepath.Join(w.dir, jobID+".json")
+}
+
+func (w dirWriter) has(jobID string) bool {
+	_, err := os.Stat(w.path(jobID))
+	return err == nil
+}
+
+func (w dirWriter) write(jobID string, result json.RawMessage) error {
+	return writeFileAtomic(w.path(jobID), result)
+}
+
+func (w dirWriter) close() error {
+	return nil
+}
+
+// ndjsonWriter Appends each result as a line of a NDJSON file.
+type ndjsonWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]bool
+}
+
+// openNDJSONWriter Opens a NDJSON file for appending, reading the jobs already on it.
+// A partial last line, left by an interrupted download, is removed.
+func openNDJSONWriter(path string) (*ndjsonWriter, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &ndjsonWriter{file: file, done: map[string]bool{}}
+	size, err := w.readDone()
+	if err == nil {
+		err = file.Truncate(size)
+	}
+
+	if err == nil {
+		_, err = file.Seek(size, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// readDone Reads the jobs on the complete lines of the file, returning the size of those lines.
+func (w *ndjsonWriter) readDone() (int64, error) {
+	reader := bufio.NewReader(w.file)
+	var size int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return size, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		size += int64(len(line))
+
+		var result struct {
+			JobID string `json:"job_ksuid"`
+		}
+		if json.Unmarshal(line, &result) == nil && result.JobID != "" {
+			w.done[result.JobID] = true
+		}
+	}
+}
+
+func (w *ndjsonWriter) has(jobID string) bool {
+	return w.done[jobID]
+}
+
+func (w *ndjsonWriter) write(jobID string, result json.RawMessage) error {
+	var line bytes.Buffer
+	err := json.Compact(&line, result)
+	if err != nil {
+		return err
+	}
+
+	line.WriteByte('\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err = w.file.Write(line.Bytes())
+	return err
+}
+
+func (w *ndjsonWriter) close() error {
+	return w.file.Close()
+}
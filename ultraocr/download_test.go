@@ -0,0 +1,137 @@
+package ultraocr
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+// failingJobAPI Fails the result requests of a job, delegating the other requests to the FakeAPI.
+type failingJobAPI struct {
+	api   *ultraocrtest.FakeAPI
+	jobID string
+}
+
+func (f *failingJobAPI) Do(req *http.Request) (*http.Response, error) {
+	if f.jobID != "" && strings.HasSuffix(req.URL.Path, "/"+f.jobID) {
+		return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+	}
+
+	return f.api.Do(req)
+}
+
+func newFakeBatch(t *testing.T) (Client, *failingJobAPI, BatchStatusResponse) {
+	api := ultraocrtest.NewFakeAPI(nil)
+	api.JobsPerBatch = 5
+	api.Document = []any{map[string]any{"Page": 1}}
+	client := newFakeClient(nil, api)
+	failing := &failingJobAPI{api: api}
+	client.SetHttpClient(failing)
+
+	created, err := client.GenerateSignedUrl(context.Background(), ServiceRG, common.RESOURCE_BATCH, nil, nil)
+	if err != nil {
+		t.Fatalf("client.GenerateSignedUrl() error = %v", err)
+	}
+
+	status, err := client.GetBatchStatus(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.GetBatchStatus() error = %v", err)
+	}
+
+	return client, failing, status
+}
+
+func TestDownloadBatchResults(t *testing.T) {
+	client, failing, status := newFakeBatch(t)
+	dir := t.TempDir()
+
+	failing.jobID = status.Jobs[2].JobID
+	got, err := client.DownloadBatchResults(context.Background(), status.BatchID, dir, BatchDownloadOptions{Concurrency: 2})
+	if !errors.Is(err, common.ErrIncompleteDownload) {
+		t.Fatalf("client.DownloadBatchResults() error = %v, want %v", err, common.ErrIncompleteDownload)
+	}
+	if got.Downloaded != 4 || len(got.Failed) != 1 || got.Failed[failing.jobID] == nil {
+		t.Errorf("client.DownloadBatchResults() = %+v", got)
+	}
+
+	failing.jobID = ""
+	got, err = client.DownloadBatchResults(context.Background(), status.BatchID, dir, BatchDownloadOptions{})
+	if err != nil {
+		t.Fatalf("client.DownloadBatchResults() resume error = %v", err)
+	}
+	if got.Downloaded != 1 || got.Skipped != 4 || len(got.Failed) != 0 {
+		t.Errorf("client.DownloadBatchResults() resume = %+v", got)
+	}
+
+	for _, job := range status.Jobs {
+		data, err := os.ReadFile(filepath.Join(dir, job.JobID+".json"))
+		if err != nil {
+			t.Fatalf("result file error = %v", err)
+		}
+
+		var result JobResultResponse
+		err = json.Unmarshal(data, &result)
+		if err != nil || result.JobID != job.JobID {
+			t.Errorf("result file = %s, error = %v", data, err)
+		}
+	}
+}
+
+func TestDownloadBatchResultsNDJSON(t *testing.T) {
+	client, failing, status := newFakeBatch(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, status.BatchID+".ndjson")
+	opts := BatchDownloadOptions{NDJSON: true}
+
+	failing.jobID = status.Jobs[0].JobID
+	_, err := client.DownloadBatchResults(context.Background(), status.BatchID, dir, opts)
+	if !errors.Is(err, common.ErrIncompleteDownload) {
+		t.Fatalf("client.DownloadBatchResults() error = %v, want %v", err, common.ErrIncompleteDownload)
+	}
+
+	// A line left partially written by an interrupted download.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = file.WriteString(`{"job_ksuid":"` + failing.jobID)
+	file.Close()
+
+	failing.jobID = ""
+	got, err := client.DownloadBatchResults(context.Background(), status.BatchID, dir, opts)
+	if err != nil {
+		t.Fatalf("client.DownloadBatchResults() resume error = %v", err)
+	}
+	if got.Downloaded != 1 || got.Skipped != 4 {
+		t.Errorf("client.DownloadBatchResults() resume = %+v", got)
+	}
+
+	file, err = os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	jobs := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var result JobResultResponse
+		err := json.Unmarshal(scanner.Bytes(), &result)
+		if err != nil {
+			t.Fatalf("line %s error = %v", scanner.Text(), err)
+		}
+		jobs[result.JobID] = true
+	}
+	if len(jobs) != len(status.Jobs) {
+		t.Errorf("lines jobs = %v, want %v jobs", jobs, len(status.Jobs))
+	}
+}
@@ -0,0 +1,155 @@
+package ultraocr
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// sealer Encrypts values with AES-GCM, bound to the key they are saved with.
+type sealer struct {
+	aead cipher.AEAD
+}
+
+// newSealer Creates a sealer with an AES-128, AES-192 or AES-256 key (16, 24 or 32 bytes).
+func newSealer(key []byte) (sealer, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return sealer{}, fmt.Errorf("%w: %w", common.ErrInvalidKey, err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return sealer{}, fmt.Errorf("%w: %w", common.ErrInvalidKey, err)
+	}
+
+	return sealer{aead: aead}, nil
+}
+
+// seal Encrypts the value with a random nonce, returning the nonce followed by the ciphertext.
+func (s sealer) seal(key string, value []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(value)+s.aead.Overhead())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.aead.Seal(nonce, nonce, value, []byte(key)), nil
+}
+
+// open Decrypts a sealed value, failing if it was changed, encrypted with another key or saved with another key.
+func (s sealer) open(key string, sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, common.ErrDecrypt
+	}
+
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	value, err := s.aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, common.ErrDecrypt
+	}
+
+	return value, nil
+}
+
+// EncryptedStore Store encrypting values with AES-GCM before saving them on another Store.
+type EncryptedStore struct {
+	store  Store
+	sealer sealer
+}
+
+// NewEncryptedStore Creates a Store encrypting the values saved on the given Store.
+// The key must have 16, 24 or 32 bytes, failing with ErrInvalidKey otherwise.
+func NewEncryptedStore(store Store, key []byte) (*EncryptedStore, error) {
+	s, err := newSealer(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedStore{store: store, sealer: s}, nil
+}
+
+// Get Returns the decrypted value saved with the key, failing with ErrDecrypt if it can't be decrypted.
+func (s *EncryptedStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	sealed, ok, err := s.store.Get(ctx, key)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	value, err := s.sealer.open(key, sealed)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w: %s", common.ErrStore, err, key)
+	}
+
+	return value, true, nil
+}
+
+// Put Saves the encrypted value with the key.
+func (s *EncryptedStore) Put(ctx context.Context, key string, value []byte) error {
+	sealed, err := s.sealer.seal(key, value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return s.store.Put(ctx, key, sealed)
+}
+
+// Delete Removes the value saved with the key.
+func (s *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
+
+// EncryptedTokenStore TokenStore encrypting tokens with AES-GCM before saving them on another TokenStore.
+// Only the token is encrypted, the expiration is kept readable.
+type EncryptedTokenStore struct {
+	store  TokenStore
+	sealer sealer
+}
+
+// NewEncryptedTokenStore Creates a TokenStore encrypting the tokens saved on the given TokenStore.
+// The key must have 16, 24 or 32 bytes, failing with ErrInvalidKey otherwise.
+func NewEncryptedTokenStore(store TokenStore, key []byte) (*EncryptedTokenStore, error) {
+	s, err := newSealer(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedTokenStore{store: store, sealer: s}, nil
+}
+
+// Load Returns the decrypted token saved with the key, failing with ErrDecrypt if it can't be decrypted.
+func (s *EncryptedTokenStore) Load(ctx context.Context, key string) (CachedToken, bool, error) {
+	token, ok, err := s.store.Load(ctx, key)
+	if err != nil || !ok {
+		return CachedToken{}, ok, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(token.Token)
+	if err == nil {
+		var value []byte
+		value, err = s.sealer.open(key, sealed)
+		token.Token = string(value)
+	}
+
+	if err != nil {
+		return CachedToken{}, false, fmt.Errorf("%w: %w", common.ErrTokenStore, common.ErrDecrypt)
+	}
+
+	return token, true, nil
+}
+
+// Save Saves the encrypted token with the key.
+func (s *EncryptedTokenStore) Save(ctx context.Context, key string, token CachedToken) error {
+	sealed, err := s.sealer.seal(key, []byte(token.Token))
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrTokenStore, err)
+	}
+
+	token.Token = base64.StdEncoding.EncodeToString(sealed)
+	return s.store.Save(ctx, key, token)
+}
@@ -0,0 +1,134 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{1}, 32)
+	value := []byte(`{"url":"https://bucket/doc?signature=secret"}`)
+
+	tests := []struct {
+		name    string
+		tamper  func(store *MemoryStore)
+		readKey []byte
+		wantErr error
+	}{
+		{
+			name:    "round trip",
+			readKey: key,
+		},
+		{
+			name:    "other key",
+			readKey: bytes.Repeat([]byte{2}, 32),
+			wantErr: common.ErrDecrypt,
+		},
+		{
+			name: "changed value",
+			tamper: func(store *MemoryStore) {
+				sealed, _, _ := store.Get(ctx, "a")
+				sealed[len(sealed)-1] ^= 1
+				_ = store.Put(ctx, "a", sealed)
+			},
+			readKey: key,
+			wantErr: common.ErrDecrypt,
+		},
+		{
+			name: "moved value",
+			tamper: func(store *MemoryStore) {
+				sealed, _, _ := store.Get(ctx, "b")
+				_ = store.Put(ctx, "a", sealed)
+			},
+			readKey: key,
+			wantErr: common.ErrDecrypt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := NewMemoryStore()
+			store, err := NewEncryptedStore(inner, key)
+			if err != nil {
+				t.Fatalf("NewEncryptedStore() error = %v", err)
+			}
+
+			_ = store.Put(ctx, "a", value)
+			_ = store.Put(ctx, "b", []byte("other"))
+
+			sealed, _, _ := inner.Get(ctx, "a")
+			if bytes.Contains(sealed, []byte("secret")) {
+				t.Fatalf("saved value = %s, want encrypted", sealed)
+			}
+
+			if tt.tamper != nil {
+				tt.tamper(inner)
+			}
+
+			reader, _ := NewEncryptedStore(inner, tt.readKey)
+			got, ok, err := reader.Get(ctx, "a")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EncryptedStore.Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (!ok || !bytes.Equal(got, value)) {
+				t.Errorf("EncryptedStore.Get() = %s, %v, want %s", got, ok, value)
+			}
+		})
+	}
+}
+
+func TestEncryptedTokenStore(t *testing.T) {
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{1}, 16)
+	expires := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	inner := NewMemoryTokenStore()
+	store, err := NewEncryptedTokenStore(inner, key)
+	if err != nil {
+		t.Fatalf("NewEncryptedTokenStore() error = %v", err)
+	}
+
+	err = store.Save(ctx, "a", CachedToken{Token: "secret-token", ExpiresAt: expires})
+	if err != nil {
+		t.Fatalf("EncryptedTokenStore.Save() error = %v", err)
+	}
+
+	saved, _, _ := inner.Load(ctx, "a")
+	if strings.Contains(saved.Token, "secret") || !saved.ExpiresAt.Equal(expires) {
+		t.Errorf("saved token = %+v, want the token encrypted", saved)
+	}
+
+	got, ok, err := store.Load(ctx, "a")
+	if err != nil || !ok || got.Token != "secret-token" || !got.ExpiresAt.Equal(expires) {
+		t.Errorf("EncryptedTokenStore.Load() = %+v, %v, %v", got, ok, err)
+	}
+
+	_, ok, err = store.Load(ctx, "missing")
+	if err != nil || ok {
+		t.Errorf("EncryptedTokenStore.Load() missing = %v, %v", ok, err)
+	}
+
+	_ = inner.Save(ctx, "b", CachedToken{Token: "plain"})
+	_, _, err = store.Load(ctx, "b")
+	if !errors.Is(err, common.ErrDecrypt) {
+		t.Errorf("EncryptedTokenStore.Load() plain token error = %v, want %v", err, common.ErrDecrypt)
+	}
+}
+
+func TestNewEncryptedStoreInvalidKey(t *testing.T) {
+	_, err := NewEncryptedStore(NewMemoryStore(), []byte("short"))
+	if !errors.Is(err, common.ErrInvalidKey) {
+		t.Errorf("NewEncryptedStore() error = %v, want %v", err, common.ErrInvalidKey)
+	}
+
+	_, err = NewEncryptedTokenStore(NewMemoryTokenStore(), nil)
+	if !errors.Is(err, common.ErrInvalidKey) {
+		t.Errorf("NewEncryptedTokenStore() error = %v, want %v", err, common.ErrInvalidKey)
+	}
+}
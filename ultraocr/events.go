@@ -0,0 +1,105 @@
+package ultraocr
+
+import (
+	"slices"
+	"sync"
+)
+
+// EventBus Delivers the job lifecycle events of a Client to its subscribers, in the subscription order.
+// Handlers are called synchronously by the Client calls emitting the events, so they must not block.
+type EventBus struct {
+	mu       sync.RWMutex
+	next     int
+	handlers map[int]func(Event)
+}
+
+// NewEventBus Creates an EventBus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: map[int]func(Event){}}
+}
+
+// SetEventBus Changes the EventBus receiving the Client events, which can be shared between clients
+// (Default none, the events are not emitted).
+func (client *Client) SetEventBus(bus *EventBus) {
+	client.Events = bus
+}
+
+// Subscribe Calls the handler with every event, until the returned function is called.
+func (bus *EventBus) Subscribe(handler func(Event)) (unsubscribe func()) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if bus.handlers == nil {
+		bus.handlers = map[int]func(Event){}
+	}
+
+	ID := bus.next
+	bus.next++
+	bus.handlers[ID] = handler
+
+	return func() {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+
+		delete(bus.handlers, ID)
+	}
+}
+
+// On Subscribes a handler to the events of type T only, like
+//
+//	ultraocr.On(bus, func(event ultraocr.JobFailed) { ... })
+func On[T Event](bus *EventBus, handler func(T)) (unsubscribe func()) {
+	return bus.Subscribe(func(event Event) {
+		if typed, ok := event.(T); ok {
+			handler(typed)
+		}
+	})
+}
+
+// publish Calls the subscribed handlers with the event.
+func (bus *EventBus) publish(event Event) {
+	bus.mu.RLock()
+	IDs := make([]int, 0, len(bus.handlers))
+	for ID := range bus.handlers {
+		IDs = append(IDs, ID)
+	}
+
+	slices.Sort(IDs)
+	handlers := make([]func(Event), len(IDs))
+	for i, ID := range IDs {
+		handlers[i] = bus.handlers[ID]
+	}
+	bus.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// emit Publishes the event on the Client EventBus, if any.
+func (client *Client) emit(event Event) {
+	if client.Events != nil {
+		client.Events.publish(event)
+	}
+}
+
+// statusChanged Emits StatusChanged when the status differs from the previous one, updating it.
+func (client *Client) statusChanged(resource, ID string, previous *Status, status Status) {
+	if *previous == status {
+		return
+	}
+
+	client.emit(StatusChanged{Resource: resource, ID: ID, Previous: *previous, Status: status, Time: client.clock().Now()})
+	*previous = status
+}
+
+// jobFinished Emits JobDone or JobFailed for a finished job.
+func (client *Client) jobFinished(jobID string, result JobResultResponse) {
+	now := client.clock().Now()
+	switch {
+	case result.Status.IsDone():
+		client.emit(JobDone{JobID: jobID, Result: result, Time: now})
+	case result.Status.IsError():
+		client.emit(JobFailed{JobID: jobID, Error: result.Error, Result: result, Time: now})
+	}
+}
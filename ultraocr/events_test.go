@@ -0,0 +1,95 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestEventBus(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 3 * time.Second
+	client := newFakeClient(clock, api)
+
+	bus := NewEventBus()
+	client.SetEventBus(bus)
+
+	var mu sync.Mutex
+	events := []string{}
+	unsubscribe := bus.Subscribe(func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch e := event.(type) {
+		case JobSubmitted:
+			events = append(events, fmt.Sprintf("submitted %s %s", e.Resource, e.Service))
+		case UploadCompleted:
+			events = append(events, "uploaded "+e.Document)
+		case StatusChanged:
+			events = append(events, fmt.Sprintf("%s %q -> %q", e.Resource, e.Previous, e.Status))
+		case JobDone:
+			events = append(events, "done")
+		case JobFailed:
+			events = append(events, "failed "+e.Error)
+		}
+		if reflect.ValueOf(event).FieldByName("Time").Interface().(time.Time).IsZero() {
+			t.Errorf("event %T without time", event)
+		}
+	})
+
+	failed := []string{}
+	On(bus, func(event JobFailed) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = append(failed, event.JobID)
+	})
+
+	created, err := client.SubmitJob(context.Background(), JobRequest{Service: ServiceRG, Document: StringSource("doc", "doc")})
+	if err != nil {
+		t.Fatalf("client.SubmitJob() error = %v", err)
+	}
+	_, err = client.WaitForJob(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+
+	errorJob := api.AddJob(string(ServiceRG), common.STATUS_ERROR)
+	_, err = client.WaitForJob(context.Background(), errorJob)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+
+	want := []string{
+		"uploaded document",
+		"submitted job rg",
+		`job "" -> "processing"`,
+		`job "processing" -> "done"`,
+		"done",
+		`job "" -> "processing"`,
+		`job "processing" -> "error"`,
+		"failed failed to process document",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+	if !reflect.DeepEqual(failed, []string{errorJob}) {
+		t.Errorf("JobFailed events = %v, want %v", failed, []string{errorJob})
+	}
+
+	unsubscribe()
+	events = nil
+	_, err = client.SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG, Document: StringSource("batch", "batch")})
+	if err != nil {
+		t.Fatalf("client.SubmitBatch() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events after unsubscribe = %q, want none", events)
+	}
+}
@@ -0,0 +1,113 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// checkpointKey Returns the Store key of a jobs export checkpoint.
+func checkpointKey(checkpointID string) string {
+	return "jobs-export/" + checkpointID
+}
+
+// ExportJobs Gets the jobs in a time interval page by page, like GetJobs, saving a checkpoint on the
+// Client Store after each page is handled, so an interrupted export can continue with ResumeJobsExport.
+// A page is handled again only if the export stops between the handler and the checkpoint save.
+// Requires a Store, an ID for the checkpoint, the start and end time in 2006-01-02 format and the page handler.
+func (client *Client) ExportJobs(ctx context.Context, checkpointID, start, end string, handler JobsHandler) error {
+	if client.Store == nil {
+		return fmt.Errorf("%w: no store configured", common.ErrStore)
+	}
+
+	checkpoint := JobsExportCheckpoint{
+		ID:    checkpointID,
+		Start: start,
+		End:   end,
+	}
+
+	err := client.saveCheckpoint(ctx, &checkpoint)
+	if err != nil {
+		return err
+	}
+
+	return client.exportJobs(ctx, checkpoint, handler)
+}
+
+// ResumeJobsExport Continues an export started with ExportJobs after the last handled page.
+// Returns nil without requests if the export was already done.
+func (client *Client) ResumeJobsExport(ctx context.Context, checkpointID string, handler JobsHandler) error {
+	checkpoint, err := client.JobsExportCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint.Done {
+		return nil
+	}
+
+	return client.exportJobs(ctx, checkpoint, handler)
+}
+
+// JobsExportCheckpoint Returns the saved progress of an export.
+func (client *Client) JobsExportCheckpoint(ctx context.Context, checkpointID string) (JobsExportCheckpoint, error) {
+	if client.Store == nil {
+		return JobsExportCheckpoint{}, fmt.Errorf("%w: no store configured", common.ErrStore)
+	}
+
+	data, ok, err := client.Store.Get(ctx, checkpointKey(checkpointID))
+	if err != nil {
+		return JobsExportCheckpoint{}, err
+	}
+
+	if !ok {
+		return JobsExportCheckpoint{}, fmt.Errorf("%w: %s", common.ErrCheckpointNotFound, checkpointID)
+	}
+
+	var checkpoint JobsExportCheckpoint
+	err = json.Unmarshal(data, &checkpoint)
+	if err != nil {
+		return JobsExportCheckpoint{}, fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return checkpoint, nil
+}
+
+func (client *Client) exportJobs(ctx context.Context, checkpoint JobsExportCheckpoint, handler JobsHandler) error {
+	for !checkpoint.Done {
+		res, err := client.getJobsPage(ctx, checkpoint.Start, checkpoint.End, checkpoint.NextPageToken, JobsFilter{})
+		if err != nil {
+			return err
+		}
+
+		err = handler(ctx, res.Jobs)
+		if err != nil {
+			return err
+		}
+
+		checkpoint.NextPageToken = res.NextPageToken
+		checkpoint.Pages += 1
+		checkpoint.Jobs += len(res.Jobs)
+		checkpoint.Done = res.NextPageToken == ""
+
+		err = client.saveCheckpoint(ctx, &checkpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) saveCheckpoint(ctx context.Context, checkpoint *JobsExportCheckpoint) error {
+	checkpoint.UpdatedAt = client.clock().Now()
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return client.Store.Put(ctx, checkpointKey(checkpoint.ID), data)
+}
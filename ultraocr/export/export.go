@@ -0,0 +1,178 @@
+// Package export implements writers of job results in formats read by data warehouses,
+// flattening the nested results in dot separated keys (e.g. result.Document.0.Data.Name.value).
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+// Export errors.
+var (
+	ErrFlatten = errors.New("failed to flatten job")
+	ErrWrite   = errors.New("failed to write export")
+)
+
+// Column A CSV column, with its header and the flattened key of its value.
+type Column struct {
+	Header string
+	Key    string
+}
+
+// Flatten Flattens the JSON representation of the job in dot separated keys.
+// Array items are keyed by their index and numbers keep their JSON text.
+func Flatten(job ultraocr.JobResultResponse) (map[string]any, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlatten, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlatten, err)
+	}
+
+	flat := map[string]any{}
+	flatten("", value, flat)
+	return flat, nil
+}
+
+func flatten(prefix string, value any, flat map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			flatten(joinKey(prefix, key), item, flat)
+		}
+	case []any:
+		for i, item := range v {
+			flatten(joinKey(prefix, strconv.Itoa(i)), item, flat)
+		}
+	default:
+		flat[prefix] = v
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+// WriteNDJSON Writes each job as a line of a flattened JSON object.
+func WriteNDJSON(w io.Writer, jobs []ultraocr.JobResultResponse) error {
+	encoder := json.NewEncoder(w)
+	for _, job := range jobs {
+		flat, err := Flatten(job)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(flat)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrWrite, err)
+		}
+	}
+
+	return nil
+}
+
+// WriteCSV Writes a header and a row for each job, with the columns values.
+// Missing keys are empty. Without columns, every flattened key of the jobs is a column, sorted.
+func WriteCSV(w io.Writer, jobs []ultraocr.JobResultResponse, columns []Column) error {
+	rows := make([]map[string]any, len(jobs))
+	for i, job := range jobs {
+		flat, err := Flatten(job)
+		if err != nil {
+			return err
+		}
+
+		rows[i] = flat
+	}
+
+	if columns == nil {
+		columns = allColumns(rows)
+	}
+
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Header
+	}
+
+	err := writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = formatValue(row[column.Key])
+		}
+
+		err = writer.Write(record)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrWrite, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+
+	return nil
+}
+
+// allColumns Returns a column for each key of the rows, sorted, with the key as header.
+func allColumns(rows []map[string]any) []Column {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		for key := range row {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	slices.Sort(keys)
+
+	columns := make([]Column, len(keys))
+	for i, key := range keys {
+		columns[i] = Column{Header: key, Key: key}
+	}
+
+	return columns
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
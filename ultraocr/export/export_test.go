@@ -0,0 +1,116 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func testJobs() []ultraocr.JobResultResponse {
+	return []ultraocr.JobResultResponse{
+		{
+			JobID:   "1",
+			Service: "rg",
+			Status:  ultraocr.StatusDone,
+			Result: ultraocr.Result{
+				Time: "1.5",
+				Document: []map[string]any{
+					{"Page": 1, "Data": map[string]any{"Name": map[string]any{"value": "Maria, Silva", "conf": 99}}},
+				},
+			},
+			ClientData: map[string]any{"order": "A1", "paid": true},
+		},
+		{
+			JobID:  "2",
+			Status: ultraocr.StatusError,
+			Error:  "invalid file",
+		},
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	got, err := Flatten(testJobs()[0])
+	if err != nil {
+		t.Fatalf("Flatten() error = %v", err)
+	}
+
+	want := map[string]any{
+		"job_ksuid":                         "1",
+		"created_at":                        "",
+		"service":                           "rg",
+		"status":                            "done",
+		"result.Time":                       "1.5",
+		"result.Document.0.Page":            json.Number("1"),
+		"result.Document.0.Data.Name.value": "Maria, Silva",
+		"result.Document.0.Data.Name.conf":  json.Number("99"),
+		"client_data.order":                 "A1",
+		"client_data.paid":                  true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteNDJSON(&buf, testJobs())
+	if err != nil {
+		t.Fatalf("WriteNDJSON() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("WriteNDJSON() lines = %v, want 2", len(lines))
+	}
+
+	var row map[string]any
+	err = json.Unmarshal([]byte(lines[1]), &row)
+	if err != nil {
+		t.Fatalf("line %q is not JSON: %v", lines[1], err)
+	}
+	if row["job_ksuid"] != "2" || row["error"] != "invalid file" {
+		t.Errorf("WriteNDJSON() row = %v", row)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		want    string
+	}{
+		{
+			name: "mapping",
+			columns: []Column{
+				{Header: "id", Key: "job_ksuid"},
+				{Header: "name", Key: "result.Document.0.Data.Name.value"},
+				{Header: "conf", Key: "result.Document.0.Data.Name.conf"},
+				{Header: "paid", Key: "client_data.paid"},
+			},
+			want: "id,name,conf,paid\n1,\"Maria, Silva\",99,true\n2,,,\n",
+		},
+		{
+			name: "all keys",
+			want: "client_data.order,client_data.paid,created_at,error,job_ksuid,result.Document.0.Data.Name.conf," +
+				"result.Document.0.Data.Name.value,result.Document.0.Page,result.Time,service,status\n" +
+				"A1,true,,,1,99,\"Maria, Silva\",1,1.5,rg,done\n" +
+				",,,invalid file,2,,,,,,error\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteCSV(&buf, testJobs(), tt.columns)
+			if err != nil {
+				t.Fatalf("WriteCSV() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("WriteCSV() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// pagedJobsClient Returns a client serving three pages of jobs.
+func pagedJobsClient(requests *[]string) *Client {
+	pages := map[string]string{
+		"":   `{"jobs":[{"job_ksuid":"1"},{"job_ksuid":"2"}],"nextPageToken":"p2"}`,
+		"p2": `{"jobs":[{"job_ksuid":"3"}],"nextPageToken":"p3"}`,
+		"p3": `{"jobs":[{"job_ksuid":"4"}],"nextPageToken":""}`,
+	}
+
+	return &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				token := req.URL.Query().Get("nextPageToken")
+				*requests = append(*requests, token)
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(pages[token]))),
+				}, nil
+			},
+		},
+		Token:     "123",
+		ExpiresAt: time.Now().Add(time.Hour),
+		Store:     NewMemoryStore(),
+	}
+}
+
+func TestExportJobs(t *testing.T) {
+	ctx := context.Background()
+	requests := []string{}
+	client := pagedJobsClient(&requests)
+
+	exported := []string{}
+	calls := 0
+	handler := func(ctx context.Context, jobs []JobResultResponse) error {
+		calls += 1
+		if calls == 2 {
+			return errors.New("interrupted")
+		}
+		for _, job := range jobs {
+			exported = append(exported, job.JobID)
+		}
+		return nil
+	}
+
+	err := client.ExportJobs(ctx, "export", "2024-01-01", "2024-01-31", handler)
+	if err == nil {
+		t.Fatalf("client.ExportJobs() error = nil, want interrupted")
+	}
+
+	checkpoint, err := client.JobsExportCheckpoint(ctx, "export")
+	if err != nil {
+		t.Fatalf("client.JobsExportCheckpoint() error = %v", err)
+	}
+	if checkpoint.NextPageToken != "p2" || checkpoint.Pages != 1 || checkpoint.Jobs != 2 || checkpoint.Done {
+		t.Errorf("client.JobsExportCheckpoint() = %+v, want after first page", checkpoint)
+	}
+
+	err = client.ResumeJobsExport(ctx, "export", handler)
+	if err != nil {
+		t.Fatalf("client.ResumeJobsExport() error = %v", err)
+	}
+
+	if want := []string{"1", "2", "3", "4"}; !reflect.DeepEqual(exported, want) {
+		t.Errorf("exported jobs = %v, want %v", exported, want)
+	}
+	if want := []string{"", "p2", "p2", "p3"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requested pages = %v, want %v", requests, want)
+	}
+
+	err = client.ResumeJobsExport(ctx, "export", handler)
+	if err != nil || len(requests) != 4 {
+		t.Errorf("client.ResumeJobsExport() done export error = %v, requests = %v", err, len(requests))
+	}
+}
+
+func TestResumeJobsExportErrors(t *testing.T) {
+	handler := func(ctx context.Context, jobs []JobResultResponse) error { return nil }
+
+	tests := []struct {
+		name  string
+		store Store
+		want  error
+	}{
+		{
+			name: "no store",
+			want: common.ErrStore,
+		},
+		{
+			name:  "checkpoint not found",
+			store: NewMemoryStore(),
+			want:  common.ErrCheckpointNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{Store: tt.store}
+			err := client.ResumeJobsExport(context.Background(), "export", handler)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("client.ResumeJobsExport() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	client := &Client{}
+	err := client.ExportJobs(context.Background(), "export", "", "", handler)
+	if !errors.Is(err, common.ErrStore) {
+		t.Errorf("client.ExportJobs() error = %v, want %v", err, common.ErrStore)
+	}
+}
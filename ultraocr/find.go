@@ -0,0 +1,51 @@
+package ultraocr
+
+import (
+	"context"
+	"reflect"
+)
+
+// FindJobs Finds the jobs in a time interval matching the query, e.g. to correlate jobs with internal IDs
+// sent as metadata. The pages are got on demand and only the matching jobs are kept.
+func (client *Client) FindJobs(ctx context.Context, query JobsQuery) ([]JobResultResponse, error) {
+	want := normalizeJSON(query.ClientData)
+	jobs := []JobResultResponse{}
+
+	it := client.JobsIterator(ctx, query.Start, query.End)
+	it.filter = query.Filter
+	for it.Next() {
+		job := it.Job()
+		if query.ClientData == nil || containsJSON(job.ClientData, want) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	return jobs, nil
+}
+
+// containsJSON Checks if got has every value of want. Objects match when they have every key of want
+// with a matching value, other values must be equal.
+func containsJSON(got, want any) bool {
+	wantObject, ok := want.(map[string]any)
+	if !ok {
+		return reflect.DeepEqual(got, want)
+	}
+
+	gotObject, ok := got.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	for key, value := range wantObject {
+		gotValue, ok := gotObject[key]
+		if !ok || !containsJSON(gotValue, value) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFindJobs(t *testing.T) {
+	pages := map[string]string{
+		"": `{"jobs":[
+			{"job_ksuid":"1","client_data":{"order":"A1","store":{"id":10,"region":"south"}}},
+			{"job_ksuid":"2","client_data":{"order":"B2","store":{"id":11,"region":"south"}}}
+		],"nextPageToken":"p2"}`,
+		"p2": `{"jobs":[
+			{"job_ksuid":"3","client_data":{"order":"A1","store":{"id":10}}},
+			{"job_ksuid":"4"}
+		]}`,
+	}
+
+	tests := []struct {
+		name       string
+		query      JobsQuery
+		want       []string
+		wantStatus string
+	}{
+		{
+			name:  "all",
+			query: JobsQuery{},
+			want:  []string{"1", "2", "3", "4"},
+		},
+		{
+			name:  "value",
+			query: JobsQuery{ClientData: map[string]any{"order": "A1"}},
+			want:  []string{"1", "3"},
+		},
+		{
+			name:  "nested",
+			query: JobsQuery{ClientData: map[string]any{"store": map[string]any{"region": "south"}}},
+			want:  []string{"1", "2"},
+		},
+		{
+			name:  "number",
+			query: JobsQuery{ClientData: map[string]any{"order": "A1", "store": map[string]any{"id": 10}}},
+			want:  []string{"1", "3"},
+		},
+		{
+			name:  "no match",
+			query: JobsQuery{ClientData: map[string]any{"order": "C3"}},
+			want:  []string{},
+		},
+		{
+			name:       "server filter",
+			query:      JobsQuery{Filter: JobsFilter{Status: StatusDone}, ClientData: map[string]any{"order": "B2"}},
+			want:       []string{"2"},
+			wantStatus: "done",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						if status := req.URL.Query().Get("status"); status != tt.wantStatus {
+							t.Errorf("status param = %v, want %v", status, tt.wantStatus)
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(bytes.NewReader([]byte(pages[req.URL.Query().Get("nextPageToken")]))),
+						}, nil
+					},
+				},
+			}
+
+			jobs, err := client.FindJobs(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("client.FindJobs() error = %v", err)
+			}
+
+			got := []string{}
+			for _, job := range jobs {
+				got = append(got, job.JobID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.FindJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
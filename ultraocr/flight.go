@@ -0,0 +1,81 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// flightGroup Coalesces identical in flight requests, so concurrent callers share one response.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+
+	// joined Is called when a caller joins an in flight call, letting the tests sync with the waiting callers
+	joined func(key string)
+}
+
+type flightCall struct {
+	done     chan struct{}
+	response Response
+	err      error
+}
+
+// do Calls fn once for concurrent calls with the same key, the other callers waiting for its response.
+// If the call fails with the context error of the first caller, a waiting caller with a live context
+// calls fn itself.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (Response, error)) (Response, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		if g.joined != nil {
+			g.joined(key)
+		}
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return Response{}, ctx.Err()
+		}
+
+		if isContextError(call.err) && ctx.Err() == nil {
+			return fn(ctx)
+		}
+
+		return call.response, call.err
+	}
+
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.response, call.err = fn(ctx)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.response, call.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// getShared Gets the URL, sharing the response with concurrent gets of the same URL on the Client.
+// Clients not created with NewClient don't share responses.
+func (client *Client) getShared(ctx context.Context, url string) (Response, error) {
+	if client.flights == nil {
+		return client.get(ctx, url, nil)
+	}
+
+	return client.flights.do(ctx, url, func(ctx context.Context) (Response, error) {
+		return client.get(ctx, url, nil)
+	})
+}
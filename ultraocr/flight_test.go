@@ -0,0 +1,99 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupShared(t *testing.T) {
+	const callers = 5
+	var requests atomic.Int32
+	release := make(chan struct{})
+
+	client := NewClient()
+	client.Token = "123"
+	client.ExpiresAt = time.Now().Add(time.Hour)
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			<-release
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done"}`))),
+			}, nil
+		},
+	})
+
+	joined := make(chan string, callers)
+	client.flights.joined = func(key string) {
+		joined <- key
+	}
+
+	var wg sync.WaitGroup
+	results := make([]JobResultResponse, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.GetJobResult(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+		}()
+	}
+
+	for i := 0; i < callers-1; i++ {
+		<-joined
+	}
+	close(release)
+	wg.Wait()
+
+	if requests.Load() != 1 {
+		t.Errorf("requests = %v, want 1", requests.Load())
+	}
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil || results[i].Status != "done" {
+			t.Errorf("client.GetJobResult() = %+v, %v", results[i], errs[i])
+		}
+	}
+}
+
+func TestFlightGroupCanceledCaller(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+
+	// the first caller is canceled once the second one waits for its call
+	ctx, cancel := context.WithCancel(context.Background())
+	g := flightGroup{joined: func(string) { cancel() }}
+	done := make(chan error)
+	go func() {
+		_, err := g.do(ctx, "key", func(ctx context.Context) (Response, error) {
+			calls.Add(1)
+			close(started)
+			<-ctx.Done()
+			return Response{}, ctx.Err()
+		})
+		done <- err
+	}()
+
+	<-started
+
+	got, err := g.do(context.Background(), "key", func(ctx context.Context) (Response, error) {
+		calls.Add(1)
+		return Response{status: 200}, nil
+	})
+	if err != nil || got.status != 200 {
+		t.Errorf("flightGroup.do() = %+v, %v, want the response of its own call", got, err)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("flightGroup.do() canceled error = %v, want %v", err, context.Canceled)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %v, want 2", calls.Load())
+	}
+}
@@ -0,0 +1,121 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// JobBuilder Describes a job step by step, to be sent with Submit. See Client.NewJob.
+type JobBuilder struct {
+	client *Client
+	req    JobRequest
+}
+
+// NewJob Starts a chain sending a job to the service, waiting for it and decoding its result, like
+//
+//	err := client.NewJob(service).File(path).Submit(ctx).Wait(ctx).DecodeInto(&out)
+//
+// The first error of the chain is kept and returned by its last call.
+func (client *Client) NewJob(service Service) *JobBuilder {
+	return &JobBuilder{
+		client: client,
+		req:    JobRequest{Service: service},
+	}
+}
+
+// File Sets the document file path.
+func (b *JobBuilder) File(path string) *JobBuilder {
+	return b.Source(FileSource(path))
+}
+
+// Source Sets the document source.
+func (b *JobBuilder) Source(src Source) *JobBuilder {
+	b.req.Document = src
+	return b
+}
+
+// WithFacematch Sends the selfie file for facematch.
+func (b *JobBuilder) WithFacematch(path string) *JobBuilder {
+	b.req.Selfie = FileSource(path)
+	b.req.Options.Facematch = true
+	return b
+}
+
+// WithExtraDocument Sends the extra document file, like the back of the document.
+func (b *JobBuilder) WithExtraDocument(path string) *JobBuilder {
+	b.req.ExtraDocument = FileSource(path)
+	b.req.Options.ExtraDocument = true
+	return b
+}
+
+// Metadata Sets the job metadata.
+func (b *JobBuilder) Metadata(metadata map[string]any) *JobBuilder {
+	b.req.Metadata = metadata
+	return b
+}
+
+// CallbackURL Sets the URL called when the job finishes.
+func (b *JobBuilder) CallbackURL(url string) *JobBuilder {
+	b.req.Options.CallbackURL = url
+	return b
+}
+
+// Request Returns the described job request.
+func (b *JobBuilder) Request() JobRequest {
+	return b.req
+}
+
+// Submit Sends the job with SubmitJob.
+func (b *JobBuilder) Submit(ctx context.Context) *SubmittedJob {
+	created, err := b.client.SubmitJob(ctx, b.req)
+	return &SubmittedJob{client: b.client, created: created, err: err}
+}
+
+// SubmittedJob A job sent by a JobBuilder, or the error sending it.
+type SubmittedJob struct {
+	client  *Client
+	created CreatedResponse
+	err     error
+}
+
+// Created Returns the created job, or the error sending it.
+func (j *SubmittedJob) Created() (CreatedResponse, error) {
+	return j.created, j.err
+}
+
+// Wait Waits for the job with WaitForJob. Jobs finishing with the error status fail with ErrJobFailed.
+func (j *SubmittedJob) Wait(ctx context.Context) *CompletedJob {
+	if j.err != nil {
+		return &CompletedJob{err: j.err}
+	}
+
+	result, err := j.client.WaitForJob(ctx, j.created.Id)
+	if err == nil && result.Status.IsError() {
+		err = fmt.Errorf("%w: %s: %s", common.ErrJobFailed, j.created.Id, result.Error)
+	}
+
+	return &CompletedJob{result: result, err: err}
+}
+
+// CompletedJob A finished job waited by a SubmittedJob, or the first error of the chain.
+type CompletedJob struct {
+	result JobResultResponse
+	err    error
+}
+
+// Result Returns the job result, or the first error of the chain.
+func (j *CompletedJob) Result() (JobResultResponse, error) {
+	return j.result, j.err
+}
+
+// DecodeInto Decodes the result document into the value pointed by v, like DecodeDocumentInto,
+// or returns the first error of the chain.
+func (j *CompletedJob) DecodeInto(v any) error {
+	if j.err != nil {
+		return j.err
+	}
+
+	return j.result.DecodeDocumentInto(v)
+}
@@ -0,0 +1,70 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestJobBuilder(t *testing.T) {
+	type document struct {
+		Nome string
+	}
+
+	tests := []struct {
+		name    string
+		build   func(b *JobBuilder) *JobBuilder
+		want    document
+		wantErr error
+	}{
+		{
+			name: "decoded",
+			build: func(b *JobBuilder) *JobBuilder {
+				return b.Source(StringSource("doc", "doc")).Metadata(map[string]any{"id": 1})
+			},
+			want: document{Nome: "JOSE"},
+		},
+		{
+			name:    "missing document",
+			build:   func(b *JobBuilder) *JobBuilder { return b },
+			wantErr: common.ErrMissingDocument,
+		},
+		{
+			name: "missing selfie",
+			build: func(b *JobBuilder) *JobBuilder {
+				b = b.Source(StringSource("doc", "doc"))
+				b.req.Options.Facematch = true
+				return b
+			},
+			wantErr: common.ErrMissingDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			api := ultraocrtest.NewFakeAPI(clock)
+			api.ProcessingTime = 5 * time.Second
+			api.Document = map[string]any{"Nome": "JOSE"}
+			client := newFakeClient(clock, api)
+
+			var got document
+			err := tt.build(client.NewJob(ServiceRG)).Submit(context.Background()).Wait(context.Background()).DecodeInto(&got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("JobBuilder chain error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("JobBuilder chain decoded = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	client := NewClient()
+	req := client.NewJob(ServiceRG).File("doc.pdf").WithFacematch("selfie.jpg").WithExtraDocument("back.pdf").CallbackURL("https://example.com").Request()
+	if !req.Options.Facematch || !req.Options.ExtraDocument || req.Options.CallbackURL != "https://example.com" || req.Selfie == nil || req.ExtraDocument == nil {
+		t.Errorf("JobBuilder.Request() = %+v", req)
+	}
+}
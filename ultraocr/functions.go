@@ -7,15 +7,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"maps"
 	"net/http"
-	"os"
+	neturl "net/url"
+	"sync"
 	"time"
 
 	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
 )
 
 // NewClient Creates a client to use UltraOCR utilities.
+// The created Client can be shared between goroutines.
 func NewClient() Client {
 	return Client{
 		BaseURL:     common.BASE_URL,
@@ -23,17 +26,33 @@ func NewClient() Client {
 		Interval:    common.POOLING_INTERVAL,
 		Timeout:     common.API_TIMEOUT,
 		HttpClient:  http.DefaultClient,
+		authMu:      &sync.Mutex{},
+		flights:     &flightGroup{},
 	}
 }
 
-// SetBaseURL Changes the Client Base URL.
-func (client *Client) SetBaseURL(url string) {
-	client.BaseURL = url
+// SetBaseURL Changes the Client Base URL. The URL must be absolute (http or https), and can have
+// a path, like an API behind a gateway prefix. Trailing slashes are removed.
+// Returns ErrInvalidBaseURL keeping the current URL if it is invalid.
+func (client *Client) SetBaseURL(url string) error {
+	normalized, err := normalizeBaseURL(url)
+	if err != nil {
+		return err
+	}
+
+	client.BaseURL = normalized
+	return nil
 }
 
-// SetAuthBaseURL Changes the Client Authentication Base URL.
-func (client *Client) SetAuthBaseURL(url string) {
-	client.AuthBaseURL = url
+// SetAuthBaseURL Changes the Client Authentication Base URL, validated as on SetBaseURL.
+func (client *Client) SetAuthBaseURL(url string) error {
+	normalized, err := normalizeBaseURL(url)
+	if err != nil {
+		return err
+	}
+
+	client.AuthBaseURL = normalized
+	return nil
 }
 
 // SetHttpClient Changes the Client HTTP Client.
@@ -51,33 +70,118 @@ func (client *Client) SetTimeout(timeout int) {
 	client.Timeout = timeout
 }
 
+// SetUseContextDeadline Changes the Client to ignore its timeout on waits when the context has a deadline.
+func (client *Client) SetUseContextDeadline(use bool) {
+	client.UseContextDeadline = use
+}
+
+// SetRefreshSkew Changes how long before the token expiration the Client refreshes it on auto refresh.
+func (client *Client) SetRefreshSkew(skew time.Duration) {
+	client.RefreshSkew = skew
+}
+
+// SetJobsConcurrency Changes how many jobs are polled at a time when waiting a batch jobs.
+func (client *Client) SetJobsConcurrency(concurrency int) {
+	client.JobsConcurrency = concurrency
+}
+
+// SetSoftFailExtra Changes a failure uploading the optional extra document to not abort the job
+// submission, warning it on the created response and the OnUploadSkipped hook instead.
+func (client *Client) SetSoftFailExtra(softFail bool) {
+	client.SoftFailExtra = softFail
+}
+
+// jobsConcurrency Returns how many jobs are handled at a time on batch helpers.
+func (client *Client) jobsConcurrency() int {
+	if client.JobsConcurrency <= 0 {
+		return common.DEFAULT_JOBS_CONCURRENCY
+	}
+
+	return client.JobsConcurrency
+}
+
+// SetErrorBudget Changes how many consecutive transient errors (network errors, 429 and 5xx)
+// the waits tolerate before failing.
+func (client *Client) SetErrorBudget(budget int) {
+	client.ErrorBudget = budget
+}
+
+// AddResultTransformer Adds a transformer applied to every job result fetched by the Client.
+// Transformers run in the order they were added.
+func (client *Client) AddResultTransformer(transformer ResultTransformer) {
+	client.Transformers = append(client.Transformers, transformer)
+}
+
 // SetAutoRefresh Changes Client to auto refresh token.
 func (client *Client) SetAutoRefresh(clientID, clientSecret string, expires int) {
 	client.ClientID = clientID
 	client.ClientSecret = clientSecret
 	client.Expires = expires
 	client.AutoRefresh = true
-	client.ExpiresAt = time.Now()
+	client.ExpiresAt = time.Time{}
 }
 
-func (client Client) request(
+func (client *Client) request(
 	ctx context.Context,
 	url,
 	method string,
 	body io.Reader,
 	params map[string]string,
 ) (Response, error) {
-	err := client.autoAuthenticate(ctx)
+	res, err := client.do(ctx, url, method, body, params)
 	if err != nil {
 		return Response{}, err
 	}
 
+	defer res.Body.Close()
+
+	resBody, _ := io.ReadAll(res.Body)
+	response := Response{
+		body:   resBody,
+		status: res.StatusCode,
+		url:    url,
+	}
+
+	// the request ID and the redacted body are only kept for the API errors
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		response.requestID = responseRequestID(res)
+		response.errorBody = []byte(client.redactBody(resBody, bearerToken(res.Request)))
+	}
+
+	return response, nil
+}
+
+// do Sends an authenticated request, the caller must close the response body.
+// Requests answered with 429 are retried as configured on the Client RateLimitRetry, failing
+// with a RateLimitError when the retries end.
+func (client *Client) do(
+	ctx context.Context,
+	url,
+	method string,
+	body io.Reader,
+	params map[string]string,
+) (*http.Response, error) {
+	token, err := client.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if body != nil {
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return nil, common.ErrMountingRequest
+		}
+
+		body = bytes.NewReader(data)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return Response{}, common.ErrMountingRequest
+		return nil, common.ErrMountingRequest
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Accept", "application/json")
 
 	q := req.URL.Query()
@@ -86,21 +190,81 @@ func (client Client) request(
 	}
 	req.URL.RawQuery = q.Encode()
 
-	res, err := client.HttpClient.Do(req)
-	if err != nil {
-		return Response{}, common.ErrDoingRequest
+	retry := client.rateLimitRetry()
+	for attempt := 1; ; attempt++ {
+		res, err := client.send(req, true)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", common.ErrDoingRequest, err)
+		}
+
+		if res.StatusCode != http.StatusTooManyRequests {
+			return res, nil
+		}
+
+		delay, retryAfter := retry.delay(res, attempt, client.clock().Now())
+		rateErr := client.rateLimitError(res, url, attempt, retryAfter)
+		if attempt >= retry.MaxAttempts || delay > retry.MaxDelay {
+			return nil, rateErr
+		}
+
+		retrying(client.Hooks, client.Metrics, RetryEvent{Phase: common.PHASE_REQUEST, Target: url, Attempt: attempt - 1, Delay: delay, Err: rateErr.Err})
+
+		err = client.sleep(ctx, delay)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", err, rateErr)
+		}
+
+		// the token may have expired during the sleep
+		token, err = client.accessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// the clone keeps the X-Request-Id, so the retries are correlated
+		req = req.Clone(ctx)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		if data != nil {
+			req.Body = io.NopCloser(bytes.NewReader(data))
+		}
 	}
+}
 
-	defer res.Body.Close()
+func (response Response) apiError() error {
+	return &common.APIError{
+		StatusCode: response.status,
+		Body:       response.errorBody,
+		RequestURL: response.url,
+		RequestID:  response.requestID,
+	}
+}
 
-	resBody, _ := io.ReadAll(res.Body)
-	return Response{
-		body:   resBody,
-		status: res.StatusCode,
-	}, nil
+// newAPIError Returns the APIError of the response, with its body redacted as on redactBody,
+// masking the request bearer token and the given secrets.
+func (client *Client) newAPIError(res *http.Response, url string, secrets ...string) *common.APIError {
+	body, _ := io.ReadAll(res.Body)
+	if res.Request != nil {
+		secrets = append(secrets, bearerToken(res.Request))
+	}
+
+	return &common.APIError{
+		StatusCode: res.StatusCode,
+		Body:       []byte(client.redactBody(body, secrets...)),
+		RequestURL: url,
+		RequestID:  responseRequestID(res),
+	}
 }
 
-func (client Client) post(
+func stripQuery(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	u.RawQuery = ""
+	return u.String()
+}
+
+func (client *Client) post(
 	ctx context.Context,
 	url string,
 	body any,
@@ -118,31 +282,159 @@ func (client Client) post(
 	return client.request(ctx, url, http.MethodPost, nil, params)
 }
 
-func (client Client) get(ctx context.Context, url string, params map[string]string) (Response, error) {
+func (client *Client) get(ctx context.Context, url string, params map[string]string) (Response, error) {
 	return client.request(ctx, url, http.MethodGet, nil, params)
 }
 
+// fallbackAuthMu Guards the token of clients not created with NewClient.
+var fallbackAuthMu sync.Mutex
+
+func (client *Client) lockAuth() func() {
+	mu := client.authMu
+	if mu == nil {
+		mu = &fallbackAuthMu
+	}
+
+	mu.Lock()
+	return mu.Unlock
+}
+
+// accessToken Returns the token to use on a request, refreshing it first if needed.
+func (client *Client) accessToken(ctx context.Context) (string, error) {
+	unlock := client.lockAuth()
+	defer unlock()
+
+	err := client.autoAuthenticate(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return client.Token, nil
+}
+
+// transformResult Applies the Client result transformers.
+func (client *Client) transformResult(result *JobResultResponse) error {
+	for _, transformer := range client.Transformers {
+		err := transformer(result)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// autoAuthenticate Refreshes the token if expired. Must be called holding the auth lock,
+// so concurrent requests wait for a single refresh instead of all authenticating.
 func (client *Client) autoAuthenticate(ctx context.Context) error {
-	if client.AutoRefresh && time.Now().After(client.ExpiresAt) {
-		return client.Authenticate(ctx, client.ClientID, client.ClientSecret, client.Expires)
+	if !client.AutoRefresh || !client.tokenExpired(client.ExpiresAt) {
+		return nil
+	}
+
+	err := client.loadCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if client.loadCachedToken(ctx) {
+		return nil
+	}
+
+	err = client.authenticate(ctx, client.ClientID, client.ClientSecret, client.Expires)
+	if err != nil {
+		return err
 	}
 
+	client.saveCachedToken(ctx)
 	return nil
 }
 
-func (client Client) uploadFile(ctx context.Context, url string, body io.Reader) error {
+func (client *Client) tokenExpired(expiresAt time.Time) bool {
+	return client.clock().Now().After(expiresAt.Add(-client.RefreshSkew))
+}
+
+// upload Uploads the source to the signed URL, returning its checksum when UploadChecksums is enabled.
+func (client *Client) upload(ctx context.Context, url string, src Source) (Checksum, error) {
+	markPhase(ctx, common.PHASE_UPLOAD)
+
+	release, err := client.acquireUpload(ctx)
+	if err != nil {
+		return Checksum{}, err
+	}
+
+	defer release()
+
+	var checksum Checksum
+	if client.UploadChecksums {
+		checksum, err = sourceChecksum(src)
+		if err != nil {
+			return Checksum{}, err
+		}
+	}
+
+	if client.UploadFunc != nil {
+		err = client.retryUpload(ctx, url, true, func(url string) error {
+			return client.UploadFunc(ctx, url, src)
+		})
+	} else if size := src.Size(); client.RangedUpload != nil && size > client.RangedUpload.partSize() && rangedUploadSupported(url) {
+		err = client.uploadRanged(ctx, url, src, size)
+	} else {
+		err = client.retryUpload(ctx, url, true, func(url string) error {
+			body, err := src.Open()
+			if err != nil {
+				return err
+			}
+
+			defer body.Close()
+
+			reader, contentType := uploadBody(src, body)
+			return client.uploadFile(ctx, url, reader, src.Size(), contentType, checksum)
+		})
+	}
+
+	if err != nil {
+		return Checksum{}, err
+	}
+
+	return checksum, nil
+}
+
+// uploadFile Uploads the body to the signed URL. A known size (zero or more) is sent as the
+// Content-Length, so streamed bodies are not sent chunked, a known type as the Content-Type
+// and a computed checksum on its headers.
+func (client Client) uploadFile(ctx context.Context, url string, body io.Reader, size int64, contentType string, checksum Checksum) error {
+	if size == 0 {
+		body = http.NoBody
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
 	if err != nil {
 		return common.ErrMountingRequest
 	}
 
-	res, err := client.HttpClient.Do(req)
+	if size >= 0 {
+		req.ContentLength = size
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	checksum.setHeaders(req, client.UploadChecksumHeaders)
+
+	res, err := client.send(req, false)
 	if err != nil {
-		return common.ErrDoingRequest
+		return fmt.Errorf("%w: %w", common.ErrDoingRequest, err)
+	}
+
+	defer res.Body.Close()
+
+	if redirected(res) {
+		return newRedirectError(res, stripQuery(url))
 	}
 
 	if res.StatusCode != 200 {
-		return common.ErrInvalidStatusCode
+		return client.newAPIError(res, stripQuery(url))
 	}
 
 	return nil
@@ -151,6 +443,26 @@ func (client Client) uploadFile(ctx context.Context, url string, body io.Reader)
 // Authenticate Generates a token on UltraOCR and save the token to use on future requests.
 // Requires the Client informations (ID and Secret) and the token expiration time (in minutes).
 func (client *Client) Authenticate(ctx context.Context, clientID, clientSecret string, expires int) error {
+	unlock := client.lockAuth()
+	defer unlock()
+
+	return client.authenticate(ctx, clientID, clientSecret, expires)
+}
+
+func (client *Client) authenticate(ctx context.Context, clientID, clientSecret string, expires int) error {
+	err := client.requestToken(ctx, clientID, clientSecret, expires)
+
+	event := AuthRefreshEvent{Err: err}
+	if err == nil {
+		event.ExpiresAt = client.ExpiresAt
+	}
+
+	client.Hooks.authRefreshed(event)
+	return err
+}
+
+// requestToken Requests a new token, keeping it on the Client.
+func (client *Client) requestToken(ctx context.Context, clientID, clientSecret string, expires int) error {
 	url := fmt.Sprintf("%s/token", client.AuthBaseURL)
 	body := map[string]any{
 		"ClientID":     clientID,
@@ -169,26 +481,40 @@ func (client *Client) Authenticate(ctx context.Context, clientID, clientSecret s
 	}
 	req.Header.Set("Accept", "application/json")
 
-	response, err := client.HttpClient.Do(req)
+	response, err := client.send(req, false)
 	if err != nil {
-		return common.ErrDoingRequest
+		return fmt.Errorf("%w: %w", common.ErrDoingRequest, client.redactError(err, clientSecret))
 	}
 
 	defer response.Body.Close()
 
-	resBody, _ := io.ReadAll(response.Body)
 	if response.StatusCode != 200 {
-		return common.ErrInvalidStatusCode
+		return client.newAPIError(response, url, clientSecret)
 	}
 
+	resBody, _ := io.ReadAll(response.Body)
+
 	var res tokenResponse
 	err = json.Unmarshal(resBody, &res)
 	if err != nil {
 		return common.ErrParsingResponse
 	}
 
+	// the token expiration is on the server clock, issued on the "iat" claim or the response date,
+	// falling back to the local clock
+	now := client.clock().Now()
+	issued, err := http.ParseTime(response.Header.Get("Date"))
+	if err != nil {
+		issued = now
+	}
+
+	lifetime := time.Duration(expires) * time.Minute
+	if valid, ok := tokenLifetime(res.Token, issued); ok && (expires <= 0 || valid < lifetime) {
+		lifetime = valid
+	}
+
 	client.Token = res.Token
-	client.ExpiresAt = time.Now().Add(time.Duration(expires) * time.Minute)
+	client.ExpiresAt = now.Add(lifetime)
 
 	return nil
 }
@@ -198,11 +524,30 @@ func (client *Client) Authenticate(ctx context.Context, clientID, clientSecret s
 // and the required metadata and query params.
 func (client *Client) GenerateSignedUrl(
 	ctx context.Context,
-	service,
+	service Service,
 	resource string,
 	metadata any,
 	params map[string]string,
 ) (SignedUrlResponse, error) {
+	markPhase(ctx, common.PHASE_SIGNED_URL)
+
+	release, err := client.acquireSubmission(ctx)
+	if err != nil {
+		return SignedUrlResponse{}, err
+	}
+
+	defer release()
+
+	metadata, err = client.serializeMetadata(metadata)
+	if err != nil {
+		return SignedUrlResponse{}, err
+	}
+
+	err = client.validateMetadata(service, metadata)
+	if err != nil {
+		return SignedUrlResponse{}, err
+	}
+
 	url := fmt.Sprintf("%s/ocr/%s/%s", client.BaseURL, resource, service)
 
 	response, err := client.post(ctx, url, metadata, params)
@@ -211,7 +556,7 @@ func (client *Client) GenerateSignedUrl(
 	}
 
 	if response.status != 200 {
-		return SignedUrlResponse{}, common.ErrInvalidStatusCode
+		return SignedUrlResponse{}, response.apiError()
 	}
 
 	var res SignedUrlResponse
@@ -224,33 +569,54 @@ func (client *Client) GenerateSignedUrl(
 }
 
 // UploadFileBase64 Upload a file on base64 format.
-// Requires the s3 URL and the data on base64 (string).
-func (client Client) UploadFileBase64(ctx context.Context, url string, data string) error {
-	return client.uploadFile(ctx, url, bytes.NewBufferString(data))
+// Requires the s3 URL and the data on base64 (string). Data URI prefixes are stripped.
+func (client *Client) UploadFileBase64(ctx context.Context, url string, data string) error {
+	_, err := client.upload(ctx, url, StringSource("base64", StripDataURI(data)))
+	return err
 }
 
 // UploadFileBase64 Upload a file given a path.
 // Requires the s3 URL and the file path.
-func (client Client) UploadFile(ctx context.Context, url string, path string) error {
-	f, err := os.ReadFile(path)
-	if err != nil {
-		return common.ErrReadFile
-	}
+func (client *Client) UploadFile(ctx context.Context, url string, path string) error {
+	_, err := client.upload(ctx, url, FileSource(path))
+	return err
+}
 
-	return client.uploadFile(ctx, url, bytes.NewBuffer(f))
+// UploadSource Upload a source.
+// Requires the s3 URL and the source.
+func (client *Client) UploadSource(ctx context.Context, url string, src Source) error {
+	_, err := client.upload(ctx, url, src)
+	return err
 }
 
 // GetBatchStatus Gets the batch status. Requires the batch ID.
 func (client *Client) GetBatchStatus(ctx context.Context, ID string) (BatchStatusResponse, error) {
+	err := ValidateID(ID)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	release, err := client.acquirePoll(ctx)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	defer release()
+
 	url := fmt.Sprintf("%s/ocr/batch/status/%s", client.BaseURL, ID)
 
-	response, err := client.get(ctx, url, nil)
+	return client.getBatchStatus(ctx, url)
+}
+
+// getBatchStatus Gets the batch status on the URL.
+func (client *Client) getBatchStatus(ctx context.Context, url string) (BatchStatusResponse, error) {
+	response, err := client.getShared(ctx, url)
 	if err != nil {
 		return BatchStatusResponse{}, err
 	}
 
 	if response.status != 200 {
-		return BatchStatusResponse{}, common.ErrInvalidStatusCode
+		return BatchStatusResponse{}, response.apiError()
 	}
 
 	var res BatchStatusResponse
@@ -262,17 +628,136 @@ func (client *Client) GetBatchStatus(ctx context.Context, ID string) (BatchStatu
 	return res, nil
 }
 
-// GetBatchStatus Gets the job result. Requires the batch and job ID.
+// GetBatchResults Gets the results of every job of a batch, in the batch order, getting up to the
+// Client jobs concurrency at a time. Stops on the first failure. Jobs not finished yet have their
+// current status, use WaitForBatchDone first to get only finished jobs. Finished jobs are stored on the
+// Client ResultSink, if any.
+func (client *Client) GetBatchResults(ctx context.Context, batchID string) ([]JobResultResponse, error) {
+	status, err := client.GetBatchStatus(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]JobResultResponse, len(status.Jobs))
+	errs := make(chan error, len(status.Jobs))
+	semaphore := make(chan struct{}, client.jobsConcurrency())
+
+	var wg sync.WaitGroup
+	for i, job := range status.Jobs {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result, err := client.GetJobResult(ctx, batchID, job.JobID)
+			if err == nil {
+				err = client.storeResult(ctx, result)
+			}
+
+			if err != nil {
+				errs <- err
+				cancel()
+				return
+			}
+
+			results[i] = result
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetJobResult Gets the job result. Requires the batch and job ID.
 func (client *Client) GetJobResult(ctx context.Context, batchID, jobID string) (JobResultResponse, error) {
+	err := validateIDs(batchID, jobID)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	release, err := client.acquirePoll(ctx)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	defer release()
+
+	url := fmt.Sprintf("%s/ocr/job/result/%s/%s", client.BaseURL, batchID, jobID)
+
+	return client.getJobResult(ctx, url)
+}
+
+// GetJobResultRaw Gets the job result as the exact JSON returned by the API, without transformers,
+// useful to store the payload for audits or to read fields not modeled on JobResultResponse.
+// Requires the batch and job ID.
+func (client *Client) GetJobResultRaw(ctx context.Context, batchID, jobID string) (json.RawMessage, error) {
+	err := validateIDs(batchID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := client.acquirePoll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
 	url := fmt.Sprintf("%s/ocr/job/result/%s/%s", client.BaseURL, batchID, jobID)
 
-	response, err := client.get(ctx, url, nil)
+	response, err := client.getShared(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.status != 200 {
+		return nil, response.apiError()
+	}
+
+	var res JobResultResponse
+	err = json.Unmarshal(response.body, &res)
+	if err != nil {
+		return nil, common.ErrParsingResponse
+	}
+
+	if res.JobID == "" {
+		res.JobID = jobID
+	}
+
+	return json.RawMessage(bytes.Clone(response.body)), client.auditResult(ctx, res)
+}
+
+// getJobResult Gets and transforms the job result on the URL.
+func (client *Client) getJobResult(ctx context.Context, url string) (JobResultResponse, error) {
+	response, err := client.getShared(ctx, url)
 	if err != nil {
 		return JobResultResponse{}, err
 	}
 
 	if response.status != 200 {
-		return JobResultResponse{}, common.ErrInvalidStatusCode
+		return JobResultResponse{}, response.apiError()
 	}
 
 	var res JobResultResponse
@@ -281,64 +766,220 @@ func (client *Client) GetJobResult(ctx context.Context, batchID, jobID string) (
 		return JobResultResponse{}, common.ErrParsingResponse
 	}
 
-	return res, nil
+	err = client.transformResult(&res)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	return res, client.auditResult(ctx, res)
 }
 
 // GetJobs Gets the jobs in a time interval.
 // Requires the start and end time in 2006-01-02 format.
 func (client *Client) GetJobs(ctx context.Context, start, end string) ([]JobResultResponse, error) {
+	return client.GetJobsWithFilter(ctx, start, end, JobsFilter{})
+}
+
+// GetJobsWithFilter Gets the jobs in a time interval like GetJobs, filtered by the API.
+func (client *Client) GetJobsWithFilter(ctx context.Context, start, end string, filter JobsFilter) ([]JobResultResponse, error) {
+	jobs := []JobResultResponse{}
+
+	it := client.JobsIterator(ctx, start, end)
+	it.filter = filter
+	for it.Next() {
+		jobs = append(jobs, it.Job())
+	}
+
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	return jobs, nil
+}
+
+// getJobsPage Gets a page of the jobs in a time interval, the first page without a page token.
+func (client *Client) getJobsPage(ctx context.Context, start, end, pageToken string, filter JobsFilter) (GetJobsResponse, error) {
 	url := fmt.Sprintf("%s/ocr/job/results", client.BaseURL)
-	params := map[string]string{
-		"startDate": start,
-		"endtDate":  end,
+	params := filter.Params()
+	params["startDate"] = start
+	params["endtDate"] = end
+
+	if pageToken != "" {
+		params["nextPageToken"] = pageToken
 	}
 
-	jobs := []JobResultResponse{}
-	hasNextPage := true
+	release, err := client.acquirePoll(ctx)
+	if err != nil {
+		return GetJobsResponse{}, err
+	}
 
-	for hasNextPage {
-		response, err := client.get(ctx, url, params)
+	response, err := client.get(ctx, url, params)
+	release()
+	if err != nil {
+		return GetJobsResponse{}, err
+	}
+
+	if response.status != 200 {
+		return GetJobsResponse{}, response.apiError()
+	}
+
+	var res GetJobsResponse
+	err = json.Unmarshal(response.body, &res)
+	if err != nil {
+		return GetJobsResponse{}, common.ErrParsingResponse
+	}
+
+	for i := range res.Jobs {
+		err = client.transformResult(&res.Jobs[i])
 		if err != nil {
-			return nil, err
+			return GetJobsResponse{}, err
 		}
 
-		if response.status != 200 {
-			return nil, common.ErrInvalidStatusCode
+		err = client.auditResult(ctx, res.Jobs[i])
+		if err != nil {
+			return GetJobsResponse{}, err
 		}
+	}
 
-		var res GetJobsResponse
-		err = json.Unmarshal(response.body, &res)
+	return res, nil
+}
+
+// GetBatches Gets the batches in a time interval, filtered by the API, getting every page.
+// Requires the start and end time in 2006-01-02 format.
+func (client *Client) GetBatches(ctx context.Context, start, end string, filter BatchesFilter) ([]BatchStatusResponse, error) {
+	batches := []BatchStatusResponse{}
+	pageToken := ""
+
+	for {
+		res, err := client.getBatchesPage(ctx, start, end, pageToken, filter)
 		if err != nil {
-			return nil, common.ErrParsingResponse
+			return nil, err
 		}
 
-		jobs = append(jobs, res.Jobs...)
-		params["nextPageToken"] = res.NextPageToken
-
+		batches = append(batches, res.Batches...)
 		if res.NextPageToken == "" {
-			hasNextPage = false
+			return batches, nil
 		}
+
+		pageToken = res.NextPageToken
 	}
+}
 
-	return jobs, nil
+// getBatchesPage Gets a page of the batches in a time interval.
+func (client *Client) getBatchesPage(ctx context.Context, start, end, pageToken string, filter BatchesFilter) (GetBatchesResponse, error) {
+	url := fmt.Sprintf("%s/ocr/batch/results", client.BaseURL)
+	params := filter.Params()
+	params["startDate"] = start
+	params["endtDate"] = end
+
+	if pageToken != "" {
+		params["nextPageToken"] = pageToken
+	}
+
+	release, err := client.acquirePoll(ctx)
+	if err != nil {
+		return GetBatchesResponse{}, err
+	}
+
+	response, err := client.get(ctx, url, params)
+	release()
+	if err != nil {
+		return GetBatchesResponse{}, err
+	}
+
+	if response.status != 200 {
+		return GetBatchesResponse{}, response.apiError()
+	}
+
+	var res GetBatchesResponse
+	err = json.Unmarshal(response.body, &res)
+	if err != nil {
+		return GetBatchesResponse{}, common.ErrParsingResponse
+	}
+
+	return res, nil
 }
 
 // SendJobSingleStep Sends a job in single step, with 6MB body limit.
 // Requires the service, the files (facematch and extra file if requested on params)
 // on base64 format and the required metadata and query params.
+// Bodies over the limit fail with ErrPayloadTooLarge before any request. Data URI prefixes are stripped.
 func (client *Client) SendJobSingleStep(
 	ctx context.Context,
-	service,
+	service Service,
 	file,
 	facematchFile,
 	extraFile string,
 	metadata map[string]any,
 	params map[string]string,
 ) (CreatedResponse, error) {
+	file, facematchFile, extraFile = StripDataURI(file), StripDataURI(facematchFile), StripDataURI(extraFile)
+
+	files := []Source{StringSource("document", file)}
+	if params[common.KEY_FACEMATCH] == common.FLAG_TRUE {
+		files = append(files, StringSource("selfie", facematchFile))
+	}
+
+	if params[common.KEY_EXTRA] == common.FLAG_TRUE {
+		files = append(files, StringSource("extra_document", extraFile))
+	}
+
+	for _, src := range files {
+		err := client.checkContentType(src, true)
+		if err != nil {
+			return CreatedResponse{}, err
+		}
+	}
+
+	if client.Preprocess != nil {
+		data := []*string{&file}
+		if params[common.KEY_FACEMATCH] == common.FLAG_TRUE {
+			data = append(data, &facematchFile)
+		}
+
+		if params[common.KEY_EXTRA] == common.FLAG_TRUE {
+			data = append(data, &extraFile)
+		}
+
+		// Share the body limit between the files, as decoded bytes.
+		limit := int64(common.SINGLE_STEP_LIMIT / 4 * 3 / len(data))
+		for _, d := range data {
+			var err error
+			*d, err = client.preprocessBase64(*d, limit)
+			if err != nil {
+				return CreatedResponse{}, err
+			}
+		}
+	}
+
+	if params[common.KEY_FACEMATCH] == common.FLAG_TRUE {
+		err := client.checkSelfieBase64(ctx, facematchFile)
+		if err != nil {
+			return CreatedResponse{}, err
+		}
+	}
+
+	release, err := client.acquireSubmission(ctx)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	defer release()
+
+	serialized, err := client.serializeMetadata(metadata)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	err = client.validateMetadata(service, serialized)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
 	url := fmt.Sprintf("%s/ocr/job/send/%s", client.BaseURL, service)
 	body := map[string]any{
 		"data":     file,
-		"metadata": metadata,
+		"metadata": serialized,
 	}
 
 	if params[common.KEY_EXTRA] == common.FLAG_TRUE {
@@ -350,13 +991,18 @@ func (client *Client) SendJobSingleStep(
 		body[common.KEY_FACEMATCH] = facematchFile
 	}
 
+	err = checkPayloadSize(body)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
 	response, err := client.post(ctx, url, body, params)
 	if err != nil {
 		return CreatedResponse{}, err
 	}
 
 	if response.status != 200 {
-		return CreatedResponse{}, common.ErrInvalidStatusCode
+		return CreatedResponse{}, response.apiError()
 	}
 
 	var res CreatedResponse
@@ -365,14 +1011,32 @@ func (client *Client) SendJobSingleStep(
 		return CreatedResponse{}, common.ErrParsingResponse
 	}
 
-	return res, nil
+	client.emit(JobSubmitted{Resource: common.RESOURCE_JOB, ID: res.Id, Service: service, Time: client.clock().Now()})
+	return res, client.auditSubmit(ctx, common.RESOURCE_JOB, res.Id, service, metadata)
+}
+
+// checkPayloadSize Checks the encoded single step body fits on the API body limit.
+func checkPayloadSize(body map[string]any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return common.ErrParsingRequestBody
+	}
+
+	if len(data) > common.SINGLE_STEP_LIMIT {
+		return fmt.Errorf(
+			"%w: body of %d bytes exceeds the %d bytes limit, send it through a signed URL with SendJobBase64 or SubmitJob",
+			common.ErrPayloadTooLarge, len(data), common.SINGLE_STEP_LIMIT,
+		)
+	}
+
+	return nil
 }
 
 // SendJobBase64 Sends a job on base64 format.
 // Requires the service, the files (facematch and extra file if requested on params)
-// on base64 format and the required metadata and query params.
+// on base64 format and the required metadata and query params. Data URI prefixes are stripped.
 func (client *Client) SendJobBase64(ctx context.Context,
-	service,
+	service Service,
 	file,
 	facematchFile,
 	extraFile string,
@@ -380,232 +1044,280 @@ func (client *Client) SendJobBase64(ctx context.Context,
 	params map[string]string,
 ) (CreatedResponse, error) {
 	p := map[string]string{
-		"base64": common.FLAG_TRUE,
+		common.KEY_BASE64: common.FLAG_TRUE,
 	}
 	maps.Copy(p, params)
 
-	response, err := client.GenerateSignedUrl(ctx, service, common.RESOURCE_JOB, metadata, p)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
-
-	urls := response.URLs
-	err = client.UploadFileBase64(ctx, urls["document"], file)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
-
-	if p[common.KEY_FACEMATCH] == common.FLAG_TRUE {
-		err = client.UploadFileBase64(ctx, urls["selfie"], facematchFile)
-		if err != nil {
-			return CreatedResponse{}, err
-		}
-	}
-
-	if p[common.KEY_EXTRA] == common.FLAG_TRUE {
-		err = client.UploadFileBase64(ctx, urls["extra_document"], extraFile)
-		if err != nil {
-			return CreatedResponse{}, err
-		}
-	}
-
-	return CreatedResponse{
-		Id:        response.Id,
-		StatusURL: response.StatusURL,
-	}, nil
+	return client.submitJob(ctx, JobRequest{
+		Service:       service,
+		Document:      StringSource("base64", StripDataURI(file)),
+		Selfie:        StringSource("base64", StripDataURI(facematchFile)),
+		ExtraDocument: StringSource("base64", StripDataURI(extraFile)),
+		Metadata:      metadata,
+	}, p)
 }
 
 // SendJob Sends a job.
 // Requires the service, the files (facematch and extra file if requested on params) paths
 // and the required metadata and query params.
 func (client *Client) SendJob(ctx context.Context,
-	service,
+	service Service,
 	filePath,
 	facematchFilePath,
 	extraFilePath string,
 	metadata map[string]any,
 	params map[string]string,
 ) (CreatedResponse, error) {
-	response, err := client.GenerateSignedUrl(ctx, service, common.RESOURCE_JOB, metadata, params)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
-
-	urls := response.URLs
-	err = client.UploadFile(ctx, urls["document"], filePath)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
-
-	if params[common.KEY_FACEMATCH] == common.FLAG_TRUE {
-		err = client.UploadFile(ctx, urls["selfie"], facematchFilePath)
-		if err != nil {
-			return CreatedResponse{}, err
-		}
-	}
-
-	if params[common.KEY_EXTRA] == common.FLAG_TRUE {
-		err = client.UploadFile(ctx, urls["extra_document"], extraFilePath)
-		if err != nil {
-			return CreatedResponse{}, err
-		}
-	}
-
-	return CreatedResponse{
-		Id:        response.Id,
-		StatusURL: response.StatusURL,
-	}, nil
+	return client.submitJob(ctx, JobRequest{
+		Service:       service,
+		Document:      FileSource(filePath),
+		Selfie:        FileSource(facematchFilePath),
+		ExtraDocument: FileSource(extraFilePath),
+		Metadata:      metadata,
+	}, params)
 }
 
 // SendBatchBase64 Sends a batch on base64 format.
 // Requires the service, the file on base64 format and the required metadata and query params.
+// Data URI prefixes are stripped.
 func (client *Client) SendBatchBase64(ctx context.Context,
-	service,
+	service Service,
 	file string,
 	metadata []map[string]any,
 	params map[string]string,
 ) (CreatedResponse, error) {
 	p := map[string]string{
-		"base64": common.FLAG_TRUE,
+		common.KEY_BASE64: common.FLAG_TRUE,
 	}
 	maps.Copy(p, params)
 
-	response, err := client.GenerateSignedUrl(ctx, service, common.RESOURCE_BATCH, metadata, p)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
-
-	urls := response.URLs
-	err = client.UploadFileBase64(ctx, urls["document"], file)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
-
-	return CreatedResponse{
-		Id:        response.Id,
-		StatusURL: response.StatusURL,
-	}, nil
+	return client.submitBatch(ctx, BatchRequest{
+		Service:  service,
+		Document: StringSource("base64", StripDataURI(file)),
+		Metadata: metadata,
+	}, p)
 }
 
 // SendBatch Sends a batch.
 // Requires the service, the file path and the required metadata and query params.
 func (client *Client) SendBatch(ctx context.Context,
-	service,
+	service Service,
 	filePath string,
 	metadata []map[string]any,
 	params map[string]string,
 ) (CreatedResponse, error) {
-	response, err := client.GenerateSignedUrl(ctx, service, common.RESOURCE_BATCH, metadata, params)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
+	return client.submitBatch(ctx, BatchRequest{
+		Service:  service,
+		Document: FileSource(filePath),
+		Metadata: metadata,
+	}, params)
+}
 
-	urls := response.URLs
-	err = client.UploadFile(ctx, urls["document"], filePath)
-	if err != nil {
-		return CreatedResponse{}, err
-	}
+// SendJobFS Sends a job reading the files from a file system, like an embed.FS or a zip archive.
+// Requires the file system, the service, the files (facematch and extra file if requested on params)
+// paths on it and the required metadata and query params.
+func (client *Client) SendJobFS(ctx context.Context,
+	fsys fs.FS,
+	service Service,
+	filePath,
+	facematchFilePath,
+	extraFilePath string,
+	metadata map[string]any,
+	params map[string]string,
+) (CreatedResponse, error) {
+	return client.submitJob(ctx, JobRequest{
+		Service:       service,
+		Document:      FSSource(fsys, filePath),
+		Selfie:        FSSource(fsys, facematchFilePath),
+		ExtraDocument: FSSource(fsys, extraFilePath),
+		Metadata:      metadata,
+	}, params)
+}
 
-	return CreatedResponse{
-		Id:        response.Id,
-		StatusURL: response.StatusURL,
-	}, nil
+// SendBatchFS Sends a batch reading the file from a file system, like an embed.FS or a zip archive.
+// Requires the file system, the service, the file path on it and the required metadata and query params.
+func (client *Client) SendBatchFS(ctx context.Context,
+	fsys fs.FS,
+	service Service,
+	filePath string,
+	metadata []map[string]any,
+	params map[string]string,
+) (CreatedResponse, error) {
+	return client.submitBatch(ctx, BatchRequest{
+		Service:  service,
+		Document: FSSource(fsys, filePath),
+		Metadata: metadata,
+	}, params)
 }
 
 // WaitForJobDone Waits for the job status be done or error.
 // Have a timeout and an interval configured on the Client.
 // Requires the batch and job ID.
 func (client *Client) WaitForJobDone(ctx context.Context, batchID, jobID string) (JobResultResponse, error) {
-	timeout := time.Now().Add(time.Duration(client.Timeout) * time.Second)
-	for {
-		result, err := client.GetJobResult(ctx, batchID, jobID)
-		if err != nil {
-			return JobResultResponse{}, err
-		}
+	err := validateIDs(batchID, jobID)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
 
-		if result.Status == common.STATUS_DONE || result.Status == common.STATUS_ERROR {
-			return result, nil
-		}
+	var result JobResultResponse
+	var status Status
 
-		if time.Now().After(timeout) {
-			return JobResultResponse{}, common.ErrTimeout
+	poller := client.NewPoller(common.RESOURCE_JOB, jobID)
+	err = poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		result, err = client.GetJobResult(ctx, batchID, jobID)
+		if err == nil {
+			client.statusChanged(common.RESOURCE_JOB, jobID, &status, result.Status)
 		}
 
-		time.Sleep(time.Second * time.Duration(client.Interval))
+		return result.Status.IsTerminal(), err
+	})
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	client.jobFinished(jobID, result)
+	return result, nil
+}
+
+// WaitForJob Waits for a job not belonging to a batch, like single step and signed URL jobs, as WaitForJobDone.
+// Requires the job ID.
+func (client *Client) WaitForJob(ctx context.Context, jobID string) (JobResultResponse, error) {
+	return client.WaitForJobDone(ctx, jobID, jobID)
+}
+
+// WaitFromStatusURL Waits for the job status be done or error, with the IDs of the status URL returned
+// on the job creation, as WaitForJobDone. Fails with ErrInvalidStatusURL, before any request, if it is not
+// a job status URL of the Client BaseURL.
+func (client *Client) WaitFromStatusURL(ctx context.Context, statusURL string) (JobResultResponse, error) {
+	ref, err := client.parseStatusURL(statusURL)
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	if ref.resource != common.RESOURCE_JOB {
+		return JobResultResponse{}, fmt.Errorf("%w: %q is not a job status URL", common.ErrInvalidStatusURL, stripQuery(statusURL))
 	}
+
+	return client.WaitForJobDone(ctx, ref.batchID, ref.jobID)
 }
 
 // WaitForBatchDone Waits for the batch status be done or error.
 // Have a timeout and an interval configured on the Client.
 // Requires the batch and an info if the utility will also wait the jobs to be done.
+// Composite IDs of split batches wait every batch concurrently, under one timeout, merging their status.
 func (client *Client) WaitForBatchDone(ctx context.Context, ID string, waitJobs bool) (BatchStatusResponse, error) {
-	timeout := time.Now().Add(time.Duration(client.Timeout) * time.Second)
+	if isCompositeBatchID(ID) {
+		return client.waitCompositeBatch(ctx, ID, waitJobs)
+	}
+
+	err := ValidateID(ID)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
 	var result BatchStatusResponse
-	var err error
+	var status Status
 
-	for {
+	poller := client.NewPoller(common.RESOURCE_BATCH, ID)
+	err = poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		var err error
 		result, err = client.GetBatchStatus(ctx, ID)
+		if err == nil {
+			client.statusChanged(common.RESOURCE_BATCH, ID, &status, result.Status)
+		}
+
+		return result.Status.IsTerminal(), err
+	})
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	if waitJobs {
+		err = client.waitBatchJobs(ctx, ID, result.Jobs)
 		if err != nil {
 			return BatchStatusResponse{}, err
 		}
+	}
 
-		if result.Status == common.STATUS_DONE || result.Status == common.STATUS_ERROR {
-			break
-		}
+	return result, nil
+}
 
-		if time.Now().After(timeout) {
-			return BatchStatusResponse{}, common.ErrTimeout
-		}
+// waitBatchJobs Waits the batch jobs concurrently, up to the Client jobs concurrency,
+// stopping on the first failure.
+func (client *Client) waitBatchJobs(ctx context.Context, ID string, jobs []BatchStatusJobs) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		time.Sleep(time.Second * time.Duration(client.Interval))
+	refs := make([]JobRef, len(jobs))
+	for i, job := range jobs {
+		refs[i] = JobRef{BatchID: ID, JobID: job.JobID}
 	}
 
-	if waitJobs {
-		for _, job := range result.Jobs {
-			_, err := client.WaitForJobDone(ctx, ID, job.JobID)
-			if err != nil {
-				return BatchStatusResponse{}, err
-			}
+	var err error
+	for result := range client.WaitForJobsDone(ctx, refs, client.jobsConcurrency()) {
+		if result.Err != nil && err == nil {
+			err = result.Err
+			cancel()
 		}
 	}
 
-	return result, nil
+	return err
 }
 
 // CreateAndWaitJob Creates and wait a job to be done.
 // Have a timeout and an interval configured on the Client.
 // Requires the service, files paths and required metadata and query params.
 func (client *Client) CreateAndWaitJob(ctx context.Context,
-	service,
+	service Service,
 	filePath,
 	facematchFilePath,
 	extraFilePath string,
 	metadata map[string]any,
 	params map[string]string,
 ) (JobResultResponse, error) {
-	response, err := client.SendJob(ctx, service, filePath, facematchFilePath, extraFilePath, metadata, params)
+	var result JobResultResponse
+
+	err := client.withJobBudget(ctx, func(ctx context.Context) error {
+		response, err := client.SendJob(ctx, service, filePath, facematchFilePath, extraFilePath, metadata, params)
+		if err != nil {
+			return err
+		}
+
+		result, err = client.WaitForJob(ctx, response.Id)
+		return err
+	})
 	if err != nil {
 		return JobResultResponse{}, err
 	}
 
-	jobID := response.Id
-	return client.WaitForJobDone(ctx, jobID, jobID)
+	return result, nil
 }
 
-// CreateAndWaitJob Creates and wait a batch to be done.
+// CreateAndWaitBatch Creates and wait a batch to be done.
 // Have a timeout and an interval configured on the Client.
 // Requires the service, file path and required metadata and query params.
 func (client *Client) CreateAndWaitBatch(ctx context.Context,
-	service,
+	service Service,
 	filePath string,
 	metadata []map[string]any,
 	params map[string]string,
 	waitJobs bool,
 ) (BatchStatusResponse, error) {
-	response, err := client.SendBatch(ctx, service, filePath, metadata, params)
+	var result BatchStatusResponse
+
+	err := client.withJobBudget(ctx, func(ctx context.Context) error {
+		response, err := client.SendBatch(ctx, service, filePath, metadata, params)
+		if err != nil {
+			return err
+		}
+
+		result, err = client.WaitForBatchDone(ctx, response.Id, waitJobs)
+		return err
+	})
 	if err != nil {
 		return BatchStatusResponse{}, err
 	}
 
-	return client.WaitForBatchDone(ctx, response.Id, waitJobs)
+	return result, nil
 }
@@ -7,9 +7,12 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -37,6 +40,8 @@ func TestNewClient(t *testing.T) {
 				Interval:    common.POOLING_INTERVAL,
 				Timeout:     common.API_TIMEOUT,
 				HttpClient:  http.DefaultClient,
+				authMu:      &sync.Mutex{},
+				flights:     &flightGroup{},
 			},
 		},
 	}
@@ -53,25 +58,33 @@ func TestSets(t *testing.T) {
 	t.Run("test sets", func(t *testing.T) {
 		c := NewClient()
 
-		c.SetBaseURL("url")
+		if err := c.SetBaseURL("https://gateway.example.com/ultraocr/v2/"); err != nil {
+			t.Fatalf("c.SetBaseURL() error = %v", err)
+		}
 		want := Client{
-			BaseURL:     "url",
+			BaseURL:     "https://gateway.example.com/ultraocr/v2",
 			AuthBaseURL: common.AUTH_BASE_URL,
 			Interval:    common.POOLING_INTERVAL,
 			Timeout:     common.API_TIMEOUT,
 			HttpClient:  http.DefaultClient,
+			authMu:      &sync.Mutex{},
+			flights:     &flightGroup{},
 		}
 		if !reflect.DeepEqual(c, want) {
 			t.Errorf("client = %v, want %v", c, want)
 		}
 
-		c.SetAuthBaseURL("url")
+		if err := c.SetAuthBaseURL("https://auth.example.com/v2"); err != nil {
+			t.Fatalf("c.SetAuthBaseURL() error = %v", err)
+		}
 		want = Client{
-			BaseURL:     "url",
-			AuthBaseURL: "url",
+			BaseURL:     "https://gateway.example.com/ultraocr/v2",
+			AuthBaseURL: "https://auth.example.com/v2",
 			Interval:    common.POOLING_INTERVAL,
 			Timeout:     common.API_TIMEOUT,
 			HttpClient:  http.DefaultClient,
+			authMu:      &sync.Mutex{},
+			flights:     &flightGroup{},
 		}
 		if !reflect.DeepEqual(c, want) {
 			t.Errorf("client = %v, want %v", c, want)
@@ -79,11 +92,13 @@ func TestSets(t *testing.T) {
 
 		c.SetInterval(3)
 		want = Client{
-			BaseURL:     "url",
-			AuthBaseURL: "url",
+			BaseURL:     "https://gateway.example.com/ultraocr/v2",
+			AuthBaseURL: "https://auth.example.com/v2",
 			Interval:    3,
 			Timeout:     common.API_TIMEOUT,
 			HttpClient:  http.DefaultClient,
+			authMu:      &sync.Mutex{},
+			flights:     &flightGroup{},
 		}
 		if !reflect.DeepEqual(c, want) {
 			t.Errorf("client = %v, want %v", c, want)
@@ -91,11 +106,13 @@ func TestSets(t *testing.T) {
 
 		c.SetTimeout(10)
 		want = Client{
-			BaseURL:     "url",
-			AuthBaseURL: "url",
+			BaseURL:     "https://gateway.example.com/ultraocr/v2",
+			AuthBaseURL: "https://auth.example.com/v2",
 			Interval:    3,
 			Timeout:     10,
 			HttpClient:  http.DefaultClient,
+			authMu:      &sync.Mutex{},
+			flights:     &flightGroup{},
 		}
 		if !reflect.DeepEqual(c, want) {
 			t.Errorf("client = %v, want %v", c, want)
@@ -105,13 +122,15 @@ func TestSets(t *testing.T) {
 			Timeout: 20,
 		})
 		want = Client{
-			BaseURL:     "url",
-			AuthBaseURL: "url",
+			BaseURL:     "https://gateway.example.com/ultraocr/v2",
+			AuthBaseURL: "https://auth.example.com/v2",
 			Interval:    3,
 			Timeout:     10,
 			HttpClient: &http.Client{
 				Timeout: 20,
 			},
+			authMu:  &sync.Mutex{},
+			flights: &flightGroup{},
 		}
 		if !reflect.DeepEqual(c, want) {
 			t.Errorf("client = %v, want %v", c, want)
@@ -119,8 +138,8 @@ func TestSets(t *testing.T) {
 
 		c.SetAutoRefresh("id", "secret", 10)
 		want = Client{
-			BaseURL:     "url",
-			AuthBaseURL: "url",
+			BaseURL:     "https://gateway.example.com/ultraocr/v2",
+			AuthBaseURL: "https://auth.example.com/v2",
 			Interval:    3,
 			Timeout:     10,
 			HttpClient: &http.Client{
@@ -503,7 +522,7 @@ func Test_UploadFile(t *testing.T) {
 			client := Client{
 				HttpClient: tt.fields.HttpClient,
 			}
-			if err := client.uploadFile(tt.args.ctx, tt.args.url, tt.args.body); (err != nil) != tt.wantErr {
+			if err := client.uploadFile(tt.args.ctx, tt.args.url, tt.args.body, -1, "", Checksum{}); (err != nil) != tt.wantErr {
 				t.Errorf("client.UploadFile() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -598,7 +617,7 @@ func TestGenerateSignedUrl(t *testing.T) {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service  string
+		service  Service
 		resource string
 		metadata map[string]any
 		params   map[string]string
@@ -812,24 +831,25 @@ func TestGetBatchStatus(t *testing.T) {
 	}{
 		{
 			name: "success",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"123","created_at":"2024-01-01","status":"done","service":"rg","jobs":[{"job_ksuid":"1234","created_at":"2024-01-01","status":"done","result_url":"url"}]}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","created_at":"2024-01-01","status":"done","service":"rg","jobs":[{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","created_at":"2024-01-01","status":"done","result_url":"url"}]}`))),
 						}, nil
 					},
 				},
 			},
 			want: BatchStatusResponse{
-				BatchID:   "123",
+				BatchID:   "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				CreatedAt: "2024-01-01",
 				Service:   "rg",
 				Status:    "done",
 				Jobs: []BatchStatusJobs{
 					{
-						JobID:     "1234",
+						JobID:     "2AwrSd7bxEMbPrQ5jZHGDzQ4qL3",
 						CreatedAt: "2024-01-01",
 						ResultURL: "url",
 						Status:    "done",
@@ -839,10 +859,11 @@ func TestGetBatchStatus(t *testing.T) {
 		},
 		{
 			name: "failed doing request",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
-						return nil, errors.New("123")
+						return nil, errors.New("0ujsszwN8NRY24YaXiTIE2VWDTS")
 					},
 				},
 			},
@@ -850,6 +871,7 @@ func TestGetBatchStatus(t *testing.T) {
 		},
 		{
 			name: "invalid status",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
@@ -897,18 +919,19 @@ func TestGetJobResult(t *testing.T) {
 	}{
 		{
 			name: "success",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS", jobID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"1234","created_at":"2024-01-01","status":"done","service":"rg"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","created_at":"2024-01-01","status":"done","service":"rg"}`))),
 						}, nil
 					},
 				},
 			},
 			want: JobResultResponse{
-				JobID:     "1234",
+				JobID:     "2AwrSd7bxEMbPrQ5jZHGDzQ4qL3",
 				CreatedAt: "2024-01-01",
 				Service:   "rg",
 				Status:    "done",
@@ -916,10 +939,11 @@ func TestGetJobResult(t *testing.T) {
 		},
 		{
 			name: "failed doing request",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS", jobID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
-						return nil, errors.New("123")
+						return nil, errors.New("0ujsszwN8NRY24YaXiTIE2VWDTS")
 					},
 				},
 			},
@@ -927,6 +951,7 @@ func TestGetJobResult(t *testing.T) {
 		},
 		{
 			name: "invalid status",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS", jobID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
@@ -1050,12 +1075,137 @@ func TestGetJobs(t *testing.T) {
 	}
 }
 
+func TestGetJobsWithFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter JobsFilter
+		want   url.Values
+	}{
+		{
+			name: "no filter",
+			want: url.Values{"startDate": {"2024-01-01"}, "endtDate": {"2024-01-31"}},
+		},
+		{
+			name: "all filters",
+			filter: JobsFilter{
+				Status:           StatusError,
+				Service:          "rg",
+				ValidationStatus: "pending",
+				ClientData:       "tag",
+			},
+			want: url.Values{
+				"startDate":        {"2024-01-01"},
+				"endtDate":         {"2024-01-31"},
+				"status":           {"error"},
+				"service":          {"rg"},
+				"validationStatus": {"pending"},
+				"clientData":       {"tag"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got url.Values
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						got = req.URL.Query()
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"jobs":[{"job_ksuid":"1234"}]}`))),
+						}, nil
+					},
+				},
+			}
+
+			jobs, err := client.GetJobsWithFilter(context.Background(), "2024-01-01", "2024-01-31", tt.filter)
+			if err != nil {
+				t.Fatalf("client.GetJobsWithFilter() error = %v", err)
+			}
+			if len(jobs) != 1 {
+				t.Errorf("client.GetJobsWithFilter() jobs = %v, want 1", len(jobs))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.GetJobsWithFilter() params = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBatches(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"batches":[{"batch_ksuid":"1","service":"rg","status":"done"}],"nextPageToken":"p2"}`,
+		"p2": `{"batches":[{"batch_ksuid":"2","service":"rg","status":"error"}]}`,
+	}
+
+	tests := []struct {
+		name       string
+		filter     BatchesFilter
+		status     int
+		want       []BatchStatusResponse
+		wantParams url.Values
+		wantErr    error
+	}{
+		{
+			name:   "pages",
+			status: 200,
+			want: []BatchStatusResponse{
+				{BatchID: "1", Service: "rg", Status: StatusDone},
+				{BatchID: "2", Service: "rg", Status: StatusError},
+			},
+			wantParams: url.Values{"startDate": {"2024-01-01"}, "endtDate": {"2024-01-31"}, "nextPageToken": {"p2"}},
+		},
+		{
+			name:       "filter",
+			filter:     BatchesFilter{Status: StatusDone, Service: "rg"},
+			status:     200,
+			want:       []BatchStatusResponse{{BatchID: "1", Service: "rg", Status: StatusDone}, {BatchID: "2", Service: "rg", Status: StatusError}},
+			wantParams: url.Values{"startDate": {"2024-01-01"}, "endtDate": {"2024-01-31"}, "nextPageToken": {"p2"}, "status": {"done"}, "service": {"rg"}},
+		},
+		{
+			name:    "invalid status",
+			status:  403,
+			wantErr: common.ErrInvalidStatusCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params url.Values
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						if req.URL.Path != "/ocr/batch/results" {
+							t.Errorf("path = %v, want /ocr/batch/results", req.URL.Path)
+						}
+						params = req.URL.Query()
+						return &http.Response{
+							StatusCode: tt.status,
+							Body:       io.NopCloser(bytes.NewReader([]byte(pages[params.Get("nextPageToken")]))),
+						}, nil
+					},
+				},
+			}
+
+			got, err := client.GetBatches(context.Background(), "2024-01-01", "2024-01-31", tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.GetBatches() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.GetBatches() = %v, want %v", got, tt.want)
+			}
+			if tt.wantParams != nil && !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("client.GetBatches() last params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
+
 func TestSendJobSingleStep(t *testing.T) {
 	type fields struct {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service       string
+		service       Service
 		file          string
 		facematchFile string
 		extraFile     string
@@ -1109,6 +1259,21 @@ func TestSendJobSingleStep(t *testing.T) {
 				StatusURL: "url/123",
 			},
 		},
+		{
+			name: "payload too large",
+			fields: fields{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						t.Errorf("client.SendJobSingleStep() requested the API with a payload too large")
+						return nil, errors.New("error")
+					},
+				},
+			},
+			args: args{
+				file: strings.Repeat("A", common.SINGLE_STEP_LIMIT),
+			},
+			wantErr: true,
+		},
 		{
 			name: "failed doing request",
 			fields: fields{
@@ -1153,13 +1318,12 @@ func TestSendJobSingleStep(t *testing.T) {
 }
 
 func TestSendJobBase64(t *testing.T) {
-	a := 0
-	b := 0
+	var a, b atomic.Int32
 	type fields struct {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service       string
+		service       Service
 		file          string
 		facematchFile string
 		extraFile     string
@@ -1254,8 +1418,7 @@ func TestSendJobBase64(t *testing.T) {
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						if req.Method == "PUT" {
-							a += 1
-							if a == 2 {
+							if a.Add(1) == 2 {
 								return nil, errors.New("error")
 							}
 						}
@@ -1281,8 +1444,7 @@ func TestSendJobBase64(t *testing.T) {
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						if req.Method == "PUT" {
-							b += 1
-							if b == 3 {
+							if b.Add(1) == 3 {
 								return nil, errors.New("error")
 							}
 						}
@@ -1329,15 +1491,14 @@ func TestSendJobBase64(t *testing.T) {
 }
 
 func TestSendJob(t *testing.T) {
-	a := 0
-	b := 0
+	var a, b atomic.Int32
 	f, _ := os.CreateTemp(".", "")
 	defer os.Remove(f.Name())
 	type fields struct {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service  string
+		service  Service
 		metadata map[string]any
 		params   map[string]string
 	}
@@ -1429,8 +1590,7 @@ func TestSendJob(t *testing.T) {
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						if req.Method == "PUT" {
-							a += 1
-							if a == 2 {
+							if a.Add(1) == 2 {
 								return nil, errors.New("error")
 							}
 						}
@@ -1456,8 +1616,7 @@ func TestSendJob(t *testing.T) {
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						if req.Method == "PUT" {
-							b += 1
-							if b == 3 {
+							if b.Add(1) == 3 {
 								return nil, errors.New("error")
 							}
 						}
@@ -1508,7 +1667,7 @@ func TestSendBatchBase64(t *testing.T) {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service  string
+		service  Service
 		file     string
 		metadata []map[string]any
 		params   map[string]string
@@ -1605,7 +1764,7 @@ func TestSendBatch(t *testing.T) {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service  string
+		service  Service
 		metadata []map[string]any
 		params   map[string]string
 	}
@@ -1713,23 +1872,25 @@ func TestWaitForJobDone(t *testing.T) {
 	}{
 		{
 			name: "success",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS", jobID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"123","status":"done"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done"}`))),
 						}, nil
 					},
 				},
 			},
 			want: JobResultResponse{
-				JobID:  "123",
+				JobID:  "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				Status: "done",
 			},
 		},
 		{
 			name: "invalid status code",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS", jobID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
@@ -1744,6 +1905,7 @@ func TestWaitForJobDone(t *testing.T) {
 		},
 		{
 			name: "timeout",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS", jobID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				Timeout:  1,
 				Interval: 1,
@@ -1751,7 +1913,7 @@ func TestWaitForJobDone(t *testing.T) {
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status":"processing"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"processing"}`))),
 						}, nil
 					},
 				},
@@ -1798,18 +1960,19 @@ func TestWaitForBatchDone(t *testing.T) {
 	}{
 		{
 			name: "success",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"123","status":"done"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done"}`))),
 						}, nil
 					},
 				},
 			},
 			want: BatchStatusResponse{
-				BatchID: "123",
+				BatchID: "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				Status:  "done",
 			},
 		},
@@ -1820,21 +1983,22 @@ func TestWaitForBatchDone(t *testing.T) {
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"123","status":"done","jobs":[{"job_ksuid":"1234","status":"done"}]}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done","jobs":[{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","status":"done"}]}`))),
 						}, nil
 					},
 				},
 			},
 			args: args{
+				batchID:  "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				waitJobs: true,
 			},
 			want: BatchStatusResponse{
-				BatchID: "123",
+				BatchID: "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				Status:  "done",
 				Jobs: []BatchStatusJobs{
 					{
 						Status: "done",
-						JobID:  "1234",
+						JobID:  "2AwrSd7bxEMbPrQ5jZHGDzQ4qL3",
 					},
 				},
 			},
@@ -1848,12 +2012,12 @@ func TestWaitForBatchDone(t *testing.T) {
 						if a == 1 {
 							return &http.Response{
 								StatusCode: 200,
-								Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"123","status":"done","jobs":[{"job_ksuid":"1234","status":"processing"}]}`))),
+								Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done","jobs":[{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","status":"processing"}]}`))),
 							}, nil
 						}
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"1234","status":"processing"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","status":"processing"}`))),
 						}, nil
 					},
 				},
@@ -1861,12 +2025,14 @@ func TestWaitForBatchDone(t *testing.T) {
 				Interval: 1,
 			},
 			args: args{
+				batchID:  "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				waitJobs: true,
 			},
 			wantErr: true,
 		},
 		{
 			name: "invalid status code",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				HttpClient: &ClientMock{
 					MockDo: func(req *http.Request) (*http.Response, error) {
@@ -1881,6 +2047,7 @@ func TestWaitForBatchDone(t *testing.T) {
 		},
 		{
 			name: "timeout",
+			args: args{batchID: "0ujsszwN8NRY24YaXiTIE2VWDTS"},
 			fields: fields{
 				Timeout:  1,
 				Interval: 1,
@@ -1888,7 +2055,7 @@ func TestWaitForBatchDone(t *testing.T) {
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status":"processing"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"processing"}`))),
 						}, nil
 					},
 				},
@@ -1920,7 +2087,7 @@ func TestCreateAndWaitJob(t *testing.T) {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service           string
+		service           Service
 		filePath          string
 		facematchFilePath string
 		extraFilePath     string
@@ -1943,7 +2110,7 @@ func TestCreateAndWaitJob(t *testing.T) {
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"123","status":"done"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"0ujsszwN8NRY24YaXiTIE2VWDTS","job_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done"}`))),
 						}, nil
 					},
 				},
@@ -1952,7 +2119,7 @@ func TestCreateAndWaitJob(t *testing.T) {
 				filePath: f.Name(),
 			},
 			want: JobResultResponse{
-				JobID:  "123",
+				JobID:  "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				Status: "done",
 			},
 		},
@@ -2014,7 +2181,7 @@ func TestCreateAndWaitBatch(t *testing.T) {
 		HttpClient HttpClient
 	}
 	type args struct {
-		service  string
+		service  Service
 		filePath string
 		metadata []map[string]any
 		params   map[string]string
@@ -2036,7 +2203,7 @@ func TestCreateAndWaitBatch(t *testing.T) {
 					MockDo: func(req *http.Request) (*http.Response, error) {
 						return &http.Response{
 							StatusCode: 200,
-							Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"123","status":"done"}`))),
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"0ujsszwN8NRY24YaXiTIE2VWDTS","batch_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done"}`))),
 						}, nil
 					},
 				},
@@ -2045,7 +2212,7 @@ func TestCreateAndWaitBatch(t *testing.T) {
 				filePath: f.Name(),
 			},
 			want: BatchStatusResponse{
-				BatchID: "123",
+				BatchID: "0ujsszwN8NRY24YaXiTIE2VWDTS",
 				Status:  "done",
 			},
 		},
@@ -2101,3 +2268,279 @@ func TestCreateAndWaitBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIError(t *testing.T) {
+	client := &Client{
+		BaseURL: "https://api",
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 404,
+					Header:     http.Header{common.HEADER_REQUEST_ID: []string{"req-1"}},
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"message":"not found"}`))),
+				}, nil
+			},
+		},
+	}
+
+	_, err := client.GetJobResult(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+	if !errors.Is(err, common.ErrInvalidStatusCode) {
+		t.Fatalf("client.GetJobResult() error = %v, want %v", err, common.ErrInvalidStatusCode)
+	}
+
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("client.GetJobResult() error = %T, want *common.APIError", err)
+	}
+
+	want := &common.APIError{
+		StatusCode: 404,
+		Body:       []byte(`{"message":"not found"}`),
+		RequestURL: "https://api/ocr/job/result/0ujsszwN8NRY24YaXiTIE2VWDTS/0ujsszwN8NRY24YaXiTIE2VWDTS",
+		RequestID:  "req-1",
+	}
+	if !reflect.DeepEqual(apiErr, want) {
+		t.Errorf("client.GetJobResult() error = %v, want %v", apiErr, want)
+	}
+}
+
+func TestAutoAuthenticateConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	authCalls := 0
+	client := NewClient()
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.String(), "token") {
+				mu.Lock()
+				authCalls += 1
+				mu.Unlock()
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"token":"123"}`))),
+				}, nil
+			}
+
+			if req.Header.Get("Authorization") != "Bearer 123" {
+				return nil, errors.New("missing token")
+			}
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       http.NoBody,
+			}, nil
+		},
+	})
+	client.SetAutoRefresh("id", "secret", 60)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.get(context.Background(), "url", nil)
+			if err != nil {
+				t.Errorf("client.get() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if authCalls != 1 {
+		t.Errorf("authentications = %v, want 1", authCalls)
+	}
+}
+
+func TestRefreshSkew(t *testing.T) {
+	tests := []struct {
+		name        string
+		skew        time.Duration
+		wantRefresh bool
+	}{
+		{
+			name: "token still valid",
+		},
+		{
+			name:        "token inside skew",
+			skew:        2 * time.Minute,
+			wantRefresh: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshed := false
+			client := &Client{
+				Token:       "old",
+				AutoRefresh: true,
+				ExpiresAt:   time.Now().Add(time.Minute),
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						refreshed = true
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(bytes.NewReader([]byte(`{"token":"new"}`))),
+						}, nil
+					},
+				},
+			}
+			client.SetRefreshSkew(tt.skew)
+
+			_, err := client.accessToken(context.Background())
+			if err != nil {
+				t.Fatalf("client.accessToken() error = %v", err)
+			}
+			if refreshed != tt.wantRefresh {
+				t.Errorf("refreshed = %v, want %v", refreshed, tt.wantRefresh)
+			}
+		})
+	}
+}
+
+func TestSoftFailExtra(t *testing.T) {
+	tests := []struct {
+		name         string
+		softFail     bool
+		wantErr      error
+		wantWarnings int
+	}{
+		{
+			name:    "abort",
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:         "soft fail",
+			softFail:     true,
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skipped []UploadSkippedEvent
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						switch req.URL.Path {
+						case "/extra":
+							return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+						case "/document":
+							return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body: io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123","urls":{` +
+								`"document":"https://bucket/document","extra_document":"https://bucket/extra"}}`))),
+						}, nil
+					},
+				},
+				Token:     "123",
+				ExpiresAt: time.Now().Add(time.Hour),
+				Hooks: Hooks{
+					OnUploadSkipped: func(event UploadSkippedEvent) {
+						skipped = append(skipped, event)
+					},
+				},
+			}
+			client.SetSoftFailExtra(tt.softFail)
+
+			params := map[string]string{common.KEY_EXTRA: common.FLAG_TRUE}
+			got, err := client.SendJobBase64(context.Background(), "rg", "ZG9j", "", "ZXh0cmE=", nil, params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.SendJobBase64() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got.Warnings) != tt.wantWarnings || len(skipped) != tt.wantWarnings {
+				t.Fatalf("client.SendJobBase64() warnings = %v, skipped = %v, want %v", got.Warnings, skipped, tt.wantWarnings)
+			}
+			if tt.softFail {
+				if got.Id != "123" || !errors.Is(got.Warnings[0], common.ErrInvalidStatusCode) {
+					t.Errorf("client.SendJobBase64() = %+v", got)
+				}
+				if skipped[0].JobID != "123" || skipped[0].Document != "extra_document" {
+					t.Errorf("OnUploadSkipped() event = %+v", skipped[0])
+				}
+			}
+		})
+	}
+}
+
+func TestGetJobResultRaw(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobID   string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{
+			name:   "success",
+			jobID:  "0ujsszwN8NRY24YaXiTIE2VWDTS",
+			status: 200,
+			body:   `{"job_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done","new_field":{"a":1}}`,
+		},
+		{
+			name:    "not found",
+			jobID:   "0ujsszwN8NRY24YaXiTIE2VWDTS",
+			status:  404,
+			body:    `{"message":"not found"}`,
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:    "invalid json",
+			jobID:   "0ujsszwN8NRY24YaXiTIE2VWDTS",
+			status:  200,
+			body:    `{"job_ksuid":`,
+			wantErr: common.ErrParsingResponse,
+		},
+		{
+			name:    "invalid ID",
+			jobID:   "123",
+			wantErr: common.ErrInvalidID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						return &http.Response{
+							StatusCode: tt.status,
+							Body:       io.NopCloser(strings.NewReader(tt.body)),
+						}, nil
+					},
+				},
+				Token:     "123",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+
+			got, err := client.GetJobResultRaw(context.Background(), tt.jobID, tt.jobID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.GetJobResultRaw() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.body {
+				t.Errorf("client.GetJobResultRaw() = %s, want %s", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestGetBatchResults(t *testing.T) {
+	client, failing, status := newFakeBatch(t)
+	client.SetJobsConcurrency(2)
+
+	got, err := client.GetBatchResults(context.Background(), status.BatchID)
+	if err != nil {
+		t.Fatalf("client.GetBatchResults() error = %v", err)
+	}
+	if len(got) != len(status.Jobs) {
+		t.Fatalf("client.GetBatchResults() = %v results, want %v", len(got), len(status.Jobs))
+	}
+	for i, job := range status.Jobs {
+		if got[i].JobID != job.JobID || got[i].Status != StatusDone {
+			t.Errorf("client.GetBatchResults()[%d] = %+v, want job %v done", i, got[i], job.JobID)
+		}
+	}
+
+	failing.jobID = status.Jobs[3].JobID
+	_, err = client.GetBatchResults(context.Background(), status.BatchID)
+	if !errors.Is(err, common.ErrInvalidStatusCode) {
+		t.Errorf("client.GetBatchResults() error = %v, want %v", err, common.ErrInvalidStatusCode)
+	}
+}
@@ -0,0 +1,104 @@
+package ultraocr
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetHealthPolicy Changes the waits to tolerate API server errors, suspending them while the API is degraded.
+func (client *Client) SetHealthPolicy(policy HealthPolicy) {
+	client.Health = &policy
+}
+
+// SetHooks Changes the functions called on Client events.
+func (client *Client) SetHooks(hooks Hooks) {
+	client.Hooks = hooks
+}
+
+// healthTracker Counts the consecutive server errors of a wait.
+type healthTracker struct {
+	clock    Clock
+	hooks    Hooks
+	policy   *HealthPolicy
+	event    DegradedEvent
+	failures int
+}
+
+func newHealthTracker(clock Clock, hooks Hooks, policy *HealthPolicy, resource, ID string) *healthTracker {
+	return &healthTracker{
+		clock:  clock,
+		hooks:  hooks,
+		policy: policy,
+		event:  DegradedEvent{Resource: resource, ID: ID},
+	}
+}
+
+func (h *healthTracker) threshold() int {
+	if h.policy.Threshold <= 0 {
+		return common.DEFAULT_HEALTH_THRESHOLD
+	}
+
+	return h.policy.Threshold
+}
+
+func (h *healthTracker) backoff() time.Duration {
+	if h.policy.Backoff <= 0 {
+		return common.DEFAULT_HEALTH_BACKOFF
+	}
+
+	return h.policy.Backoff
+}
+
+// failed Records a poll error. Returns the error if it must stop the wait, or how long to
+// suspend the wait if the API is degraded (zero to poll as usual).
+func (h *healthTracker) failed(err error) (time.Duration, error) {
+	if h.policy == nil || !serverError(err) {
+		return 0, err
+	}
+
+	h.failures += 1
+	if h.failures < h.threshold() {
+		return 0, nil
+	}
+
+	now := h.clock.Now()
+	if h.failures == h.threshold() {
+		h.event.Since = now
+		h.event.Failures = h.failures
+		h.event.Err = err
+		if h.hooks.OnDegraded != nil {
+			h.hooks.OnDegraded(h.event)
+		}
+	}
+
+	if h.policy.MaxSuspension > 0 && now.Sub(h.event.Since) >= h.policy.MaxSuspension {
+		return 0, err
+	}
+
+	return h.backoff(), nil
+}
+
+// succeeded Records a successful poll, notifying the recovery of a degraded API.
+func (h *healthTracker) succeeded() {
+	if h.policy == nil {
+		return
+	}
+
+	if h.failures >= h.threshold() && h.hooks.OnRecovered != nil {
+		event := h.event
+		event.Failures = h.failures
+		h.hooks.OnRecovered(event)
+	}
+
+	h.failures = 0
+}
+
+// serverError Checks if the error is an API server error (status code 5xx).
+func serverError(err error) bool {
+	var apiErr *common.APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
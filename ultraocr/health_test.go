@@ -0,0 +1,115 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+// statusSequenceClient Answers the job result with the given status codes, then with the job done.
+func statusSequenceClient(clock Clock, codes ...int) *Client {
+	calls := 0
+	return &Client{
+		Clock:   clock,
+		Timeout: 10,
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				calls += 1
+				if calls <= len(codes) {
+					return &http.Response{StatusCode: codes[calls-1], Body: http.NoBody}, nil
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"job_ksuid":"123","status":"done"}`))),
+				}, nil
+			},
+		},
+		Token:     "123",
+		ExpiresAt: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestHealthPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	degraded := make([]int, 20)
+	for i := range degraded {
+		degraded[i] = 503
+	}
+
+	tests := []struct {
+		name          string
+		policy        *HealthPolicy
+		codes         []int
+		wantErr       error
+		wantDegraded  int
+		wantRecovered int
+	}{
+		{
+			name:    "disabled",
+			codes:   []int{500},
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:   "transient errors",
+			policy: &HealthPolicy{},
+			codes:  []int{500, 502},
+		},
+		{
+			name:    "client errors",
+			policy:  &HealthPolicy{},
+			codes:   []int{404},
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:          "suspended beyond the timeout",
+			policy:        &HealthPolicy{Threshold: 2, Backoff: time.Minute},
+			codes:         degraded,
+			wantDegraded:  1,
+			wantRecovered: 1,
+		},
+		{
+			name:         "max suspension",
+			policy:       &HealthPolicy{Threshold: 2, Backoff: time.Minute, MaxSuspension: 5 * time.Minute},
+			codes:        degraded,
+			wantErr:      common.ErrInvalidStatusCode,
+			wantDegraded: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := statusSequenceClient(ultraocrtest.NewAutoClock(start), tt.codes...)
+			client.Health = tt.policy
+
+			events, recovered := 0, 0
+			client.SetHooks(Hooks{
+				OnDegraded: func(event DegradedEvent) {
+					events += 1
+					if event.Resource != common.RESOURCE_JOB || event.ID != "0ujsszwN8NRY24YaXiTIE2VWDTS" || event.Err == nil {
+						t.Errorf("OnDegraded() event = %+v", event)
+					}
+				},
+				OnRecovered: func(event DegradedEvent) {
+					recovered += 1
+				},
+			})
+
+			_, err := client.WaitForJobDone(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("client.WaitForJobDone() error = %v, want %v", err, tt.wantErr)
+			}
+			if events != tt.wantDegraded {
+				t.Errorf("OnDegraded() calls = %v, want %v", events, tt.wantDegraded)
+			}
+			if recovered != tt.wantRecovered {
+				t.Errorf("OnRecovered() calls = %v, want %v", recovered, tt.wantRecovered)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package ultraocr
+
+import (
+	"net/http"
+	neturl "net/url"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetMetrics Changes the Metrics receiving the requests, retries and waits measurements (Default none).
+func (client *Client) SetMetrics(metrics Metrics) {
+	client.Metrics = metrics
+}
+
+// send Does the request with its X-Request-Id, calling the OnRequest and OnResponse hooks and the Metrics around it.
+// Bodies are only captured with captureBodies, see sendDebug.
+func (client *Client) send(req *http.Request, captureBodies bool) (*http.Response, error) {
+	setRequestID(req)
+
+	hooks := client.Hooks
+	if hooks.OnRequest == nil && hooks.OnResponse == nil && client.Metrics == nil {
+		return client.sendDebug(req, captureBodies)
+	}
+
+	url := req.URL.String()
+	if !captureBodies {
+		url = stripQuery(url)
+	}
+
+	start := client.clock().Now()
+	if hooks.OnRequest != nil {
+		hooks.OnRequest(RequestEvent{Method: req.Method, URL: url, RequestID: req.Header.Get(common.HEADER_REQUEST_ID), Time: start})
+	}
+
+	res, err := client.sendDebug(req, captureBodies)
+
+	event := ResponseEvent{
+		Method:  req.Method,
+		URL:     url,
+		Latency: client.clock().Now().Sub(start),
+		Err:     err,
+	}
+	if res != nil {
+		event.StatusCode = res.StatusCode
+		event.RequestID = responseRequestID(res)
+	}
+
+	if hooks.OnResponse != nil {
+		hooks.OnResponse(event)
+	}
+
+	if client.Metrics != nil {
+		client.Metrics.ObserveRequest(client.endpoint(req), event.StatusCode, event.Latency, err)
+	}
+
+	return res, err
+}
+
+// endpoint Returns the request route with the IDs replaced by "{id}", or "upload" for requests
+// outside the API, like the signed URLs.
+func (client *Client) endpoint(req *http.Request) string {
+	for _, base := range []string{client.BaseURL, client.AuthBaseURL} {
+		u, err := neturl.Parse(base)
+		if err != nil || u.Host != req.URL.Host || !strings.HasPrefix(req.URL.Path, u.Path) {
+			continue
+		}
+
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, u.Path), "/"), "/")
+		for i, part := range parts {
+			if ValidateID(part) == nil {
+				parts[i] = "{id}"
+			}
+		}
+
+		return req.Method + " /" + strings.Join(parts, "/")
+	}
+
+	return common.PHASE_UPLOAD
+}
+
+// retrying Calls the OnRetry hook and counts the retry on the Metrics, if set.
+func retrying(hooks Hooks, metrics Metrics, event RetryEvent) {
+	if hooks.OnRetry != nil {
+		hooks.OnRetry(event)
+	}
+
+	if metrics != nil {
+		metrics.IncRetry(event.Phase)
+	}
+}
+
+// authRefreshed Calls the OnAuthRefresh hook, if set.
+func (hooks Hooks) authRefreshed(event AuthRefreshEvent) {
+	if hooks.OnAuthRefresh != nil {
+		hooks.OnAuthRefresh(event)
+	}
+}
@@ -0,0 +1,110 @@
+package ultraocr
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+// hookRecorder Records the lifecycle hooks events.
+type hookRecorder struct {
+	mu        sync.Mutex
+	requests  []RequestEvent
+	responses []ResponseEvent
+	retries   []RetryEvent
+	refreshes []AuthRefreshEvent
+}
+
+func (r *hookRecorder) hooks() Hooks {
+	return Hooks{
+		OnRequest: func(event RequestEvent) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.requests = append(r.requests, event)
+		},
+		OnResponse: func(event ResponseEvent) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.responses = append(r.responses, event)
+		},
+		OnRetry: func(event RetryEvent) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.retries = append(r.retries, event)
+		},
+		OnAuthRefresh: func(event AuthRefreshEvent) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.refreshes = append(r.refreshes, event)
+		},
+	}
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ultraocrtest.NewAutoClock(start)
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+	client.SetErrorBudget(1)
+	client.SetUploadRetry(UploadRetry{Backoff: time.Second})
+
+	var recorder hookRecorder
+	client.SetHooks(recorder.hooks())
+
+	var failed sync.Map
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			// The first upload and result requests fail with transient errors.
+			first := req.Method == http.MethodPut || strings.Contains(req.URL.Path, "/result/")
+			if _, seen := failed.LoadOrStore(req.Method+req.URL.Path, true); first && !seen {
+				return &http.Response{StatusCode: 503, Header: http.Header{}, Body: http.NoBody}, nil
+			}
+			return api.Do(req)
+		},
+	})
+
+	created, err := client.SubmitJob(context.Background(), JobRequest{Service: ServiceRG, Document: StringSource("doc", "doc")})
+	if err != nil {
+		t.Fatalf("client.SubmitJob() error = %v", err)
+	}
+	_, err = client.WaitForJob(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+
+	// token, signed url, two uploads and two results
+	if len(recorder.requests) != 6 || len(recorder.responses) != 6 {
+		t.Fatalf("hooks got %d requests and %d responses, want 6", len(recorder.requests), len(recorder.responses))
+	}
+	for i, event := range recorder.responses {
+		if event.URL != recorder.requests[i].URL || event.Method != recorder.requests[i].Method {
+			t.Errorf("response %d = %+v, want of request %+v", i, event, recorder.requests[i])
+		}
+		if strings.Contains(event.URL, "?") && event.Method == http.MethodPut {
+			t.Errorf("upload URL %q kept its query string", event.URL)
+		}
+	}
+	if recorder.responses[2].StatusCode != 503 || recorder.responses[3].StatusCode != 200 {
+		t.Errorf("upload responses = %+v, want 503 and 200", recorder.responses[2:4])
+	}
+
+	if len(recorder.retries) != 2 {
+		t.Fatalf("hooks got retries %+v, want 2", recorder.retries)
+	}
+	if retry := recorder.retries[0]; retry.Phase != common.PHASE_UPLOAD || retry.Delay != time.Second || retry.Err == nil {
+		t.Errorf("upload retry = %+v", retry)
+	}
+	if retry := recorder.retries[1]; retry.Phase != common.PHASE_WAIT || retry.Target != created.Id || retry.Err == nil {
+		t.Errorf("wait retry = %+v", retry)
+	}
+
+	if len(recorder.refreshes) != 1 || recorder.refreshes[0].Err != nil || !recorder.refreshes[0].ExpiresAt.After(start) {
+		t.Errorf("hooks got refreshes %+v, want one", recorder.refreshes)
+	}
+}
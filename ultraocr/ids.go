@@ -0,0 +1,35 @@
+package ultraocr
+
+import (
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// ValidateID Checks if the ID has the shape of the job and batch IDs (KSUID, 27 base62 characters).
+// Returns ErrInvalidID otherwise, e.g. when a file name is passed as ID.
+func ValidateID(ID string) error {
+	if len(ID) != common.KSUID_LENGTH {
+		return fmt.Errorf("%w: %q must have %d characters", common.ErrInvalidID, ID, common.KSUID_LENGTH)
+	}
+
+	for _, c := range ID {
+		if !('0' <= c && c <= '9' || 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z') {
+			return fmt.Errorf("%w: %q must have only letters and digits", common.ErrInvalidID, ID)
+		}
+	}
+
+	return nil
+}
+
+// validateIDs Validates many IDs, returning the first error.
+func validateIDs(IDs ...string) error {
+	for _, ID := range IDs {
+		err := ValidateID(ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestValidateID(t *testing.T) {
+	tests := []struct {
+		name    string
+		ID      string
+		wantErr bool
+	}{
+		{
+			name: "ksuid",
+			ID:   "2AwrSd7bxEMbPrQ5jZHGDzQ4qL3",
+		},
+		{
+			name:    "empty",
+			wantErr: true,
+		},
+		{
+			name:    "short",
+			ID:      "2AwrSd7bxEMbPrQ5jZHGDzQ4qL",
+			wantErr: true,
+		},
+		{
+			name:    "file name",
+			ID:      "documents/2AwrSd7bxEMb.pdf",
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			ID:      "2AwrSd7bxEMbPrQ5jZHGDzQ4qL-",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateID(tt.ID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, common.ErrInvalidID) {
+				t.Errorf("ValidateID() error = %v, want %v", err, common.ErrInvalidID)
+			}
+		})
+	}
+}
+
+func TestInvalidIDNotRequested(t *testing.T) {
+	api := ultraocrtest.NewFakeAPI(nil)
+	client := newFakeClient(nil, api)
+	jobID := api.AddJob("rg", common.STATUS_DONE)
+
+	calls := map[string]func() error{
+		"GetJobResult": func() error {
+			_, err := client.GetJobResult(context.Background(), jobID, "doc.pdf")
+			return err
+		},
+		"GetBatchStatus": func() error {
+			_, err := client.GetBatchStatus(context.Background(), "doc.pdf")
+			return err
+		},
+		"GetJobResultFields": func() error {
+			_, err := client.GetJobResultFields(context.Background(), "doc.pdf", jobID, "Nome")
+			return err
+		},
+		"WaitForJob": func() error {
+			_, err := client.WaitForJob(context.Background(), "doc.pdf")
+			return err
+		},
+		"WaitForBatchDone": func() error {
+			_, err := client.WaitForBatchDone(context.Background(), "doc.pdf", true)
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			before := api.RequestCount()
+			err := call()
+			if !errors.Is(err, common.ErrInvalidID) {
+				t.Errorf("client.%s() error = %v, want %v", name, err, common.ErrInvalidID)
+			}
+			if api.RequestCount() != before {
+				t.Errorf("client.%s() requests = %v, want none", name, api.RequestCount()-before)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package ultraocr
+
+import (
+	"context"
+	"iter"
+)
+
+// JobsIterator Iterates the jobs in a time interval, getting a page only when the previous one
+// was iterated, so the jobs don't need to fit in memory.
+type JobsIterator struct {
+	client    *Client
+	ctx       context.Context
+	start     string
+	end       string
+	filter    JobsFilter
+	page      []JobResultResponse
+	index     int
+	pageToken string
+	lastPage  bool
+	job       JobResultResponse
+	err       error
+}
+
+// JobsIterator Creates an iterator of the jobs in a time interval, like GetJobs.
+// Requires the start and end time in 2006-01-02 format.
+func (client *Client) JobsIterator(ctx context.Context, start, end string) *JobsIterator {
+	return &JobsIterator{
+		client: client,
+		ctx:    ctx,
+		start:  start,
+		end:    end,
+	}
+}
+
+// Jobs Returns a range over function of the jobs in a time interval, see JobsIterator.
+// A failure is yielded as the last pair.
+func (client *Client) Jobs(ctx context.Context, start, end string) iter.Seq2[JobResultResponse, error] {
+	return client.JobsIterator(ctx, start, end).All()
+}
+
+// Next Advances to the next job, getting the next page if needed.
+// Returns false after the last job or a failure, see Err.
+func (it *JobsIterator) Next() bool {
+	for it.index >= len(it.page) {
+		if it.err != nil || it.lastPage {
+			return false
+		}
+
+		res, err := it.client.getJobsPage(it.ctx, it.start, it.end, it.pageToken, it.filter)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = res.Jobs
+		it.index = 0
+		it.pageToken = res.NextPageToken
+		it.lastPage = res.NextPageToken == ""
+	}
+
+	it.job = it.page[it.index]
+	it.index += 1
+	return true
+}
+
+// Job Returns the current job.
+func (it *JobsIterator) Job() JobResultResponse {
+	return it.job
+}
+
+// Err Returns the failure that stopped the iteration, if any.
+func (it *JobsIterator) Err() error {
+	return it.err
+}
+
+// All Returns a range over function of the remaining jobs. A failure is yielded as the last pair.
+func (it *JobsIterator) All() iter.Seq2[JobResultResponse, error] {
+	return func(yield func(JobResultResponse, error) bool) {
+		for it.Next() {
+			if !yield(it.Job(), nil) {
+				return
+			}
+		}
+
+		if it.err != nil {
+			yield(JobResultResponse{}, it.err)
+		}
+	}
+}
+
+// GetJobsStream Gets the jobs in a time interval like GetJobs, sending them on the returned channel
+// as the pages arrive. The next page is got while the current one is received, so the jobs can be
+// processed while later pages are still downloading. The jobs channel is closed at the end, then the
+// error channel receives the failure, if any, and is closed. Cancel the context to stop early.
+func (client *Client) GetJobsStream(ctx context.Context, start, end string) (<-chan JobResultResponse, <-chan error) {
+	jobs := make(chan JobResultResponse)
+	errc := make(chan error, 1)
+	pages := make(chan []JobResultResponse, 1)
+	fetchErr := make(chan error, 1)
+
+	go func() {
+		defer close(pages)
+
+		pageToken := ""
+		for {
+			res, err := client.getJobsPage(ctx, start, end, pageToken, JobsFilter{})
+			if err != nil {
+				fetchErr <- err
+				return
+			}
+
+			select {
+			case pages <- res.Jobs:
+			case <-ctx.Done():
+				fetchErr <- ctx.Err()
+				return
+			}
+
+			if res.NextPageToken == "" {
+				return
+			}
+			pageToken = res.NextPageToken
+		}
+	}()
+
+	go func() {
+		defer close(errc)
+		defer close(jobs)
+
+		for page := range pages {
+			for _, job := range page {
+				select {
+				case jobs <- job:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+		}
+
+		select {
+		case err := <-fetchErr:
+			errc <- err
+		default:
+		}
+	}()
+
+	return jobs, errc
+}
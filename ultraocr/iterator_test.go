@@ -0,0 +1,159 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestJobsIterator(t *testing.T) {
+	requests := []string{}
+	client := pagedJobsClient(&requests)
+
+	it := client.JobsIterator(context.Background(), "2024-01-01", "2024-01-31")
+	if !it.Next() || it.Job().JobID != "1" {
+		t.Fatalf("JobsIterator.Next() job = %v, want 1", it.Job().JobID)
+	}
+	if want := []string{""}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests after first job = %v, want %v", requests, want)
+	}
+
+	jobs := []string{"1"}
+	for it.Next() {
+		jobs = append(jobs, it.Job().JobID)
+	}
+	if it.Err() != nil {
+		t.Fatalf("JobsIterator.Err() = %v", it.Err())
+	}
+	if want := []string{"1", "2", "3", "4"}; !reflect.DeepEqual(jobs, want) {
+		t.Errorf("jobs = %v, want %v", jobs, want)
+	}
+	if want := []string{"", "p2", "p3"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if it.Next() {
+		t.Errorf("JobsIterator.Next() after last job = true")
+	}
+}
+
+func TestJobs(t *testing.T) {
+	tests := []struct {
+		name     string
+		failAt   string
+		stopAt   string
+		wantJobs []string
+		wantErr  error
+		wantReqs int
+	}{
+		{
+			name:     "all",
+			wantJobs: []string{"1", "2", "3", "4"},
+			wantReqs: 3,
+		},
+		{
+			name:     "break",
+			stopAt:   "2",
+			wantJobs: []string{"1", "2"},
+			wantReqs: 1,
+		},
+		{
+			name:     "error",
+			failAt:   "p3",
+			wantJobs: []string{"1", "2", "3"},
+			wantErr:  common.ErrInvalidStatusCode,
+			wantReqs: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := []string{}
+			client := pagedJobsClient(&requests)
+			pages := client.HttpClient.(*ClientMock).MockDo
+			client.HttpClient = &ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					if tt.failAt != "" && req.URL.Query().Get("nextPageToken") == tt.failAt {
+						requests = append(requests, tt.failAt)
+						return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+					}
+					return pages(req)
+				},
+			}
+
+			jobs := []string{}
+			var err error
+			for job, jobErr := range client.Jobs(context.Background(), "2024-01-01", "2024-01-31") {
+				if jobErr != nil {
+					err = jobErr
+					break
+				}
+				jobs = append(jobs, job.JobID)
+				if job.JobID == tt.stopAt {
+					break
+				}
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("client.Jobs() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(jobs, tt.wantJobs) {
+				t.Errorf("client.Jobs() jobs = %v, want %v", jobs, tt.wantJobs)
+			}
+			if len(requests) != tt.wantReqs {
+				t.Errorf("requests = %v, want %v", len(requests), tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestGetJobsStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		failAt   string
+		wantJobs []string
+		wantErr  error
+	}{
+		{
+			name:     "all",
+			wantJobs: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:     "error",
+			failAt:   "p3",
+			wantJobs: []string{"1", "2", "3"},
+			wantErr:  common.ErrInvalidStatusCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := []string{}
+			client := pagedJobsClient(&requests)
+			pages := client.HttpClient.(*ClientMock).MockDo
+			client.HttpClient = &ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					if tt.failAt != "" && req.URL.Query().Get("nextPageToken") == tt.failAt {
+						return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+					}
+					return pages(req)
+				},
+			}
+
+			stream, errc := client.GetJobsStream(context.Background(), "2024-01-01", "2024-01-31")
+			jobs := []string{}
+			for job := range stream {
+				jobs = append(jobs, job.JobID)
+			}
+			err := <-errc
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("client.GetJobsStream() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(jobs, tt.wantJobs) {
+				t.Errorf("client.GetJobsStream() jobs = %v, want %v", jobs, tt.wantJobs)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package ultraocr
+
+import "context"
+
+type limiter struct {
+	submissions chan struct{}
+	polls       chan struct{}
+	uploads     chan struct{}
+}
+
+// SetConcurrencyLimits Changes the maximum in flight requests for submissions (signed URL and
+// single step jobs), status polls (job results, batch status and job listing) and uploads.
+// Requests over the limit wait for a free slot or the context cancellation.
+func (client *Client) SetConcurrencyLimits(limits ConcurrencyLimits) {
+	client.limiter = &limiter{
+		submissions: newSemaphore(limits.Submissions),
+		polls:       newSemaphore(limits.Polls),
+		uploads:     newSemaphore(limits.Uploads),
+	}
+}
+
+func newSemaphore(size int) chan struct{} {
+	if size <= 0 {
+		return nil
+	}
+
+	return make(chan struct{}, size)
+}
+
+func (client *Client) acquireSubmission(ctx context.Context) (func(), error) {
+	if client.limiter == nil {
+		return func() {}, nil
+	}
+
+	return acquire(ctx, client.limiter.submissions)
+}
+
+func (client *Client) acquirePoll(ctx context.Context) (func(), error) {
+	if client.limiter == nil {
+		return func() {}, nil
+	}
+
+	return acquire(ctx, client.limiter.polls)
+}
+
+func (client *Client) acquireUpload(ctx context.Context) (func(), error) {
+	if client.limiter == nil {
+		return func() {}, nil
+	}
+
+	return acquire(ctx, client.limiter.uploads)
+}
+
+func acquire(ctx context.Context, semaphore chan struct{}) (func(), error) {
+	if semaphore == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,112 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits ConcurrencyLimits
+		call   func(client *Client) error
+		want   int
+	}{
+		{
+			name:   "uploads",
+			limits: ConcurrencyLimits{Uploads: 2},
+			call: func(client *Client) error {
+				return client.UploadFileBase64(context.Background(), "url", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+			},
+			want: 2,
+		},
+		{
+			name:   "polls",
+			limits: ConcurrencyLimits{Polls: 3},
+			call: func(client *Client) error {
+				_, err := client.GetBatchStatus(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS")
+				return err
+			},
+			want: 3,
+		},
+		{
+			name:   "submissions",
+			limits: ConcurrencyLimits{Submissions: 1},
+			call: func(client *Client) error {
+				_, err := client.GenerateSignedUrl(context.Background(), "rg", "job", nil, nil)
+				return err
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						mu.Lock()
+						inFlight += 1
+						maxInFlight = max(maxInFlight, inFlight)
+						mu.Unlock()
+
+						time.Sleep(10 * time.Millisecond)
+
+						mu.Lock()
+						inFlight -= 1
+						mu.Unlock()
+						return nil, errors.New("error")
+					},
+				},
+			}
+			client.SetConcurrencyLimits(tt.limits)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_ = tt.call(client)
+				}()
+			}
+			wg.Wait()
+
+			if maxInFlight != tt.want {
+				t.Errorf("max in flight = %v, want %v", maxInFlight, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimitsContextCanceled(t *testing.T) {
+	block := make(chan struct{})
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				<-block
+				return nil, errors.New("error")
+			},
+		},
+	}
+	client.SetConcurrencyLimits(ConcurrencyLimits{Uploads: 1})
+
+	go func() {
+		_ = client.UploadFileBase64(context.Background(), "url", "123")
+	}()
+	for len(client.limiter.uploads) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.UploadFileBase64(ctx, "url", "123")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("client.UploadFileBase64() error = %v, want %v", err, context.Canceled)
+	}
+	close(block)
+}
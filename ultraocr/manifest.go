@@ -0,0 +1,187 @@
+package ultraocr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// NewManifest Creates a batch manifest, hashing the given files.
+func NewManifest(
+	service Service,
+	filePaths []string,
+	metadata []map[string]any,
+	params map[string]string,
+	createdAt time.Time,
+) (Manifest, error) {
+	manifest := Manifest{
+		Version:   common.MANIFEST_VERSION,
+		Service:   service,
+		CreatedAt: createdAt,
+		Files:     []ManifestFile{},
+		Metadata:  metadata,
+		Params:    maps.Clone(params),
+	}
+
+	for _, path := range filePaths {
+		file, err := hashFile(path)
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	return manifest, nil
+}
+
+// WriteManifest Writes a manifest as indented JSON.
+func WriteManifest(w io.Writer, manifest Manifest) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(manifest)
+}
+
+// ReadManifest Reads a manifest written by WriteManifest.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	var manifest Manifest
+	err := json.NewDecoder(r).Decode(&manifest)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("%w: %w", common.ErrInvalidManifest, err)
+	}
+
+	if manifest.Version != common.MANIFEST_VERSION {
+		return Manifest{}, fmt.Errorf("%w: unsupported version %d", common.ErrInvalidManifest, manifest.Version)
+	}
+
+	return manifest, nil
+}
+
+// SaveManifest Writes a manifest to a file.
+func SaveManifest(path string, manifest Manifest) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = WriteManifest(f, manifest)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// LoadManifest Reads a manifest from a file.
+func LoadManifest(path string) (Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Manifest{}, common.ErrReadFile
+	}
+
+	defer f.Close()
+
+	return ReadManifest(f)
+}
+
+// Verify Checks that the manifest files still exist with the same size and hash.
+func (manifest Manifest) Verify() error {
+	for _, want := range manifest.Files {
+		got, err := hashFile(want.Path)
+		if err != nil {
+			return err
+		}
+
+		if got != want {
+			return fmt.Errorf("%w: %s", common.ErrManifestMismatch, want.Path)
+		}
+	}
+
+	return nil
+}
+
+// SetJobs Saves the jobs of a batch status on the manifest.
+func (manifest *Manifest) SetJobs(status BatchStatusResponse) {
+	manifest.Jobs = []ManifestJob{}
+	for _, job := range status.Jobs {
+		manifest.Jobs = append(manifest.Jobs, ManifestJob{
+			JobID:  job.JobID,
+			Status: job.Status,
+			Error:  job.Error,
+		})
+	}
+}
+
+// SendBatchWithManifest Sends a batch, like SendBatch, returning its manifest.
+// Use Manifest.SetJobs after the batch is done to record the produced jobs.
+func (client *Client) SendBatchWithManifest(ctx context.Context,
+	service Service,
+	filePath string,
+	metadata []map[string]any,
+	params map[string]string,
+) (Manifest, error) {
+	manifest, err := NewManifest(service, []string{filePath}, metadata, params, client.clock().Now())
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	response, err := client.SendBatch(ctx, service, filePath, metadata, params)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest.BatchID = response.Id
+	manifest.StatusURL = response.StatusURL
+
+	return manifest, nil
+}
+
+// ResubmitManifest Sends again the batch described by a manifest, after verifying its files.
+// The recorded idempotency key is dropped, so the API creates a new batch instead of returning
+// the original one. Returns the manifest of the new batch.
+func (client *Client) ResubmitManifest(ctx context.Context, manifest Manifest) (Manifest, error) {
+	if len(manifest.Files) != 1 {
+		return Manifest{}, fmt.Errorf("%w: expected one file, found %d", common.ErrInvalidManifest, len(manifest.Files))
+	}
+
+	err := manifest.Verify()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	params := maps.Clone(manifest.Params)
+	delete(params, common.KEY_IDEMPOTENCY_KEY)
+
+	return client.SendBatchWithManifest(ctx, manifest.Service, manifest.Files[0].Path, manifest.Metadata, params)
+}
+
+func hashFile(path string) (ManifestFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ManifestFile{}, common.ErrReadFile
+	}
+
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return ManifestFile{}, common.ErrReadFile
+	}
+
+	return ManifestFile{
+		Path:   path,
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
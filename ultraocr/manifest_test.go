@@ -0,0 +1,135 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.pdf")
+	err := os.WriteFile(path, []byte("batch"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.JobsPerBatch = 2
+	client := newFakeClient(clock, api)
+
+	var keys []string
+	client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/ocr/batch/") {
+			keys = append(keys, req.URL.Query().Get(common.KEY_IDEMPOTENCY_KEY))
+		}
+		return api.Do(req)
+	}})
+
+	metadata := []map[string]any{{"id": "1"}}
+	params := map[string]string{common.KEY_IDEMPOTENCY_KEY: "batch-1"}
+	manifest, err := client.SendBatchWithManifest(context.Background(), "rg", path, metadata, params)
+	if err != nil {
+		t.Fatalf("client.SendBatchWithManifest() error = %v", err)
+	}
+
+	sum := sha256.Sum256([]byte("batch"))
+	wantFiles := []ManifestFile{
+		{
+			Path:   path,
+			Size:   5,
+			SHA256: hex.EncodeToString(sum[:]),
+		},
+	}
+	if manifest.BatchID == "" || !reflect.DeepEqual(manifest.Files, wantFiles) {
+		t.Errorf("client.SendBatchWithManifest() = %v, want files %v", manifest, wantFiles)
+	}
+
+	status, err := client.WaitForBatchDone(context.Background(), manifest.BatchID, false)
+	if err != nil {
+		t.Fatalf("client.WaitForBatchDone() error = %v", err)
+	}
+	manifest.SetJobs(status)
+	if len(manifest.Jobs) != 2 {
+		t.Errorf("manifest jobs = %v, want 2", len(manifest.Jobs))
+	}
+
+	var buf bytes.Buffer
+	err = WriteManifest(&buf, manifest)
+	if err != nil {
+		t.Fatalf("WriteManifest() error = %v", err)
+	}
+
+	got, err := ReadManifest(&buf)
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, manifest) {
+		t.Errorf("ReadManifest() = %v, want %v", got, manifest)
+	}
+
+	resubmitted, err := client.ResubmitManifest(context.Background(), got)
+	if err != nil {
+		t.Fatalf("client.ResubmitManifest() error = %v", err)
+	}
+	if resubmitted.BatchID == manifest.BatchID {
+		t.Errorf("client.ResubmitManifest() reused batch %v", resubmitted.BatchID)
+	}
+	if len(keys) != 2 || keys[0] != "batch-1" || keys[1] != "" {
+		t.Errorf("client.ResubmitManifest() idempotency keys = %q, want the original one only on the first batch", keys)
+	}
+	if got.Params[common.KEY_IDEMPOTENCY_KEY] != "batch-1" {
+		t.Errorf("client.ResubmitManifest() changed the manifest params %v", got.Params)
+	}
+
+	err = os.WriteFile(path, []byte("changed"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = got.Verify(); !errors.Is(err, common.ErrManifestMismatch) {
+		t.Errorf("Manifest.Verify() error = %v, want %v", err, common.ErrManifestMismatch)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			data: `{"version":1,"service":"rg","files":[]}`,
+		},
+		{
+			name:    "unsupported version",
+			data:    `{"version":2,"service":"rg","files":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			data:    `{`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadManifest(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
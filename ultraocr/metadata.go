@@ -0,0 +1,137 @@
+package ultraocr
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// SetMetadataSerializer Changes the serializer used to convert custom metadata values before sending them.
+// Values not handled by the serializer are converted with their json.Marshaler or encoding.TextMarshaler
+// implementations, and unsupported values (like channels and functions) fail with ErrInvalidMetadata.
+func (client *Client) SetMetadataSerializer(serializer MetadataSerializer) {
+	client.Serializer = serializer
+}
+
+// serializeMetadata Converts the metadata to JSON compatible values, reporting the path of invalid values.
+func (client *Client) serializeMetadata(metadata any) (any, error) {
+	if isNil(metadata) {
+		return metadata, nil
+	}
+
+	return client.serializeValue("metadata", reflect.ValueOf(metadata))
+}
+
+func (client *Client) serializeValue(path string, value reflect.Value) (any, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	if value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		if !value.Type().Implements(jsonMarshalerType) && !value.Type().Implements(textMarshalerType) {
+			return client.serializeValue(path, value.Elem())
+		}
+	}
+
+	if client.Serializer != nil && value.CanInterface() {
+		serialized, ok, err := client.Serializer(value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", common.ErrInvalidMetadata, path, err)
+		}
+
+		if ok {
+			return serialized, nil
+		}
+	}
+
+	if value.Type().Implements(jsonMarshalerType) && value.CanInterface() {
+		data, err := value.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil || !json.Valid(data) {
+			return nil, fmt.Errorf("%w: %s: failed to marshal %s", common.ErrInvalidMetadata, path, value.Type())
+		}
+
+		return json.RawMessage(data), nil
+	}
+
+	if value.Type().Implements(textMarshalerType) && value.CanInterface() {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: failed to marshal %s", common.ErrInvalidMetadata, path, value.Type())
+		}
+
+		return string(text), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Interface(), nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(value.Float()) || math.IsInf(value.Float(), 0) {
+			return nil, fmt.Errorf("%w: %s: unsupported value %v", common.ErrInvalidMetadata, path, value.Float())
+		}
+
+		return value.Interface(), nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s: unsupported map key type %s", common.ErrInvalidMetadata, path, value.Type().Key())
+		}
+
+		serialized := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			item, err := client.serializeValue(path+"."+key, iter.Value())
+			if err != nil {
+				return nil, err
+			}
+
+			serialized[key] = item
+		}
+
+		return serialized, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Interface(), nil
+		}
+
+		serialized := make([]any, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := client.serializeValue(fmt.Sprintf("%s[%d]", path, i), value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			serialized[i] = item
+		}
+
+		return serialized, nil
+	case reflect.Struct:
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", common.ErrInvalidMetadata, path, err)
+		}
+
+		return json.RawMessage(data), nil
+	default:
+		return nil, fmt.Errorf("%w: %s: unsupported type %s", common.ErrInvalidMetadata, path, value.Type())
+	}
+}
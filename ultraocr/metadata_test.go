@@ -0,0 +1,144 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+type metadataID int
+
+func (id metadataID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]int{"id": int(id)})
+}
+
+type metadataCode string
+
+func (c metadataCode) MarshalText() ([]byte, error) {
+	return []byte("code-" + string(c)), nil
+}
+
+func TestSerializeMetadata(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	value := 10
+
+	tests := []struct {
+		name       string
+		serializer MetadataSerializer
+		metadata   any
+		want       any
+		wantErr    bool
+	}{
+		{
+			name:     "nil metadata",
+			metadata: nil,
+			want:     nil,
+		},
+		{
+			name:     "basic values",
+			metadata: map[string]any{"a": "1", "b": 2, "c": true, "d": &value},
+			want:     map[string]any{"a": "1", "b": 2, "c": true, "d": 10},
+		},
+		{
+			name:     "json marshaler",
+			metadata: map[string]any{"id": metadataID(1)},
+			want:     map[string]any{"id": json.RawMessage(`{"id":1}`)},
+		},
+		{
+			name:     "text marshaler",
+			metadata: map[string]any{"code": metadataCode("x")},
+			want:     map[string]any{"code": "code-x"},
+		},
+		{
+			name:     "time value",
+			metadata: []any{date},
+			want:     []any{json.RawMessage(`"2024-01-02T03:04:05Z"`)},
+		},
+		{
+			name: "custom serializer",
+			serializer: func(value any) (any, bool, error) {
+				if d, ok := value.(time.Time); ok {
+					return d.Format(time.DateOnly), true, nil
+				}
+				return nil, false, nil
+			},
+			metadata: []map[string]any{{"date": date}},
+			want:     []any{map[string]any{"date": "2024-01-02"}},
+		},
+		{
+			name: "custom serializer failure",
+			serializer: func(value any) (any, bool, error) {
+				return nil, false, errors.New("error")
+			},
+			metadata: map[string]any{"a": "1"},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported channel",
+			metadata: map[string]any{"a": []any{make(chan int)}},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported function",
+			metadata: map[string]any{"a": func() {}},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported float",
+			metadata: map[string]any{"a": math.NaN()},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported map key",
+			metadata: map[int]string{1: "a"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{}
+			client.SetMetadataSerializer(tt.serializer)
+			got, err := client.serializeMetadata(tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.serializeMetadata() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, common.ErrInvalidMetadata) {
+				t.Errorf("client.serializeMetadata() error = %v, want %v", err, common.ErrInvalidMetadata)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.serializeMetadata() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSignedUrlInvalidMetadata(t *testing.T) {
+	requests := 0
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				requests += 1
+				return nil, errors.New("unexpected request")
+			},
+		},
+		Token:     "123",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	metadata := map[string]any{"callback": func() {}}
+	_, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_JOB, metadata, nil)
+	if !errors.Is(err, common.ErrInvalidMetadata) {
+		t.Errorf("client.GenerateSignedUrl() error = %v, want %v", err, common.ErrInvalidMetadata)
+	}
+	if requests != 0 {
+		t.Errorf("client.GenerateSignedUrl() requests = %v, want 0", requests)
+	}
+}
@@ -0,0 +1,164 @@
+package ultraocr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetMetadataSchema Validates the metadata of the service against the schema before sending it,
+// failing with ErrInvalidMetadata and the path of the invalid field. A nil schema removes it.
+func (client *Client) SetMetadataSchema(service Service, schema *MetadataSchema) {
+	schemas := maps.Clone(client.Schemas)
+	if schemas == nil {
+		schemas = map[Service]*MetadataSchema{}
+	}
+
+	if schema == nil {
+		delete(schemas, service)
+	} else {
+		schemas[service] = schema
+	}
+
+	client.Schemas = schemas
+}
+
+// validateMetadata Validates the serialized metadata against the service schema, if any.
+func (client *Client) validateMetadata(service Service, metadata any) error {
+	schema := client.Schemas[service]
+	if schema == nil {
+		return nil
+	}
+
+	return schema.Validate(metadata)
+}
+
+// Validate Validates the metadata against the schema. Nil metadata is validated as an empty object.
+func (s *MetadataSchema) Validate(metadata any) error {
+	if isNil(metadata) {
+		metadata = map[string]any{}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("%w: metadata: %w", common.ErrInvalidMetadata, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	err = decoder.Decode(&value)
+	if err != nil {
+		return fmt.Errorf("%w: metadata: %w", common.ErrInvalidMetadata, err)
+	}
+
+	return s.validate("metadata", value)
+}
+
+func (s *MetadataSchema) validate(path string, value any) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Type != "" && !hasSchemaType(s.Type, value) {
+		return fmt.Errorf("%w: %s: want %s, got %s", common.ErrInvalidMetadata, path, s.Type, schemaType(value))
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return fmt.Errorf("%w: %s: value %v not in %v", common.ErrInvalidMetadata, path, value, s.Enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				return fmt.Errorf("%w: %s.%s: required", common.ErrInvalidMetadata, path, key)
+			}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			property, ok := s.Properties[key]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%w: %s.%s: unknown field", common.ErrInvalidMetadata, path, key)
+				}
+
+				continue
+			}
+
+			err := property.validate(path+"."+key, v[key])
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range v {
+			err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasSchemaType Checks if the decoded JSON value has the schema type.
+func hasSchemaType(want string, value any) bool {
+	got := schemaType(value)
+	if want == "number" && got == "integer" {
+		return true
+	}
+
+	return got == want
+}
+
+// schemaType Returns the schema type of a decoded JSON value.
+func schemaType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			f, err := v.Float64()
+			if err != nil || f != float64(int64(f)) {
+				return "number"
+			}
+		}
+
+		return "integer"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// inEnum Checks if the decoded JSON value is equal to one of the enum values.
+func inEnum(enum []any, value any) bool {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	for _, item := range enum {
+		itemData, err := json.Marshal(item)
+		if err == nil && bytes.Equal(itemData, data) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestMetadataSchemaValidate(t *testing.T) {
+	var schema MetadataSchema
+	err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["name"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer"},
+			"score": {"type": "number"},
+			"kind": {"enum": ["a", "b", 1]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		metadata any
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			metadata: map[string]any{"name": "John", "age": 30, "score": 9.5, "kind": "a", "tags": []string{"x"}},
+		},
+		{
+			name:     "integer as number",
+			metadata: map[string]any{"name": "John", "score": 9, "age": 30.0, "kind": 1},
+		},
+		{
+			name:     "nil metadata",
+			metadata: nil,
+			wantErr:  "metadata.name: required",
+		},
+		{
+			name:     "missing field",
+			metadata: map[string]any{"age": 30},
+			wantErr:  "metadata.name: required",
+		},
+		{
+			name:     "unknown field",
+			metadata: map[string]any{"name": "John", "nmae": "John"},
+			wantErr:  "metadata.nmae: unknown field",
+		},
+		{
+			name:     "wrong type",
+			metadata: map[string]any{"name": "John", "age": "30"},
+			wantErr:  "metadata.age: want integer, got string",
+		},
+		{
+			name:     "fractional integer",
+			metadata: map[string]any{"name": "John", "age": 30.5},
+			wantErr:  "metadata.age: want integer, got number",
+		},
+		{
+			name:     "not in enum",
+			metadata: map[string]any{"name": "John", "kind": "c"},
+			wantErr:  "metadata.kind: value c not in [a b 1]",
+		},
+		{
+			name:     "wrong item",
+			metadata: map[string]any{"name": "John", "tags": []any{"x", 1}},
+			wantErr:  "metadata.tags[1]: want string, got integer",
+		},
+		{
+			name:     "wrong root",
+			metadata: []string{"John"},
+			wantErr:  "metadata: want object, got array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.metadata)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("MetadataSchema.Validate() error = %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, common.ErrInvalidMetadata) || err.Error() != common.ErrInvalidMetadata.Error()+": "+tt.wantErr {
+				t.Errorf("MetadataSchema.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetMetadataSchema(t *testing.T) {
+	requests := 0
+	client := NewClient()
+	client.Token = "123"
+	client.ExpiresAt = time.Now().Add(time.Hour)
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			requests += 1
+			return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+		},
+	})
+
+	tenant := client.WithCredentials("a", "secret-a")
+	tenant.Token = "123"
+	tenant.ExpiresAt = client.ExpiresAt
+	tenant.SetMetadataSchema(ServiceCNH, &MetadataSchema{Type: "object", Required: []string{"name"}})
+
+	_, err := tenant.GenerateSignedUrl(context.Background(), ServiceCNH, common.RESOURCE_JOB, map[string]any{}, nil)
+	if !errors.Is(err, common.ErrInvalidMetadata) || requests != 0 {
+		t.Fatalf("tenant.GenerateSignedUrl() error = %v, requests = %v, want %v before requests", err, requests, common.ErrInvalidMetadata)
+	}
+
+	_, err = tenant.SendJobSingleStep(context.Background(), ServiceCNH, "ZG9j", "", "", nil, nil)
+	if !errors.Is(err, common.ErrInvalidMetadata) || requests != 0 {
+		t.Fatalf("tenant.SendJobSingleStep() error = %v, requests = %v, want %v before requests", err, requests, common.ErrInvalidMetadata)
+	}
+
+	_, err = tenant.GenerateSignedUrl(context.Background(), ServiceRG, common.RESOURCE_JOB, map[string]any{}, nil)
+	if errors.Is(err, common.ErrInvalidMetadata) || requests != 1 {
+		t.Errorf("tenant.GenerateSignedUrl() error = %v, requests = %v, want the request of a service without schema", err, requests)
+	}
+
+	if len(client.Schemas) != 0 {
+		t.Errorf("base client schemas = %v, want none", client.Schemas)
+	}
+
+	tenant.SetMetadataSchema(ServiceCNH, nil)
+	if len(tenant.Schemas) != 0 {
+		t.Errorf("tenant schemas = %v, want none", tenant.Schemas)
+	}
+}
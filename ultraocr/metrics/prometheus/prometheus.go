@@ -0,0 +1,252 @@
+// Package prometheus implements the UltraOCR client Metrics, served on the Prometheus text
+// exposition format, so they are scraped without other dependencies.
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prometheus constants.
+const (
+	DEFAULT_NAMESPACE = "ultraocr"
+	CONTENT_TYPE      = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Default histogram buckets, in seconds.
+var (
+	DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	DefaultWaitBuckets    = []float64{1, 2, 5, 10, 30, 60, 120, 300, 600}
+)
+
+// Metrics Keeps the client measurements in memory:
+//
+//   - requests_total, by endpoint and status code ("error" when not answered)
+//   - request_errors_total, by endpoint, counting unanswered requests and 4xx and 5xx answers
+//   - request_duration_seconds histogram, by endpoint
+//   - retries_total, by phase (request, upload or wait)
+//   - wait_duration_seconds histogram, by resource (job or batch) and outcome (ok or error)
+//
+// All of them prefixed by the Namespace. It is an http.Handler serving them for scrapes. It is not a
+// client_golang Collector and can't be registered on a prometheus.Registry, as the SDK has no dependencies:
+// serve it on its own path, next to the registry handler. The buckets are sorted, without duplicates,
+// NaN or infinite bounds, when the histograms are created.
+type Metrics struct {
+	Namespace      string
+	LatencyBuckets []float64
+	WaitBuckets    []float64
+
+	mu        sync.Mutex
+	requests  map[string]*counter
+	errors    map[string]*counter
+	retries   map[string]*counter
+	latencies map[string]*histogram
+	waits     map[string]*histogram
+}
+
+type counter struct {
+	labels []string
+	value  float64
+}
+
+type histogram struct {
+	labels  []string
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// New Creates the Metrics with the namespace (Default ultraocr) and the default buckets.
+func New(namespace string) *Metrics {
+	if namespace == "" {
+		namespace = DEFAULT_NAMESPACE
+	}
+
+	return &Metrics{
+		Namespace:      namespace,
+		LatencyBuckets: DefaultLatencyBuckets,
+		WaitBuckets:    DefaultWaitBuckets,
+	}
+}
+
+// ObserveRequest Counts a request and its latency.
+func (m *Metrics) ObserveRequest(endpoint string, statusCode int, latency time.Duration, err error) {
+	code := strconv.Itoa(statusCode)
+	if err != nil || statusCode == 0 {
+		code = "error"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = inc(m.requests, endpoint, code)
+	if code == "error" || statusCode >= 400 {
+		m.errors = inc(m.errors, endpoint)
+	}
+
+	m.latencies = observe(m.latencies, m.LatencyBuckets, latency.Seconds(), endpoint)
+}
+
+// IncRetry Counts a retry.
+func (m *Metrics) IncRetry(phase string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries = inc(m.retries, phase)
+}
+
+// ObserveWait Records how long a wait took.
+func (m *Metrics) ObserveWait(resource string, wait time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.waits = observe(m.waits, m.WaitBuckets, wait.Seconds(), resource, outcome)
+}
+
+// WriteTo Writes the metrics on the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	m.mu.Lock()
+	m.writeCounters(&buf, "requests_total", "Requests sent to the UltraOCR API and storage.", []string{"endpoint", "code"}, m.requests)
+	m.writeCounters(&buf, "request_errors_total", "Requests not answered or answered with 4xx and 5xx.", []string{"endpoint"}, m.errors)
+	m.writeHistograms(&buf, "request_duration_seconds", "Requests latency.", []string{"endpoint"}, m.latencies)
+	m.writeCounters(&buf, "retries_total", "Requests, uploads and polls retried after transient errors.", []string{"phase"}, m.retries)
+	m.writeHistograms(&buf, "wait_duration_seconds", "Time waiting jobs and batches to finish.", []string{"resource", "outcome"}, m.waits)
+	m.mu.Unlock()
+
+	return buf.WriteTo(w)
+}
+
+// ServeHTTP Serves the metrics for Prometheus scrapes.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", CONTENT_TYPE)
+	_, _ = m.WriteTo(w)
+}
+
+// writeCounters Writes a counter family, sorted by labels.
+func (m *Metrics) writeCounters(buf *bytes.Buffer, name, help string, names []string, counters map[string]*counter) {
+	name = m.Namespace + "_" + name
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+
+	for _, key := range sortedKeys(counters) {
+		c := counters[key]
+		fmt.Fprintf(buf, "%s%s %s\n", name, formatLabels(names, c.labels), formatFloat(c.value))
+	}
+}
+
+// writeHistograms Writes a histogram family, sorted by labels, with cumulative buckets.
+func (m *Metrics) writeHistograms(buf *bytes.Buffer, name, help string, names []string, histograms map[string]*histogram) {
+	name = m.Namespace + "_" + name
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	bucketNames := append(slices.Clone(names), "le")
+	for _, key := range sortedKeys(histograms) {
+		h := histograms[key]
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += h.counts[i]
+			labels := append(slices.Clone(h.labels), formatFloat(bound))
+			fmt.Fprintf(buf, "%s_bucket%s %d\n", name, formatLabels(bucketNames, labels), cumulative)
+		}
+
+		labels := append(slices.Clone(h.labels), "+Inf")
+		fmt.Fprintf(buf, "%s_bucket%s %d\n", name, formatLabels(bucketNames, labels), h.count)
+		fmt.Fprintf(buf, "%s_sum%s %s\n", name, formatLabels(names, h.labels), formatFloat(h.sum))
+		fmt.Fprintf(buf, "%s_count%s %d\n", name, formatLabels(names, h.labels), h.count)
+	}
+}
+
+// inc Increments the counter of the labels, creating the map and the counter if needed.
+func inc(counters map[string]*counter, labels ...string) map[string]*counter {
+	if counters == nil {
+		counters = map[string]*counter{}
+	}
+
+	key := strings.Join(labels, "\xff")
+	c, ok := counters[key]
+	if !ok {
+		c = &counter{labels: labels}
+		counters[key] = c
+	}
+
+	c.value++
+	return counters
+}
+
+// observe Adds the value to the histogram of the labels, creating the map and the histogram if needed.
+func observe(histograms map[string]*histogram, buckets []float64, value float64, labels ...string) map[string]*histogram {
+	if histograms == nil {
+		histograms = map[string]*histogram{}
+	}
+
+	key := strings.Join(labels, "\xff")
+	h, ok := histograms[key]
+	if !ok {
+		buckets = normalizeBuckets(buckets)
+		h = &histogram{labels: labels, buckets: buckets, counts: make([]uint64, len(buckets))}
+		histograms[key] = h
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+
+	h.count++
+	h.sum += value
+	return histograms
+}
+
+// normalizeBuckets Returns the bucket bounds sorted, without duplicates, NaN or infinite bounds, the
+// +Inf bucket being always written. Unsorted bounds would write invalid cumulative counts.
+func normalizeBuckets(buckets []float64) []float64 {
+	buckets = slices.DeleteFunc(slices.Clone(buckets), func(bound float64) bool {
+		return math.IsNaN(bound) || math.IsInf(bound, 0)
+	})
+
+	slices.Sort(buckets)
+	return slices.Compact(buckets)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+// formatLabels Formats the label pairs, escaping the values.
+func formatLabels(names, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(values[i])
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, value)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
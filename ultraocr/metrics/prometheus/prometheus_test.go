@@ -0,0 +1,126 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := New("")
+	metrics.LatencyBuckets = []float64{0.1, 1}
+	metrics.WaitBuckets = []float64{10}
+
+	metrics.ObserveRequest("GET /ocr/job/result/{id}/{id}", 200, 50*time.Millisecond, nil)
+	metrics.ObserveRequest("GET /ocr/job/result/{id}/{id}", 503, 2*time.Second, nil)
+	metrics.ObserveRequest("upload", 0, time.Second, errors.New("connection reset"))
+	metrics.IncRetry("wait")
+	metrics.ObserveWait("job", 5*time.Second, nil)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Header().Get("Content-Type") != CONTENT_TYPE {
+		t.Errorf("Metrics.ServeHTTP() content type = %q", rec.Header().Get("Content-Type"))
+	}
+
+	want := `# HELP ultraocr_requests_total Requests sent to the UltraOCR API and storage.
+# TYPE ultraocr_requests_total counter
+ultraocr_requests_total{endpoint="GET /ocr/job/result/{id}/{id}",code="200"} 1
+ultraocr_requests_total{endpoint="GET /ocr/job/result/{id}/{id}",code="503"} 1
+ultraocr_requests_total{endpoint="upload",code="error"} 1
+# HELP ultraocr_request_errors_total Requests not answered or answered with 4xx and 5xx.
+# TYPE ultraocr_request_errors_total counter
+ultraocr_request_errors_total{endpoint="GET /ocr/job/result/{id}/{id}"} 1
+ultraocr_request_errors_total{endpoint="upload"} 1
+# HELP ultraocr_request_duration_seconds Requests latency.
+# TYPE ultraocr_request_duration_seconds histogram
+ultraocr_request_duration_seconds_bucket{endpoint="GET /ocr/job/result/{id}/{id}",le="0.1"} 1
+ultraocr_request_duration_seconds_bucket{endpoint="GET /ocr/job/result/{id}/{id}",le="1"} 1
+ultraocr_request_duration_seconds_bucket{endpoint="GET /ocr/job/result/{id}/{id}",le="+Inf"} 2
+ultraocr_request_duration_seconds_sum{endpoint="GET /ocr/job/result/{id}/{id}"} 2.05
+ultraocr_request_duration_seconds_count{endpoint="GET /ocr/job/result/{id}/{id}"} 2
+ultraocr_request_duration_seconds_bucket{endpoint="upload",le="0.1"} 0
+ultraocr_request_duration_seconds_bucket{endpoint="upload",le="1"} 1
+ultraocr_request_duration_seconds_bucket{endpoint="upload",le="+Inf"} 1
+ultraocr_request_duration_seconds_sum{endpoint="upload"} 1
+ultraocr_request_duration_seconds_count{endpoint="upload"} 1
+# HELP ultraocr_retries_total Requests, uploads and polls retried after transient errors.
+# TYPE ultraocr_retries_total counter
+ultraocr_retries_total{phase="wait"} 1
+# HELP ultraocr_wait_duration_seconds Time waiting jobs and batches to finish.
+# TYPE ultraocr_wait_duration_seconds histogram
+ultraocr_wait_duration_seconds_bucket{resource="job",outcome="ok",le="10"} 1
+ultraocr_wait_duration_seconds_bucket{resource="job",outcome="ok",le="+Inf"} 1
+ultraocr_wait_duration_seconds_sum{resource="job",outcome="ok"} 5
+ultraocr_wait_duration_seconds_count{resource="job",outcome="ok"} 1
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("Metrics.ServeHTTP() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMetricsUnsortedBuckets(t *testing.T) {
+	metrics := New("")
+	metrics.WaitBuckets = []float64{10, 1, math.NaN(), 5, 1, math.Inf(1)}
+
+	metrics.ObserveWait("job", 3*time.Second, nil)
+
+	var buf strings.Builder
+	_, _ = metrics.WriteTo(&buf)
+
+	want := `ultraocr_wait_duration_seconds_bucket{resource="job",outcome="ok",le="1"} 0
+ultraocr_wait_duration_seconds_bucket{resource="job",outcome="ok",le="5"} 1
+ultraocr_wait_duration_seconds_bucket{resource="job",outcome="ok",le="10"} 1
+ultraocr_wait_duration_seconds_bucket{resource="job",outcome="ok",le="+Inf"} 1
+`
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Errorf("Metrics.WriteTo() =\n%s\nwant the buckets\n%s", got, want)
+	}
+}
+
+func TestClientMetrics(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 3 * time.Second
+
+	client := ultraocr.NewClient()
+	client.SetClock(clock)
+	client.SetHttpClient(api)
+	client.SetAutoRefresh("id", "secret", 60)
+
+	metrics := New("ocr")
+	client.SetMetrics(metrics)
+
+	created, err := client.SubmitJob(context.Background(), ultraocr.JobRequest{
+		Service:  ultraocr.ServiceRG,
+		Document: ultraocr.StringSource("doc", "doc"),
+	})
+	if err != nil {
+		t.Fatalf("client.SubmitJob() error = %v", err)
+	}
+	_, err = client.WaitForJob(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+
+	var out strings.Builder
+	_, _ = metrics.WriteTo(&out)
+	for _, line := range []string{
+		`ocr_requests_total{endpoint="POST /token",code="200"} 1`,
+		`ocr_requests_total{endpoint="POST /ocr/job/rg",code="200"} 1`,
+		`ocr_requests_total{endpoint="upload",code="200"} 1`,
+		`ocr_requests_total{endpoint="GET /ocr/job/result/{id}/{id}",code="200"} 4`,
+		`ocr_wait_duration_seconds_count{resource="job",outcome="ok"} 1`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("metrics missing %q on\n%s", line, out.String())
+		}
+	}
+}
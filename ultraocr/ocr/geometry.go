@@ -0,0 +1,212 @@
+// Package ocr implements helpers to read the full page OCR results as typed pages, lines and words.
+package ocr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Box Axis aligned bounding box, on the document coordinates.
+type Box struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Right Returns the box right edge.
+func (b Box) Right() float64 {
+	return b.X + b.Width
+}
+
+// Bottom Returns the box bottom edge.
+func (b Box) Bottom() float64 {
+	return b.Y + b.Height
+}
+
+// Empty Checks if the box has no area.
+func (b Box) Empty() bool {
+	return b.Width <= 0 || b.Height <= 0
+}
+
+// Union Returns the smallest box containing both boxes, ignoring empty boxes.
+func (b Box) Union(other Box) Box {
+	if b.Empty() {
+		return other
+	}
+
+	if other.Empty() {
+		return b
+	}
+
+	x := math.Min(b.X, other.X)
+	y := math.Min(b.Y, other.Y)
+
+	return Box{
+		X:      x,
+		Y:      y,
+		Width:  math.Max(b.Right(), other.Right()) - x,
+		Height: math.Max(b.Bottom(), other.Bottom()) - y,
+	}
+}
+
+// parseBox Reads a bounding box on the known geometry formats:
+// [x0, y0, x1, y1], flat or nested polygons ([x0, y0, x1, y1, ...] or [[x, y], ...] or [{"x", "y"}, ...])
+// and objects with x/left, y/top, width/height or x0, y0, x1, y1 keys.
+func parseBox(value any) (Box, bool) {
+	switch v := value.(type) {
+	case []any:
+		if len(v) == 0 {
+			return Box{}, false
+		}
+
+		if _, ok := toFloat(v[0]); ok {
+			numbers := make([]float64, 0, len(v))
+			for _, item := range v {
+				n, ok := toFloat(item)
+				if !ok {
+					return Box{}, false
+				}
+				numbers = append(numbers, n)
+			}
+
+			if len(numbers) == 4 {
+				return corners(numbers[0], numbers[1], numbers[2], numbers[3]), true
+			}
+
+			return polygon(numbers)
+		}
+
+		numbers := []float64{}
+		for _, item := range v {
+			x, y, ok := parsePoint(item)
+			if !ok {
+				return Box{}, false
+			}
+			numbers = append(numbers, x, y)
+		}
+
+		return polygon(numbers)
+	case map[string]any:
+		if x0, ok := lookupFloat(v, "x0"); ok {
+			y0, _ := lookupFloat(v, "y0")
+			x1, _ := lookupFloat(v, "x1")
+			y1, _ := lookupFloat(v, "y1")
+			return corners(x0, y0, x1, y1), true
+		}
+
+		x, okX := lookupFloat(v, "x", "left")
+		y, okY := lookupFloat(v, "y", "top")
+		width, okW := lookupFloat(v, "width", "w")
+		height, okH := lookupFloat(v, "height", "h")
+		if !okX || !okY || !okW || !okH {
+			if points, ok := lookup(v, "points", "polygon", "vertices"); ok {
+				return parseBox(points)
+			}
+
+			return Box{}, false
+		}
+
+		return Box{X: x, Y: y, Width: width, Height: height}, true
+	default:
+		return Box{}, false
+	}
+}
+
+func parsePoint(value any) (float64, float64, bool) {
+	switch v := value.(type) {
+	case []any:
+		if len(v) != 2 {
+			return 0, 0, false
+		}
+
+		x, okX := toFloat(v[0])
+		y, okY := toFloat(v[1])
+		return x, y, okX && okY
+	case map[string]any:
+		x, okX := lookupFloat(v, "x")
+		y, okY := lookupFloat(v, "y")
+		return x, y, okX && okY
+	default:
+		return 0, 0, false
+	}
+}
+
+func corners(x0, y0, x1, y1 float64) Box {
+	return Box{
+		X:      math.Min(x0, x1),
+		Y:      math.Min(y0, y1),
+		Width:  math.Abs(x1 - x0),
+		Height: math.Abs(y1 - y0),
+	}
+}
+
+func polygon(numbers []float64) (Box, bool) {
+	if len(numbers) < 4 || len(numbers)%2 != 0 {
+		return Box{}, false
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for i := 0; i < len(numbers); i += 2 {
+		minX = math.Min(minX, numbers[i])
+		maxX = math.Max(maxX, numbers[i])
+		minY = math.Min(minY, numbers[i+1])
+		maxY = math.Max(maxY, numbers[i+1])
+	}
+
+	return corners(minX, minY, maxX, maxY), true
+}
+
+// lookup Finds the first of the keys on an object, ignoring the key case.
+func lookup(obj map[string]any, keys ...string) (any, bool) {
+	for _, key := range keys {
+		if value, ok := obj[key]; ok {
+			return value, true
+		}
+	}
+
+	for _, key := range keys {
+		for k, value := range obj {
+			if strings.EqualFold(k, key) {
+				return value, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func lookupFloat(obj map[string]any, keys ...string) (float64, bool) {
+	value, ok := lookup(obj, keys...)
+	if !ok {
+		return 0, false
+	}
+
+	return toFloat(value)
+}
+
+func lookupString(obj map[string]any, keys ...string) string {
+	value, _ := lookup(obj, keys...)
+	s, _ := value.(string)
+
+	return s
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case interface{ Float64() (float64, error) }:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
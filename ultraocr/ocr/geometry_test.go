@@ -0,0 +1,112 @@
+package ocr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBox(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		want   Box
+		wantOk bool
+	}{
+		{
+			name:   "corners",
+			value:  []any{10.0, 20.0, 30.0, 25.0},
+			want:   Box{X: 10, Y: 20, Width: 20, Height: 5},
+			wantOk: true,
+		},
+		{
+			name:   "flat polygon",
+			value:  []any{10.0, 20.0, 30.0, 20.0, 30.0, 25.0, 10.0, 25.0},
+			want:   Box{X: 10, Y: 20, Width: 20, Height: 5},
+			wantOk: true,
+		},
+		{
+			name:   "points polygon",
+			value:  []any{[]any{10.0, 20.0}, []any{30.0, 25.0}},
+			want:   Box{X: 10, Y: 20, Width: 20, Height: 5},
+			wantOk: true,
+		},
+		{
+			name:   "vertices object",
+			value:  map[string]any{"Vertices": []any{map[string]any{"x": 10.0, "y": 20.0}, map[string]any{"x": 30.0, "y": 25.0}}},
+			want:   Box{X: 10, Y: 20, Width: 20, Height: 5},
+			wantOk: true,
+		},
+		{
+			name:   "rectangle object",
+			value:  map[string]any{"Left": "10", "Top": 20.0, "Width": 20.0, "Height": 5.0},
+			want:   Box{X: 10, Y: 20, Width: 20, Height: 5},
+			wantOk: true,
+		},
+		{
+			name:   "corners object",
+			value:  map[string]any{"x0": 10.0, "y0": 20.0, "x1": 30.0, "y1": 25.0},
+			want:   Box{X: 10, Y: 20, Width: 20, Height: 5},
+			wantOk: true,
+		},
+		{
+			name:  "invalid numbers",
+			value: []any{10.0, "a", 30.0, 25.0},
+		},
+		{
+			name:  "odd polygon",
+			value: []any{10.0, 20.0, 30.0},
+		},
+		{
+			name:  "incomplete object",
+			value: map[string]any{"x": 10.0},
+		},
+		{
+			name:  "not a box",
+			value: "box",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseBox(tt.value)
+			if ok != tt.wantOk {
+				t.Errorf("parseBox() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBox() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoxUnion(t *testing.T) {
+	tests := []struct {
+		name  string
+		box   Box
+		other Box
+		want  Box
+	}{
+		{
+			name:  "overlapping",
+			box:   Box{X: 0, Y: 0, Width: 10, Height: 10},
+			other: Box{X: 5, Y: 5, Width: 10, Height: 10},
+			want:  Box{X: 0, Y: 0, Width: 15, Height: 15},
+		},
+		{
+			name:  "empty box",
+			other: Box{X: 5, Y: 5, Width: 10, Height: 10},
+			want:  Box{X: 5, Y: 5, Width: 10, Height: 10},
+		},
+		{
+			name: "empty other",
+			box:  Box{X: 5, Y: 5, Width: 10, Height: 10},
+			want: Box{X: 5, Y: 5, Width: 10, Height: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.box.Union(tt.other); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Box.Union() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package ocr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"strings"
+)
+
+// ErrPagesMismatch Error returned when the images and OCR pages quantities differ.
+var ErrPagesMismatch = errors.New("images and pages quantities differ")
+
+// Output defaults.
+const (
+	JPEG_QUALITY    = 90
+	avgCharWidth    = 0.5
+	minFontSize     = 1.0
+	pdfFontResource = "F1"
+)
+
+// HOCR Writes the pages as an hOCR document, the HTML text layer format used by archival tools.
+func HOCR(w io.Writer, pages []Page) error {
+	var b strings.Builder
+
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">` + "\n")
+	b.WriteString(`<html xmlns="http://www.w3.org/1999/xhtml">` + "\n<head>\n")
+	b.WriteString(`<meta http-equiv="Content-Type" content="text/html; charset=utf-8" />` + "\n")
+	b.WriteString(`<meta name="ocr-system" content="ultraocr" />` + "\n")
+	b.WriteString(`<meta name="ocr-capabilities" content="ocr_page ocr_line ocrx_word" />` + "\n")
+	b.WriteString("</head>\n<body>\n")
+
+	for _, page := range pages {
+		fmt.Fprintf(&b, `<div class="ocr_page" id="page_%d" title="ppageno %d; bbox 0 0 %d %d">`+"\n",
+			page.Number, page.Number-1, int(page.Width), int(page.Height))
+
+		for i, line := range page.Lines {
+			fmt.Fprintf(&b, `<span class="ocr_line" id="line_%d_%d" title="%s">`, page.Number, i+1, hocrBox(line.Box))
+
+			if len(line.Words) == 0 {
+				b.WriteString(html.EscapeString(line.Text))
+			}
+
+			for j, word := range line.Words {
+				if j > 0 {
+					b.WriteString(" ")
+				}
+
+				title := hocrBox(word.Box)
+				if word.Confidence > 0 {
+					title = fmt.Sprintf("%s; x_wconf %d", title, int(word.Confidence*100))
+				}
+
+				fmt.Fprintf(&b, `<span class="ocrx_word" id="word_%d_%d_%d" title="%s">%s</span>`,
+					page.Number, i+1, j+1, title, html.EscapeString(word.Text))
+			}
+
+			b.WriteString("</span>\n")
+		}
+
+		b.WriteString("</div>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func hocrBox(box Box) string {
+	return fmt.Sprintf("bbox %d %d %d %d", int(box.X), int(box.Y), int(box.Right()), int(box.Bottom()))
+}
+
+// Annotate Returns a copy of the page image with the word boxes drawn (or the line boxes, for lines
+// without words). Boxes are scaled from the page size to the image size when the page size is known.
+func Annotate(img image.Image, page Page, c color.Color) *image.RGBA {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Src)
+
+	sx, sy := scale(page, bounds.Dx(), bounds.Dy())
+	for _, line := range page.Lines {
+		if len(line.Words) == 0 {
+			drawBox(out, line.Box, sx, sy, c)
+			continue
+		}
+
+		for _, word := range line.Words {
+			drawBox(out, word.Box, sx, sy, c)
+		}
+	}
+
+	return out
+}
+
+func scale(page Page, width, height int) (float64, float64) {
+	sx, sy := 1.0, 1.0
+	if page.Width > 0 && page.Height > 0 {
+		sx = float64(width) / page.Width
+		sy = float64(height) / page.Height
+	}
+
+	return sx, sy
+}
+
+func drawBox(img *image.RGBA, box Box, sx, sy float64, c color.Color) {
+	if box.Empty() {
+		return
+	}
+
+	r := image.Rect(int(box.X*sx), int(box.Y*sy), int(box.Right()*sx), int(box.Bottom()*sy)).Intersect(img.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	for x := r.Min.X; x < r.Max.X; x++ {
+		img.Set(x, r.Min.Y, c)
+		img.Set(x, r.Max.Y-1, c)
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		img.Set(r.Min.X, y, c)
+		img.Set(r.Max.X-1, y, c)
+	}
+}
+
+// SearchablePDFFromImages Writes a PDF with one page per image and the OCR text as an invisible layer
+// over it, so the document can be searched and copied. It converts images only: a PDF input must be
+// rasterized to images by the caller first, one per page. Images are JPEG encoded at 72 DPI
+// (one point per pixel). The text uses the standard Helvetica font, characters outside
+// Latin-1 are replaced by "?".
+func SearchablePDFFromImages(w io.Writer, images []image.Image, pages []Page) error {
+	if len(images) != len(pages) {
+		return ErrPagesMismatch
+	}
+
+	pdf := &pdfWriter{}
+	pdf.header()
+
+	// Object numbers: 1 catalog, 2 pages tree, 3 font and 3 objects per page.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+i*3)
+	}
+
+	pdf.object(1, "<< /Type /Catalog /Pages 2 0 R >>", nil)
+	pdf.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)), nil)
+	pdf.object(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+
+	for i, img := range images {
+		pageObj, contentObj, imageObj := 4+i*3, 5+i*3, 6+i*3
+		bounds := img.Bounds()
+		width, height := bounds.Dx(), bounds.Dy()
+
+		var encoded bytes.Buffer
+		rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+		err := jpeg.Encode(&encoded, rgba, &jpeg.Options{Quality: JPEG_QUALITY})
+		if err != nil {
+			return err
+		}
+
+		content := textLayer(pages[i], width, height)
+
+		pdf.object(pageObj, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R >> /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>",
+			width, height, pdfFontResource, imageObj, contentObj,
+		), nil)
+		pdf.object(contentObj, fmt.Sprintf("<< /Length %d >>", len(content)), content)
+		pdf.object(imageObj, fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>",
+			width, height, encoded.Len(),
+		), encoded.Bytes())
+	}
+
+	pdf.trailer(1)
+
+	_, err := w.Write(pdf.buf.Bytes())
+	return err
+}
+
+// textLayer Builds the page content stream: the image on the whole page and the invisible words over it.
+func textLayer(page Page, width, height int) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "q %d 0 0 %d 0 0 cm /Im0 Do Q\n", width, height)
+	b.WriteString("BT 3 Tr\n")
+
+	sx, sy := scale(page, width, height)
+	for _, line := range page.Lines {
+		words := line.Words
+		if len(words) == 0 {
+			words = []Word{{Text: line.Text, Box: line.Box}}
+		}
+
+		for _, word := range words {
+			text := []rune(word.Text)
+			if word.Box.Empty() || len(text) == 0 {
+				continue
+			}
+
+			size := max(word.Box.Height*sy, minFontSize)
+			natural := avgCharWidth * size * float64(len(text))
+			stretch := 100 * word.Box.Width * sx / natural
+			x := word.Box.X * sx
+			y := float64(height) - word.Box.Bottom()*sy
+
+			fmt.Fprintf(&b, "/%s %.2f Tf %.2f Tz 1 0 0 1 %.2f %.2f Tm (%s) Tj\n",
+				pdfFontResource, size, stretch, x, y, pdfString(text))
+		}
+	}
+
+	b.WriteString("ET\n")
+	return b.Bytes()
+}
+
+// pdfString Escapes a text as a PDF literal string on WinAnsi encoding.
+func pdfString(text []rune) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// pdfWriter Minimal PDF writer, tracking the objects offsets for the cross reference table.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (p *pdfWriter) header() {
+	p.offsets = map[int]int{}
+	p.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+}
+
+func (p *pdfWriter) object(n int, dict string, stream []byte) {
+	p.offsets[n] = p.buf.Len()
+	fmt.Fprintf(&p.buf, "%d 0 obj\n%s\n", n, dict)
+	if stream != nil {
+		p.buf.WriteString("stream\n")
+		p.buf.Write(stream)
+		p.buf.WriteString("\nendstream\n")
+	}
+	p.buf.WriteString("endobj\n")
+}
+
+func (p *pdfWriter) trailer(root int) {
+	xref := p.buf.Len()
+	size := len(p.offsets) + 1
+
+	fmt.Fprintf(&p.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for n := 1; n < size; n++ {
+		fmt.Fprintf(&p.buf, "%010d 00000 n \n", p.offsets[n])
+	}
+
+	fmt.Fprintf(&p.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, root, xref)
+}
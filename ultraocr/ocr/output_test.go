@@ -0,0 +1,126 @@
+package ocr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var outputPage = Page{
+	Number: 1,
+	Width:  100,
+	Height: 50,
+	Lines: []Line{
+		{
+			Text: "a (b)",
+			Box:  Box{X: 10, Y: 10, Width: 40, Height: 10},
+			Words: []Word{
+				{Text: "a", Box: Box{X: 10, Y: 10, Width: 10, Height: 10}, Confidence: 0.95},
+				{Text: "(b)", Box: Box{X: 30, Y: 10, Width: 20, Height: 10}},
+			},
+		},
+		{Text: "ação <x>", Box: Box{X: 10, Y: 30, Width: 40, Height: 10}},
+	},
+}
+
+func TestHOCR(t *testing.T) {
+	var buf bytes.Buffer
+	err := HOCR(&buf, []Page{outputPage})
+	if err != nil {
+		t.Fatalf("HOCR() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<div class="ocr_page" id="page_1" title="ppageno 0; bbox 0 0 100 50">`,
+		`<span class="ocrx_word" id="word_1_1_1" title="bbox 10 10 20 20; x_wconf 95">a</span>`,
+		`<span class="ocrx_word" id="word_1_1_2" title="bbox 30 10 50 20">(b)</span>`,
+		`<span class="ocr_line" id="line_1_2" title="bbox 10 30 50 40">ação &lt;x&gt;</span>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("HOCR() = %v, want to contain %v", buf.String(), want)
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 200, 100))
+	red := color.RGBA{R: 255, A: 255}
+
+	out := Annotate(img, outputPage, red)
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.Color
+	}{
+		{name: "word corner", x: 20, y: 20, want: red},
+		{name: "word edge", x: 30, y: 39, want: red},
+		{name: "line without words", x: 20, y: 60, want: red},
+		{name: "inside word", x: 25, y: 30, want: color.RGBA{A: 255}},
+		{name: "outside boxes", x: 150, y: 90, want: color.RGBA{A: 255}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := out.At(tt.x, tt.y); got != tt.want {
+				t.Errorf("Annotate() At(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchablePDFFromImages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+
+	t.Run("pages mismatch", func(t *testing.T) {
+		err := SearchablePDFFromImages(&bytes.Buffer{}, []image.Image{img}, nil)
+		if !errors.Is(err, ErrPagesMismatch) {
+			t.Errorf("SearchablePDFFromImages() error = %v, want %v", err, ErrPagesMismatch)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := SearchablePDFFromImages(&buf, []image.Image{img, img}, []Page{outputPage, outputPage})
+		if err != nil {
+			t.Fatalf("SearchablePDFFromImages() error = %v", err)
+		}
+
+		pdf := buf.Bytes()
+		if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+			t.Fatalf("SearchablePDFFromImages() invalid header or trailer")
+		}
+
+		for _, want := range []string{
+			"/Count 2",
+			"/MediaBox [0 0 200 100]",
+			"/Filter /DCTDecode",
+			"(\\(b\\)) Tj",
+			"(a\\347\\343o <x>) Tj",
+		} {
+			if !bytes.Contains(pdf, []byte(want)) {
+				t.Errorf("SearchablePDFFromImages() want to contain %v", want)
+			}
+		}
+
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+		xref, _ := strconv.Atoi(string(startxref[1]))
+		if !bytes.HasPrefix(pdf[xref:], []byte("xref\n0 10\n")) {
+			t.Fatalf("SearchablePDFFromImages() invalid startxref %d", xref)
+		}
+
+		entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+		for i, entry := range entries {
+			offset, _ := strconv.Atoi(string(entry[1]))
+			want := fmt.Sprintf("%d 0 obj", i+1)
+			if !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+				t.Errorf("SearchablePDFFromImages() xref entry %d does not point to %v", i+1, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package ocr
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+// ErrInvalidDocument Error returned when the result document is not a full page OCR document.
+var ErrInvalidDocument = errors.New("invalid OCR document")
+
+// PAGE_SEPARATOR Separates the pages on the document plain text.
+const PAGE_SEPARATOR = "\f"
+
+// Word Recognized word with its bounding box.
+type Word struct {
+	Text       string  `json:"text"`
+	Box        Box     `json:"box"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// Line Text line with its words, in reading order.
+type Line struct {
+	Text  string `json:"text"`
+	Box   Box    `json:"box"`
+	Words []Word `json:"words,omitempty"`
+}
+
+// Page Document page with its lines, in reading order.
+type Page struct {
+	Number int     `json:"number"`
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+	Lines  []Line  `json:"lines"`
+}
+
+// Text Returns the page plain text, one line per text line.
+func (p Page) Text() string {
+	lines := make([]string, len(p.Lines))
+	for i, line := range p.Lines {
+		lines[i] = line.Text
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Words Returns every page word, in reading order.
+func (p Page) Words() []Word {
+	words := []Word{}
+	for _, line := range p.Lines {
+		words = append(words, line.Words...)
+	}
+
+	return words
+}
+
+// Pages Reads the pages of a full page OCR job result.
+func Pages(result ultraocr.JobResultResponse) ([]Page, error) {
+	return ParseDocument(result.Result.Document)
+}
+
+// Text Returns the plain text of a full page OCR job result, with pages separated by PAGE_SEPARATOR.
+func Text(result ultraocr.JobResultResponse) (string, error) {
+	pages, err := Pages(result)
+	if err != nil {
+		return "", err
+	}
+
+	texts := make([]string, len(pages))
+	for i, page := range pages {
+		texts[i] = page.Text()
+	}
+
+	return strings.Join(texts, PAGE_SEPARATOR), nil
+}
+
+// ParseDocument Reads the pages of a result document.
+// The document can be a page list, an object with a "pages" list or a single page. Pages have
+// "lines" with "words", only "words" (grouped on lines by position) or only "text", and geometries
+// on "bbox", "box", "boundingBox" or "geometry" keys. Keys are matched ignoring the case.
+func ParseDocument(document any) ([]Page, error) {
+	var items []any
+	switch v := document.(type) {
+	case []any:
+		items = v
+	case map[string]any:
+		if pages, ok := lookup(v, "pages"); ok {
+			items, _ = pages.([]any)
+		} else {
+			items = []any{v}
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, ErrInvalidDocument
+	}
+
+	pages := make([]Page, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, ErrInvalidDocument
+		}
+
+		page, ok := parsePage(obj, i+1)
+		if !ok {
+			return nil, ErrInvalidDocument
+		}
+
+		pages = append(pages, page)
+	}
+
+	return pages, nil
+}
+
+func parsePage(obj map[string]any, number int) (Page, bool) {
+	page := Page{Number: number}
+	if n, ok := lookupFloat(obj, "number", "page"); ok && n > 0 {
+		page.Number = int(n)
+	}
+	page.Width, _ = lookupFloat(obj, "width")
+	page.Height, _ = lookupFloat(obj, "height")
+
+	if items, ok := lookupList(obj, "lines"); ok {
+		for _, item := range items {
+			line, ok := item.(map[string]any)
+			if !ok {
+				return Page{}, false
+			}
+			page.Lines = append(page.Lines, parseLine(line))
+		}
+
+		return page, true
+	}
+
+	if items, ok := lookupList(obj, "words"); ok {
+		page.Lines = GroupLines(parseWords(items))
+		return page, true
+	}
+
+	value, ok := lookup(obj, "text", "fulltext")
+	text, isString := value.(string)
+	if !ok || !isString {
+		return Page{}, false
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		page.Lines = append(page.Lines, Line{Text: line})
+	}
+
+	return page, true
+}
+
+func parseLine(obj map[string]any) Line {
+	line := Line{Text: lookupString(obj, "text")}
+	line.Box, _ = parseGeometry(obj)
+
+	if items, ok := lookupList(obj, "words"); ok {
+		line.Words = parseWords(items)
+		sort.SliceStable(line.Words, func(i, j int) bool {
+			return line.Words[i].Box.X < line.Words[j].Box.X
+		})
+	}
+
+	if line.Text == "" {
+		line.Text = joinWords(line.Words)
+	}
+
+	for _, word := range line.Words {
+		line.Box = line.Box.Union(word.Box)
+	}
+
+	return line
+}
+
+func parseWords(items []any) []Word {
+	words := make([]Word, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		word := Word{Text: lookupString(obj, "text")}
+		word.Box, _ = parseGeometry(obj)
+		word.Confidence, _ = lookupFloat(obj, "confidence", "score")
+		words = append(words, word)
+	}
+
+	return words
+}
+
+func parseGeometry(obj map[string]any) (Box, bool) {
+	value, ok := lookup(obj, "bbox", "box", "boundingBox", "geometry")
+	if !ok {
+		return Box{}, false
+	}
+
+	return parseBox(value)
+}
+
+func lookupList(obj map[string]any, key string) ([]any, bool) {
+	value, ok := lookup(obj, key)
+	if !ok {
+		return nil, false
+	}
+
+	list, ok := value.([]any)
+	return list, ok
+}
+
+func joinWords(words []Word) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Text
+	}
+
+	return strings.Join(texts, " ")
+}
+
+// GroupLines Groups words on lines by their vertical overlap, sorting lines top to bottom
+// and words left to right.
+func GroupLines(words []Word) []Line {
+	sorted := append([]Word(nil), words...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Box.Y+sorted[i].Box.Height/2 < sorted[j].Box.Y+sorted[j].Box.Height/2
+	})
+
+	lines := []Line{}
+	for _, word := range sorted {
+		n := len(lines)
+		if n > 0 && sameLine(lines[n-1].Box, word.Box) {
+			lines[n-1].Words = append(lines[n-1].Words, word)
+			lines[n-1].Box = lines[n-1].Box.Union(word.Box)
+			continue
+		}
+
+		lines = append(lines, Line{Box: word.Box, Words: []Word{word}})
+	}
+
+	for i := range lines {
+		sort.SliceStable(lines[i].Words, func(a, b int) bool {
+			return lines[i].Words[a].Box.X < lines[i].Words[b].Box.X
+		})
+		lines[i].Text = joinWords(lines[i].Words)
+	}
+
+	return lines
+}
+
+// sameLine Checks if a word overlaps at least half of its height with a line.
+func sameLine(line, word Box) bool {
+	if word.Empty() {
+		return true
+	}
+
+	top := max(line.Y, word.Y)
+	bottom := min(line.Bottom(), word.Bottom())
+
+	return bottom-top >= word.Height/2
+}
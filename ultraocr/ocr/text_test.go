@@ -0,0 +1,135 @@
+package ocr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func word(text string, x, y float64) map[string]any {
+	return map[string]any{"text": text, "bbox": []any{x, y, x + 10, y + 10}, "confidence": 0.9}
+}
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document any
+		want     []Page
+		wantErr  bool
+	}{
+		{
+			name: "lines with words",
+			document: map[string]any{
+				"Pages": []any{
+					map[string]any{
+						"Page":   2.0,
+						"Width":  100.0,
+						"Height": 200.0,
+						"Lines": []any{
+							map[string]any{"Words": []any{word("world", 20, 0), word("hello", 0, 0)}},
+						},
+					},
+				},
+			},
+			want: []Page{
+				{
+					Number: 2,
+					Width:  100,
+					Height: 200,
+					Lines: []Line{
+						{
+							Text: "hello world",
+							Box:  Box{X: 0, Y: 0, Width: 30, Height: 10},
+							Words: []Word{
+								{Text: "hello", Box: Box{X: 0, Y: 0, Width: 10, Height: 10}, Confidence: 0.9},
+								{Text: "world", Box: Box{X: 20, Y: 0, Width: 10, Height: 10}, Confidence: 0.9},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "only words",
+			document: []any{
+				map[string]any{
+					"words": []any{word("second", 0, 20), word("b", 20, 2), word("a", 0, 0)},
+				},
+			},
+			want: []Page{
+				{
+					Number: 1,
+					Lines: []Line{
+						{
+							Text: "a b",
+							Box:  Box{X: 0, Y: 0, Width: 30, Height: 12},
+							Words: []Word{
+								{Text: "a", Box: Box{X: 0, Y: 0, Width: 10, Height: 10}, Confidence: 0.9},
+								{Text: "b", Box: Box{X: 20, Y: 2, Width: 10, Height: 10}, Confidence: 0.9},
+							},
+						},
+						{
+							Text: "second",
+							Box:  Box{X: 0, Y: 20, Width: 10, Height: 10},
+							Words: []Word{
+								{Text: "second", Box: Box{X: 0, Y: 20, Width: 10, Height: 10}, Confidence: 0.9},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "only text",
+			document: map[string]any{"FullText": "a\nb"},
+			want:     []Page{{Number: 1, Lines: []Line{{Text: "a"}, {Text: "b"}}}},
+		},
+		{
+			name:     "empty document",
+			document: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "page without text",
+			document: []any{map[string]any{"CPF": "123"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDocument(tt.document)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidDocument) {
+				t.Errorf("ParseDocument() error = %v, want %v", err, ErrInvalidDocument)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDocument() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	result := ultraocr.JobResultResponse{
+		Result: ultraocr.Result{
+			Document: []any{
+				map[string]any{"text": "page 1\nline 2"},
+				map[string]any{"text": "page 2"},
+			},
+		},
+	}
+
+	got, err := Text(result)
+	if err != nil {
+		t.Fatalf("Text() error = %v", err)
+	}
+
+	want := "page 1\nline 2" + PAGE_SEPARATOR + "page 2"
+	if got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	neturl "net/url"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// Validate Checks the options values.
+func (opts JobOptions) Validate() error {
+	if opts.CallbackURL == "" {
+		return nil
+	}
+
+	u, err := neturl.Parse(opts.CallbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q", common.ErrInvalidCallbackURL, opts.CallbackURL)
+	}
+
+	return nil
+}
+
+// Params Returns the options as the API query params, the Extra ones replaced by the typed options set.
+func (opts JobOptions) Params() map[string]string {
+	params := map[string]string{}
+	maps.Copy(params, opts.Extra)
+
+	for key, enabled := range map[string]bool{
+		common.KEY_FACEMATCH: opts.Facematch,
+		common.KEY_EXTRA:     opts.ExtraDocument,
+		common.KEY_BASE64:    opts.Base64,
+	} {
+		if enabled {
+			params[key] = common.FLAG_TRUE
+		}
+	}
+
+	if opts.CallbackURL != "" {
+		params[common.KEY_CALLBACK_URL] = opts.CallbackURL
+	}
+
+	if opts.IdempotencyKey != "" {
+		params[common.KEY_IDEMPOTENCY_KEY] = opts.IdempotencyKey
+	}
+
+	return params
+}
+
+// Params Returns the filters as the API query params.
+func (filter JobsFilter) Params() map[string]string {
+	params := map[string]string{}
+
+	for key, value := range map[string]string{
+		common.KEY_STATUS:            string(filter.Status),
+		common.KEY_SERVICE:           string(filter.Service),
+		common.KEY_VALIDATION_STATUS: filter.ValidationStatus,
+		common.KEY_CLIENT_DATA:       filter.ClientData,
+	} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+
+	return params
+}
+
+// Params Returns the filters as the API query params.
+func (filter BatchesFilter) Params() map[string]string {
+	return JobsFilter{Status: filter.Status, Service: filter.Service}.Params()
+}
+
+// SendJobWithOptions Sends a job, like SendJob, using typed options instead of raw query params.
+// With the Base64 option, the files are base64 data, like SendJobBase64.
+func (client *Client) SendJobWithOptions(ctx context.Context,
+	service Service,
+	filePath,
+	facematchFilePath,
+	extraFilePath string,
+	metadata map[string]any,
+	opts JobOptions,
+) (CreatedResponse, error) {
+	source := FileSource
+	if opts.Base64 {
+		source = func(data string) Source { return StringSource("base64", data) }
+	}
+
+	return client.SubmitJob(ctx, JobRequest{
+		Service:       service,
+		Document:      source(filePath),
+		Selfie:        source(facematchFilePath),
+		ExtraDocument: source(extraFilePath),
+		Metadata:      metadata,
+		Options:       opts,
+	})
+}
+
+// SendBatchWithOptions Sends a batch, like SendBatch, using typed options instead of raw query params.
+// With the Base64 option, the file is base64 data, like SendBatchBase64.
+func (client *Client) SendBatchWithOptions(ctx context.Context,
+	service Service,
+	filePath string,
+	metadata []map[string]any,
+	opts JobOptions,
+) (CreatedResponse, error) {
+	document := FileSource(filePath)
+	if opts.Base64 {
+		document = StringSource("base64", filePath)
+	}
+
+	return client.SubmitBatch(ctx, BatchRequest{
+		Service:  service,
+		Document: document,
+		Metadata: metadata,
+		Options:  opts,
+	})
+}
@@ -0,0 +1,197 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestJobOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    JobOptions
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			want: map[string]string{},
+		},
+		{
+			name: "callback",
+			opts: JobOptions{CallbackURL: "https://example.com/hook"},
+			want: map[string]string{common.KEY_CALLBACK_URL: "https://example.com/hook"},
+		},
+		{
+			name: "callback and extra",
+			opts: JobOptions{
+				CallbackURL: "http://example.com/hook",
+				Extra:       map[string]string{common.KEY_FACEMATCH: common.FLAG_TRUE},
+			},
+			want: map[string]string{
+				common.KEY_CALLBACK_URL: "http://example.com/hook",
+				common.KEY_FACEMATCH:    common.FLAG_TRUE,
+			},
+		},
+		{
+			name: "typed flags",
+			opts: JobOptions{Facematch: true, ExtraDocument: true, Base64: true},
+			want: map[string]string{
+				common.KEY_FACEMATCH: common.FLAG_TRUE,
+				common.KEY_EXTRA:     common.FLAG_TRUE,
+				common.KEY_BASE64:    common.FLAG_TRUE,
+			},
+		},
+		{
+			name: "typed flags override extra",
+			opts: JobOptions{
+				Facematch: true,
+				Extra:     map[string]string{common.KEY_FACEMATCH: "false", "custom": "value"},
+			},
+			want: map[string]string{
+				common.KEY_FACEMATCH: common.FLAG_TRUE,
+				"custom":             "value",
+			},
+		},
+		{
+			name: "unset typed flags keep extra",
+			opts: JobOptions{
+				Facematch: false,
+				Extra: map[string]string{
+					common.KEY_FACEMATCH:       common.FLAG_TRUE,
+					common.KEY_BASE64:          common.FLAG_TRUE,
+					common.KEY_IDEMPOTENCY_KEY: "order-41",
+				},
+			},
+			want: map[string]string{
+				common.KEY_FACEMATCH:       common.FLAG_TRUE,
+				common.KEY_BASE64:          common.FLAG_TRUE,
+				common.KEY_IDEMPOTENCY_KEY: "order-41",
+			},
+		},
+		{
+			name: "idempotency key",
+			opts: JobOptions{IdempotencyKey: "order-42"},
+			want: map[string]string{common.KEY_IDEMPOTENCY_KEY: "order-42"},
+		},
+		{
+			name:    "relative callback",
+			opts:    JobOptions{CallbackURL: "/hook"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			opts:    JobOptions{CallbackURL: "ftp://example.com/hook"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JobOptions.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, common.ErrInvalidCallbackURL) {
+					t.Errorf("JobOptions.Validate() error = %v, want %v", err, common.ErrInvalidCallbackURL)
+				}
+				return
+			}
+			if got := tt.opts.Params(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JobOptions.Params() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendBatchWithOptions(t *testing.T) {
+	f, _ := os.CreateTemp(t.TempDir(), "")
+	_, _ = f.WriteString("file")
+	f.Close()
+
+	var callback string
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.Method == http.MethodPost {
+					callback = req.URL.Query().Get(common.KEY_CALLBACK_URL)
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123","urls":{"document":"url"}}`))),
+				}, nil
+			},
+		},
+		Token:     "123",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	opts := JobOptions{CallbackURL: "https://example.com/hook"}
+	_, err := client.SendBatchWithOptions(context.Background(), "rg", f.Name(), nil, opts)
+	if err != nil {
+		t.Fatalf("client.SendBatchWithOptions() error = %v", err)
+	}
+	if callback != opts.CallbackURL {
+		t.Errorf("client.SendBatchWithOptions() callback = %v, want %v", callback, opts.CallbackURL)
+	}
+
+	_, err = client.SendJobWithOptions(context.Background(), "rg", f.Name(), "", "", nil, JobOptions{CallbackURL: "hook"})
+	if !errors.Is(err, common.ErrInvalidCallbackURL) {
+		t.Errorf("client.SendJobWithOptions() error = %v, want %v", err, common.ErrInvalidCallbackURL)
+	}
+}
+
+func TestSendJobWithOptions(t *testing.T) {
+	var params url.Values
+	var mu sync.Mutex
+	uploads := map[string]string{}
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.Method == http.MethodPut {
+					body, _ := io.ReadAll(req.Body)
+					mu.Lock()
+					uploads[req.URL.Path] = string(body)
+					mu.Unlock()
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				}
+
+				params = req.URL.Query()
+				return &http.Response{
+					StatusCode: 200,
+					Body: io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123","urls":{` +
+						`"document":"https://bucket/document","selfie":"https://bucket/selfie","extra_document":"https://bucket/extra"}}`))),
+				}, nil
+			},
+		},
+		Token:     "123",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	opts := JobOptions{Facematch: true, ExtraDocument: true, Base64: true}
+	_, err := client.SendJobWithOptions(context.Background(), ServiceCNH, "ZG9j", "c2VsZmll", "ZXh0cmE=", nil, opts)
+	if err != nil {
+		t.Fatalf("client.SendJobWithOptions() error = %v", err)
+	}
+
+	for _, key := range []string{common.KEY_FACEMATCH, common.KEY_EXTRA, common.KEY_BASE64} {
+		if params.Get(key) != common.FLAG_TRUE {
+			t.Errorf("client.SendJobWithOptions() param %v = %q, want %q", key, params.Get(key), common.FLAG_TRUE)
+		}
+	}
+
+	want := map[string]string{"/document": "ZG9j", "/selfie": "c2VsZmll", "/extra": "ZXh0cmE="}
+	if !reflect.DeepEqual(uploads, want) {
+		t.Errorf("client.SendJobWithOptions() uploads = %v, want %v", uploads, want)
+	}
+}
@@ -0,0 +1,212 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// pollDeadlineKey Context key of a timeout deadline shared by concurrent polls.
+type pollDeadlineKey struct{}
+
+// withPollDeadline Returns a context whose polls time out at deadline instead of their own timeout.
+func withPollDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, pollDeadlineKey{}, deadline)
+}
+
+// NewPoller Creates a Poller with the Client interval, poll strategy, timeout, error budget, health policy, hooks, metrics and clock,
+// the same used by the Client waits. Resource and ID identify the polled item on the hooks events.
+func (client *Client) NewPoller(resource, ID string) Poller {
+	interval := time.Second * time.Duration(client.Interval)
+	if client.Interval <= 0 {
+		interval = time.Second * common.POOLING_INTERVAL
+	}
+
+	strategy := pollStrategyDefaults(client.PollStrategy, interval)
+
+	// a zero Client timeout times out after the first poll, while a zero Poller timeout waits forever
+	timeout := max(time.Second*time.Duration(client.Timeout), time.Nanosecond)
+
+	return Poller{
+		Strategy:           strategy,
+		Timeout:            timeout,
+		UseContextDeadline: client.UseContextDeadline,
+		ErrorBudget:        client.ErrorBudget,
+		Health:             client.Health,
+		Hooks:              client.Hooks,
+		Metrics:            client.Metrics,
+		Clock:              client.clock(),
+		Resource:           resource,
+		ID:                 ID,
+	}
+}
+
+// Poll Calls poll until it returns done, sleeping between calls as the strategy.
+// Fails with ErrTimeout after the timeout, ErrMaxAttempts after the max attempts or the context error
+// when it is done first. Up to the error budget consecutive transient errors are tolerated, polling as usual.
+// With a health policy, server errors are tolerated and suspend the wait, see HealthPolicy.
+// The context deadline also ends the wait, or replaces the timeout with UseContextDeadline.
+func (p Poller) Poll(ctx context.Context, poll PollFunc) (err error) {
+	markPhase(ctx, common.PHASE_WAIT)
+
+	clock := p.clock()
+	if p.Metrics != nil {
+		start := clock.Now()
+		defer func() {
+			p.Metrics.ObserveWait(p.Resource, clock.Now().Sub(start), err)
+		}()
+	}
+
+	timeout := p.timeout(ctx)
+	deadline := clock.Now().Add(timeout)
+	if shared, ok := ctx.Value(pollDeadlineKey{}).(time.Time); ok {
+		deadline = shared
+	}
+	health := newHealthTracker(clock, p.Hooks, p.Health, p.Resource, p.ID)
+	transientErrors := 0
+
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		done, err := poll(ctx)
+		if p.OnTick != nil {
+			p.OnTick(PollTick{Attempt: attempt, Done: done, Err: err})
+		}
+
+		var retryErr error
+		if err != nil && transientError(err) && transientErrors < p.ErrorBudget {
+			transientErrors += 1
+			retryErr = err
+		} else if err != nil {
+			suspended, err := health.failed(err)
+			if err != nil {
+				return err
+			}
+
+			if suspended > 0 {
+				// the suspended time does not count on the timeout
+				deadline = deadline.Add(suspended)
+				err = sleep(ctx, clock, suspended)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+		} else {
+			transientErrors = 0
+			health.succeeded()
+		}
+
+		if done {
+			return nil
+		}
+
+		if timeout > 0 && clock.Now().After(deadline) {
+			return common.ErrTimeout
+		}
+
+		if p.MaxAttempts > 0 && attempt+1 >= p.MaxAttempts {
+			return common.ErrMaxAttempts
+		}
+
+		delay := p.delay(attempt)
+		if retryErr != nil {
+			retrying(p.Hooks, p.Metrics, RetryEvent{Phase: common.PHASE_WAIT, Target: p.ID, Attempt: attempt, Delay: delay, Err: retryErr})
+		}
+
+		err = sleep(ctx, clock, delay)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// PollStatusURL Polls a status URL, like the one returned on job and batch creation, until its status
+// is done or error, decoding the last response on result (e.g. a JobResultResponse or BatchStatusResponse).
+// Fails with ErrInvalidStatusURL, before any request, if it is not a status URL of the Client BaseURL.
+func (client *Client) PollStatusURL(ctx context.Context, poller Poller, statusURL string, result any) error {
+	_, err := client.parseStatusURL(statusURL)
+	if err != nil {
+		return err
+	}
+
+	return poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		response, err := client.get(ctx, statusURL, nil)
+		if err != nil {
+			return false, err
+		}
+
+		if response.status != 200 {
+			return false, response.apiError()
+		}
+
+		var status struct {
+			Status Status `json:"status"`
+		}
+
+		err = json.Unmarshal(response.body, &status)
+		if err != nil {
+			return false, common.ErrParsingResponse
+		}
+
+		err = json.Unmarshal(response.body, result)
+		if err != nil {
+			return false, common.ErrParsingResponse
+		}
+
+		return status.Status.IsTerminal(), nil
+	})
+}
+
+// transientError Checks if the error may go away on the next poll, like network errors,
+// rate limits (status code 429) and API server errors (status code 5xx).
+func transientError(err error) bool {
+	if isContextError(err) {
+		return false
+	}
+
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return errors.Is(err, common.ErrDoingRequest)
+}
+
+func (p Poller) clock() Clock {
+	if p.Clock == nil {
+		return realClock{}
+	}
+
+	return p.Clock
+}
+
+// timeout Returns the Poller timeout, or the time left to the context deadline if the Poller uses it instead.
+func (p Poller) timeout(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if p.UseContextDeadline && ok {
+		return max(time.Until(deadline), time.Nanosecond)
+	}
+
+	return p.Timeout
+}
+
+// delay Returns the sleep before the next poll, attempt starting at 0.
+func (p Poller) delay(attempt int) time.Duration {
+	if p.Strategy != nil {
+		return p.Strategy.Delay(attempt)
+	}
+
+	if p.Interval <= 0 {
+		return time.Second * common.POOLING_INTERVAL
+	}
+
+	return p.Interval
+}
@@ -0,0 +1,212 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestPoller(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	errPoll := errors.New("poll failed")
+
+	tests := []struct {
+		name      string
+		poller    Poller
+		doneAt    int
+		err       error
+		wantErr   error
+		wantTicks int
+		wantSlept time.Duration
+	}{
+		{
+			name:      "done",
+			poller:    Poller{Interval: 2 * time.Second},
+			doneAt:    3,
+			wantTicks: 3,
+			wantSlept: 4 * time.Second,
+		},
+		{
+			name:      "default interval",
+			doneAt:    2,
+			wantTicks: 2,
+			wantSlept: time.Second,
+		},
+		{
+			name:      "strategy",
+			poller:    Poller{Interval: time.Hour, Strategy: ExponentialPolling{Initial: time.Second}},
+			doneAt:    4,
+			wantTicks: 4,
+			wantSlept: 7 * time.Second,
+		},
+		{
+			name:      "max attempts",
+			poller:    Poller{MaxAttempts: 3},
+			doneAt:    -1,
+			wantErr:   common.ErrMaxAttempts,
+			wantTicks: 3,
+			wantSlept: 2 * time.Second,
+		},
+		{
+			name:      "timeout",
+			poller:    Poller{Interval: 2 * time.Second, Timeout: 5 * time.Second},
+			doneAt:    -1,
+			wantErr:   common.ErrTimeout,
+			wantTicks: 4,
+			wantSlept: 6 * time.Second,
+		},
+		{
+			name:      "error",
+			err:       errPoll,
+			wantErr:   errPoll,
+			wantTicks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewAutoClock(start)
+			poller := tt.poller
+			poller.Clock = clock
+
+			ticks := 0
+			poller.OnTick = func(tick PollTick) {
+				if tick.Attempt != ticks {
+					t.Errorf("PollTick.Attempt = %v, want %v", tick.Attempt, ticks)
+				}
+				ticks += 1
+			}
+
+			polls := 0
+			err := poller.Poll(context.Background(), func(ctx context.Context) (bool, error) {
+				polls += 1
+				return polls == tt.doneAt, tt.err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Poller.Poll() error = %v, want %v", err, tt.wantErr)
+			}
+			if ticks != tt.wantTicks {
+				t.Errorf("Poller.Poll() ticks = %v, want %v", ticks, tt.wantTicks)
+			}
+			if slept := clock.Now().Sub(start); slept != tt.wantSlept {
+				t.Errorf("Poller.Poll() slept = %v, want %v", slept, tt.wantSlept)
+			}
+		})
+	}
+}
+
+func TestPollStatusURL(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ultraocrtest.NewAutoClock(start)
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 10 * time.Second
+	client := newFakeClient(clock, api)
+
+	created, err := client.SendJobSingleStep(context.Background(), "rg", "ZmlsZQ==", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendJobSingleStep() error = %v", err)
+	}
+
+	var result JobResultResponse
+	poller := client.NewPoller(common.RESOURCE_JOB, created.Id)
+	err = client.PollStatusURL(context.Background(), poller, created.StatusURL, &result)
+	if err != nil {
+		t.Fatalf("client.PollStatusURL() error = %v", err)
+	}
+	if result.JobID != created.Id || result.Status != common.STATUS_DONE {
+		t.Errorf("client.PollStatusURL() result = %+v, want job %v done", result, created.Id)
+	}
+}
+
+func TestErrorBudget(t *testing.T) {
+	serverErr := &common.APIError{StatusCode: 503}
+	networkErr := fmt.Errorf("%w: %w", common.ErrDoingRequest, errors.New("connection reset"))
+
+	tests := []struct {
+		name    string
+		budget  int
+		errs    []error
+		wantErr error
+	}{
+		{
+			name:    "no budget",
+			errs:    []error{serverErr},
+			wantErr: serverErr,
+		},
+		{
+			name:   "server errors within budget",
+			budget: 2,
+			errs:   []error{serverErr, serverErr},
+		},
+		{
+			name:    "server errors beyond budget",
+			budget:  2,
+			errs:    []error{serverErr, serverErr, serverErr},
+			wantErr: serverErr,
+		},
+		{
+			name:   "rate limited",
+			budget: 1,
+			errs:   []error{&common.APIError{StatusCode: 429}},
+		},
+		{
+			name:   "network errors",
+			budget: 1,
+			errs:   []error{networkErr},
+		},
+		{
+			name:   "budget reset after success",
+			budget: 1,
+			errs:   []error{serverErr, nil, networkErr, nil, serverErr},
+		},
+		{
+			name:    "client errors",
+			budget:  2,
+			errs:    []error{&common.APIError{StatusCode: 404}},
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:    "context errors",
+			budget:  2,
+			errs:    []error{fmt.Errorf("%w: %w", common.ErrDoingRequest, context.DeadlineExceeded)},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poller := Poller{
+				Clock:       ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				ErrorBudget: tt.budget,
+			}
+
+			polls := 0
+			err := poller.Poll(context.Background(), func(ctx context.Context) (bool, error) {
+				polls += 1
+				if polls <= len(tt.errs) {
+					return false, tt.errs[polls-1]
+				}
+				return true, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Poller.Poll() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientErrorBudget(t *testing.T) {
+	client := statusSequenceClient(ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), 502, 500)
+	client.SetErrorBudget(2)
+
+	result, err := client.WaitForJobDone(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+	if err != nil {
+		t.Fatalf("client.WaitForJobDone() error = %v", err)
+	}
+	if result.Status != common.STATUS_DONE {
+		t.Errorf("client.WaitForJobDone() status = %v, want %v", result.Status, common.STATUS_DONE)
+	}
+}
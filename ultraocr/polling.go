@@ -0,0 +1,120 @@
+package ultraocr
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetPollStrategy Changes how long the wait utilities sleep between status requests.
+// Nil uses a fixed sleep of the Client interval, as do a zero ExponentialPolling Initial and
+// a nil JitteredPolling Strategy. Fails with ErrInvalidPollStrategy on an ExponentialPolling Factor under 1.
+func (client *Client) SetPollStrategy(strategy PollStrategy) error {
+	err := validatePollStrategy(strategy)
+	if err != nil {
+		return err
+	}
+
+	client.PollStrategy = strategy
+	return nil
+}
+
+// validatePollStrategy Checks the known strategies, rejecting factors that shrink the sleep.
+func validatePollStrategy(strategy PollStrategy) error {
+	switch s := strategy.(type) {
+	case ExponentialPolling:
+		if s.Factor != 0 && s.Factor < 1 {
+			return fmt.Errorf("%w: factor %v under 1", common.ErrInvalidPollStrategy, s.Factor)
+		}
+	case JitteredPolling:
+		return validatePollStrategy(s.Strategy)
+	}
+
+	return nil
+}
+
+// pollStrategyDefaults Fills the zero values of the known strategies with the interval,
+// so they don't poll without sleeping.
+func pollStrategyDefaults(strategy PollStrategy, interval time.Duration) PollStrategy {
+	switch s := strategy.(type) {
+	case nil:
+		return FixedPolling{Interval: interval}
+	case ExponentialPolling:
+		if s.Initial <= 0 {
+			s.Initial = interval
+		}
+
+		return s
+	case JitteredPolling:
+		s.Strategy = pollStrategyDefaults(s.Strategy, interval)
+		return s
+	}
+
+	return strategy
+}
+
+// FixedPolling Sleeps the same interval between every status request.
+type FixedPolling struct {
+	Interval time.Duration
+}
+
+// Delay Returns the fixed interval.
+func (p FixedPolling) Delay(attempt int) time.Duration {
+	return p.Interval
+}
+
+// ExponentialPolling Multiplies the sleep by Factor (default 2, at least 1) after each status request,
+// starting at Initial (default the Client interval) and capped at Max (zero means no cap).
+type ExponentialPolling struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// Delay Returns Initial * Factor^attempt, capped at Max.
+func (p ExponentialPolling) Delay(attempt int) time.Duration {
+	factor := p.Factor
+	if factor == 0 {
+		factor = 2
+	}
+
+	initial := p.Initial
+	if initial <= 0 {
+		initial = time.Second * common.POOLING_INTERVAL
+	}
+
+	delay := float64(initial) * math.Pow(max(factor, 1), float64(attempt))
+	if p.Max > 0 && delay > float64(p.Max) {
+		return p.Max
+	}
+
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
+
+// JitteredPolling Randomizes the sleep of another strategy by up to Fraction (0 to 1) in both
+// directions, so many clients don't request the status endpoints in lockstep. A nil Strategy
+// uses the Client interval.
+type JitteredPolling struct {
+	Strategy PollStrategy
+	Fraction float64
+}
+
+// Delay Returns the strategy delay randomized by the fraction.
+func (p JitteredPolling) Delay(attempt int) time.Duration {
+	strategy := p.Strategy
+	if strategy == nil {
+		strategy = FixedPolling{Interval: time.Second * common.POOLING_INTERVAL}
+	}
+
+	delay := strategy.Delay(attempt)
+	fraction := min(max(p.Fraction, 0), 1)
+
+	return time.Duration(float64(delay) * (1 + fraction*(2*rand.Float64()-1)))
+}
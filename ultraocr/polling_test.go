@@ -0,0 +1,168 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestPollStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy PollStrategy
+		attempt  int
+		want     time.Duration
+	}{
+		{
+			name:     "fixed",
+			strategy: FixedPolling{Interval: 3 * time.Second},
+			attempt:  5,
+			want:     3 * time.Second,
+		},
+		{
+			name:     "exponential first attempt",
+			strategy: ExponentialPolling{Initial: time.Second},
+			attempt:  0,
+			want:     time.Second,
+		},
+		{
+			name:     "exponential default factor",
+			strategy: ExponentialPolling{Initial: time.Second},
+			attempt:  3,
+			want:     8 * time.Second,
+		},
+		{
+			name:     "exponential custom factor",
+			strategy: ExponentialPolling{Initial: time.Second, Factor: 1.5},
+			attempt:  2,
+			want:     2250 * time.Millisecond,
+		},
+		{
+			name:     "exponential capped",
+			strategy: ExponentialPolling{Initial: time.Second, Max: 10 * time.Second},
+			attempt:  100,
+			want:     10 * time.Second,
+		},
+		{
+			name:     "exponential zero value",
+			strategy: ExponentialPolling{},
+			attempt:  2,
+			want:     4 * time.Second,
+		},
+		{
+			name:     "exponential factor under 1",
+			strategy: ExponentialPolling{Initial: time.Second, Factor: 0.5},
+			attempt:  3,
+			want:     time.Second,
+		},
+		{
+			name:     "jitter without strategy",
+			strategy: JitteredPolling{},
+			attempt:  1,
+			want:     time.Second,
+		},
+		{
+			name:     "jitter without fraction",
+			strategy: JitteredPolling{Strategy: FixedPolling{Interval: time.Second}},
+			attempt:  1,
+			want:     time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.Delay(tt.attempt); got != tt.want {
+				t.Errorf("PollStrategy.Delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollStrategyDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy PollStrategy
+		want     PollStrategy
+		wantErr  error
+	}{
+		{name: "nil", want: FixedPolling{Interval: 3 * time.Second}},
+		{name: "exponential zero value", strategy: ExponentialPolling{}, want: ExponentialPolling{Initial: 3 * time.Second}},
+		{
+			name:     "jitter zero value",
+			strategy: JitteredPolling{Fraction: 0.2},
+			want:     JitteredPolling{Strategy: FixedPolling{Interval: 3 * time.Second}, Fraction: 0.2},
+		},
+		{
+			name:     "jitter exponential zero value",
+			strategy: JitteredPolling{Strategy: ExponentialPolling{Max: time.Minute}},
+			want:     JitteredPolling{Strategy: ExponentialPolling{Initial: 3 * time.Second, Max: time.Minute}},
+		},
+		{name: "exponential factor under 1", strategy: ExponentialPolling{Factor: 0.5}, wantErr: common.ErrInvalidPollStrategy},
+		{
+			name:     "jitter exponential factor under 1",
+			strategy: JitteredPolling{Strategy: ExponentialPolling{Factor: 0.9}},
+			wantErr:  common.ErrInvalidPollStrategy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			client.SetInterval(3)
+
+			err := client.SetPollStrategy(tt.strategy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.SetPollStrategy() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := client.NewPoller("job", "ID").Strategy; got != tt.want {
+				t.Errorf("client.NewPoller() strategy = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJitteredPolling(t *testing.T) {
+	strategy := JitteredPolling{Strategy: FixedPolling{Interval: 10 * time.Second}, Fraction: 0.2}
+
+	distinct := map[time.Duration]bool{}
+	for i := 0; i < 100; i++ {
+		got := strategy.Delay(i)
+		if got < 8*time.Second || got > 12*time.Second {
+			t.Fatalf("JitteredPolling.Delay() = %v, want between 8s and 12s", got)
+		}
+		distinct[got] = true
+	}
+
+	if len(distinct) < 2 {
+		t.Errorf("JitteredPolling.Delay() returned the same delay on every attempt")
+	}
+}
+
+func TestWaitForJobDonePollStrategy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ultraocrtest.NewAutoClock(start)
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 100 * time.Second
+	client := newFakeClient(clock, api)
+	client.SetTimeout(int(time.Hour.Seconds()))
+	_ = client.SetPollStrategy(ExponentialPolling{Initial: time.Second, Max: 60 * time.Second})
+
+	jobID := api.AddJob("rg", common.STATUS_DONE)
+	before := api.RequestCount()
+
+	_, err := client.WaitForJobDone(context.Background(), jobID, jobID)
+	if err != nil {
+		t.Fatalf("client.WaitForJobDone() error = %v", err)
+	}
+
+	// one authentication and polls at 0, 1, 3, 7, 15, 31, 63 and 127 seconds
+	if got := api.RequestCount() - before; got != 9 {
+		t.Errorf("client.WaitForJobDone() requests = %v, want 9", got)
+	}
+}
@@ -0,0 +1,231 @@
+package ultraocr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetPreprocess Enables re-encoding oversized JPEG and PNG documents before submissions,
+// so large photos fit on the upload and single step limits (Default disabled).
+func (client *Client) SetPreprocess(preprocess Preprocess) {
+	client.Preprocess = &preprocess
+}
+
+func (p Preprocess) maxBytes() int64 {
+	if p.MaxBytes <= 0 {
+		return common.DEFAULT_PREPROCESS_BYTES
+	}
+
+	return p.MaxBytes
+}
+
+func (p Preprocess) quality() int {
+	if p.Quality <= 0 {
+		return common.DEFAULT_JPEG_QUALITY
+	}
+
+	return p.Quality
+}
+
+func (p Preprocess) minQuality() int {
+	if p.MinQuality <= 0 {
+		return min(common.MIN_JPEG_QUALITY, p.quality())
+	}
+
+	return min(p.MinQuality, p.quality())
+}
+
+// PreprocessImage Re-encodes a JPEG or PNG image as JPEG to fit on the preprocess limits.
+// Other documents and images already fitting are returned unchanged.
+func PreprocessImage(data []byte, preprocess Preprocess) ([]byte, error) {
+	out, _, err := preprocessImage(data, preprocess)
+	return out, err
+}
+
+// preprocessImage Re-encodes the image if needed, reporting if it was changed.
+func preprocessImage(data []byte, preprocess Preprocess) ([]byte, bool, error) {
+	contentType := DetectContentType(data)
+	if contentType != common.CONTENT_TYPE_JPEG && contentType != common.CONTENT_TYPE_PNG {
+		return data, false, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", common.ErrPreprocess, err)
+	}
+
+	maxBytes := preprocess.maxBytes()
+	longest := max(config.Width, config.Height)
+	oversized := preprocess.MaxDimension > 0 && longest > preprocess.MaxDimension
+	if int64(len(data)) <= maxBytes && !oversized {
+		return data, false, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", common.ErrPreprocess, err)
+	}
+
+	img := flattenImage(decoded)
+	if oversized {
+		img = downscaleImage(img, preprocess.MaxDimension)
+	}
+
+	var buf bytes.Buffer
+	for {
+		for quality := preprocess.quality(); ; quality = max(quality-10, preprocess.minQuality()) {
+			buf.Reset()
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+			if err != nil {
+				return nil, false, fmt.Errorf("%w: %w", common.ErrPreprocess, err)
+			}
+
+			if int64(buf.Len()) <= maxBytes {
+				return buf.Bytes(), true, nil
+			}
+
+			if quality == preprocess.minQuality() {
+				break
+			}
+		}
+
+		longest = max(img.Bounds().Dx(), img.Bounds().Dy())
+		if longest <= common.MIN_PREPROCESS_SIDE {
+			return nil, false, fmt.Errorf("%w: still over %d bytes at %d pixels", common.ErrPreprocess, maxBytes, longest)
+		}
+
+		img = downscaleImage(img, max(longest*3/4, common.MIN_PREPROCESS_SIDE))
+	}
+}
+
+// flattenImage Draws the image over a white background, as JPEG has no transparency.
+func flattenImage(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+
+	return flat
+}
+
+// downscaleImage Resizes the image to the longest side keeping its aspect ratio, averaging
+// the source pixels covered by each new pixel.
+func downscaleImage(img *image.RGBA, longest int) *image.RGBA {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	scale := float64(longest) / float64(max(width, height))
+	newWidth := max(1, int(float64(width)*scale))
+	newHeight := max(1, int(float64(height)*scale))
+
+	scaled := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		y0 := y * height / newHeight
+		y1 := max((y+1)*height/newHeight, y0+1)
+
+		for x := 0; x < newWidth; x++ {
+			x0 := x * width / newWidth
+			x1 := max((x+1)*width/newWidth, x0+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				offset := img.PixOffset(img.Rect.Min.X+x0, img.Rect.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(img.Pix[offset+c])
+					}
+					offset += 4
+				}
+			}
+
+			pixels := (y1 - y0) * (x1 - x0)
+			offset := scaled.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				scaled.Pix[offset+c] = uint8(sum[c] / pixels)
+			}
+		}
+	}
+
+	return scaled
+}
+
+// preprocessSource Re-encodes an image source when the Client preprocessing is enabled, keeping
+// base64 sources encoded. A positive limit lowers the preprocess MaxBytes.
+func (client *Client) preprocessSource(src Source, encoded bool, limit int64) (Source, error) {
+	if client.Preprocess == nil || src == nil {
+		return src, nil
+	}
+
+	preprocess := *client.Preprocess
+	if limit > 0 {
+		preprocess.MaxBytes = min(preprocess.maxBytes(), limit)
+	}
+
+	size := src.Size()
+	if encoded {
+		size = size / 4 * 3
+	}
+
+	if size >= 0 && size <= preprocess.maxBytes() && preprocess.MaxDimension <= 0 {
+		return src, nil
+	}
+
+	body, err := src.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	var r io.Reader = body
+	if encoded {
+		r = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", common.ErrPreprocess, src.Name(), err)
+	}
+
+	out, changed, err := preprocessImage(data, preprocess)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src.Name(), err)
+	}
+
+	if !changed {
+		return src, nil
+	}
+
+	if encoded {
+		return StringSource(src.Name(), base64.StdEncoding.EncodeToString(out)), nil
+	}
+
+	return BytesSource(src.Name(), out), nil
+}
+
+// preprocessBase64 Re-encodes base64 image data when the Client preprocessing is enabled.
+func (client *Client) preprocessBase64(data string, limit int64) (string, error) {
+	src, err := client.preprocessSource(StringSource("base64", data), true, limit)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := src.Open()
+	if err != nil {
+		return "", err
+	}
+
+	defer body.Close()
+
+	out, err := io.ReadAll(body)
+	if err != nil {
+		return "", common.ErrReadFile
+	}
+
+	return string(out), nil
+}
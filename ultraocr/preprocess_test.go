@@ -0,0 +1,100 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// newNoisePNG Creates a PNG that doesn't compress, like a large photo.
+func newNoisePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestPreprocessImage(t *testing.T) {
+	photo := newNoisePNG(t, 400, 300)
+
+	tests := []struct {
+		name          string
+		data          []byte
+		preprocess    Preprocess
+		wantUnchanged bool
+		wantMaxSide   int
+		wantErr       error
+	}{
+		{name: "not an image", data: pdfData, preprocess: Preprocess{MaxBytes: 1}, wantUnchanged: true},
+		{name: "fits", data: photo, wantUnchanged: true},
+		{name: "over max bytes", data: photo, preprocess: Preprocess{MaxBytes: 60 << 10}, wantMaxSide: 400},
+		{name: "over max dimension", data: photo, preprocess: Preprocess{MaxDimension: 100}, wantMaxSide: 100},
+		{name: "cannot fit", data: photo, preprocess: Preprocess{MaxBytes: 100}, wantErr: common.ErrPreprocess},
+		{name: "invalid image", data: pngData, preprocess: Preprocess{MaxBytes: 1}, wantErr: common.ErrPreprocess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PreprocessImage(tt.data, tt.preprocess)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PreprocessImage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if tt.wantUnchanged {
+				if !bytes.Equal(got, tt.data) {
+					t.Errorf("PreprocessImage() changed the data")
+				}
+				return
+			}
+
+			if int64(len(got)) > tt.preprocess.maxBytes() {
+				t.Errorf("PreprocessImage() = %d bytes, want at most %d", len(got), tt.preprocess.maxBytes())
+			}
+			config, format, err := image.DecodeConfig(bytes.NewReader(got))
+			if err != nil || format != "jpeg" {
+				t.Fatalf("PreprocessImage() = %s image, error %v, want jpeg", format, err)
+			}
+			if side := max(config.Width, config.Height); side > tt.wantMaxSide {
+				t.Errorf("PreprocessImage() longest side = %d, want at most %d", side, tt.wantMaxSide)
+			}
+		})
+	}
+}
+
+func TestSubmitPreprocess(t *testing.T) {
+	recorder := &submissionRecorder{}
+	client := recorder.client()
+	client.SetPreprocess(Preprocess{MaxDimension: 100})
+
+	_, err := client.SubmitJob(context.Background(), JobRequest{
+		Service:  ServiceRG,
+		Document: BytesSource("photo.png", newNoisePNG(t, 400, 300)),
+	})
+	if err != nil {
+		t.Fatalf("client.SubmitJob() error = %v", err)
+	}
+	if got := DetectContentType([]byte(recorder.uploads["/document"])); got != common.CONTENT_TYPE_JPEG {
+		t.Errorf("uploaded document type = %q, want %q", got, common.CONTENT_TYPE_JPEG)
+	}
+}
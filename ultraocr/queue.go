@@ -0,0 +1,539 @@
+package ultraocr
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// JobQueue Durable queue of jobs saved on the Client Store, e.g. a FileStore. Each job progress
+// (pending, uploading, submitted, done or failed) is saved after every step, so a restarted
+// worker resumes the queue where it stopped: created jobs are uploaded or polled again instead
+// of sent twice. Failed steps are retried up to MaxAttempts times (default 3), sleeping Backoff
+// (default 1s) doubled on each retry.
+//
+// Run leases each job it processes for Lease (default 30 minutes, renewed on every step), so
+// concurrent Run calls skip it. The lease of a crashed worker expires, letting another Run resume
+// the job, so it should be longer than the longest step, like a wait. The Store has no atomic
+// compare and swap: the lease is exclusive between Run calls of a JobQueue, while separate
+// processes sharing a Store may still race on a job freed at the same time.
+type JobQueue struct {
+	Client      *Client
+	Name        string
+	MaxAttempts int
+	Backoff     time.Duration
+	Lease       time.Duration
+
+	mu sync.Mutex
+}
+
+// NewJobQueue Creates a queue saving its jobs on the Client Store under the given name.
+func NewJobQueue(client *Client, name string) *JobQueue {
+	return &JobQueue{
+		Client: client,
+		Name:   name,
+	}
+}
+
+func (q *JobQueue) maxAttempts() int {
+	if q.MaxAttempts <= 0 {
+		return common.DEFAULT_QUEUE_ATTEMPTS
+	}
+
+	return q.MaxAttempts
+}
+
+func (q *JobQueue) backoff(attempt int) time.Duration {
+	backoff := q.Backoff
+	if backoff <= 0 {
+		backoff = common.DEFAULT_QUEUE_BACKOFF
+	}
+
+	return backoff << (attempt - 1)
+}
+
+func (q *JobQueue) lease() time.Duration {
+	if q.Lease <= 0 {
+		return common.DEFAULT_QUEUE_LEASE
+	}
+
+	return q.Lease
+}
+
+// indexKey Returns the Store key of the queued job IDs.
+func (q *JobQueue) indexKey() string {
+	return "job-queue/" + q.Name
+}
+
+// jobKey Returns the Store key of a queued job.
+func (q *JobQueue) jobKey(ID string) string {
+	return "job-queue/" + q.Name + "/" + ID
+}
+
+// Add Saves a job on the queue as pending, to be sent by Run.
+func (q *JobQueue) Add(ctx context.Context, req QueuedJobRequest) (QueuedJob, error) {
+	err := req.Options.Validate()
+	if err != nil {
+		return QueuedJob{}, err
+	}
+
+	ID, err := newQueuedJobID()
+	if err != nil {
+		return QueuedJob{}, err
+	}
+
+	now := q.Client.clock().Now()
+	job := QueuedJob{
+		ID:        ID,
+		Request:   req,
+		State:     common.QUEUE_STATE_PENDING,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = q.save(ctx, &job)
+	if err != nil {
+		return QueuedJob{}, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	IDs, err := q.index(ctx)
+	if err != nil {
+		return QueuedJob{}, err
+	}
+
+	return job, q.saveIndex(ctx, append(IDs, ID))
+}
+
+// Get Returns a queued job, failing with ErrQueuedJobNotFound if it is not on the queue.
+func (q *JobQueue) Get(ctx context.Context, ID string) (QueuedJob, error) {
+	store, err := q.store()
+	if err != nil {
+		return QueuedJob{}, err
+	}
+
+	data, ok, err := store.Get(ctx, q.jobKey(ID))
+	if err != nil {
+		return QueuedJob{}, storeError(err)
+	}
+
+	if !ok {
+		return QueuedJob{}, fmt.Errorf("%w: %s", common.ErrQueuedJobNotFound, ID)
+	}
+
+	var job QueuedJob
+	err = json.Unmarshal(data, &job)
+	if err != nil {
+		return QueuedJob{}, fmt.Errorf("%w: %w: %s", common.ErrStore, err, ID)
+	}
+
+	return job, nil
+}
+
+// Jobs Returns the queued jobs in the order they were added.
+func (q *JobQueue) Jobs(ctx context.Context) ([]QueuedJob, error) {
+	q.mu.Lock()
+	IDs, err := q.index(ctx)
+	q.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]QueuedJob, 0, len(IDs))
+	for _, ID := range IDs {
+		job, err := q.Get(ctx, ID)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Remove Deletes a job from the queue, e.g. once its result is handled.
+func (q *JobQueue) Remove(ctx context.Context, ID string) error {
+	store, err := q.store()
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	IDs, err := q.index(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = q.saveIndex(ctx, slices.DeleteFunc(IDs, func(queued string) bool { return queued == ID }))
+	if err != nil {
+		return err
+	}
+
+	return storeError(store.Delete(ctx, q.jobKey(ID)))
+}
+
+// Retry Resets the attempts of a failed job, so Run tries it again from its last step.
+func (q *JobQueue) Retry(ctx context.Context, ID string) (QueuedJob, error) {
+	job, err := q.Get(ctx, ID)
+	if err != nil {
+		return QueuedJob{}, err
+	}
+
+	if job.State != common.QUEUE_STATE_FAILED {
+		return job, nil
+	}
+
+	switch {
+	case job.JobID == "":
+		job.State = common.QUEUE_STATE_PENDING
+	case job.URLs != nil:
+		job.State = common.QUEUE_STATE_UPLOADING
+	default:
+		job.State = common.QUEUE_STATE_SUBMITTED
+	}
+
+	job.Attempts = 0
+	job.Error = ""
+	return job, q.save(ctx, &job)
+}
+
+// Run Processes the unfinished jobs of the queue, up to the Client JobsConcurrency at a time,
+// until each one is done or failed. Jobs leased by another Run are skipped. Returns the first
+// Store error, or the context error if it is done first, keeping the jobs progress to be resumed
+// by the next Run.
+func (q *JobQueue) Run(ctx context.Context) error {
+	jobs, err := q.Jobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := newQueuedJobID()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	slots := make(chan struct{}, q.Client.jobsConcurrency())
+
+jobs:
+	for _, job := range jobs {
+		if job.State == common.QUEUE_STATE_DONE || job.State == common.QUEUE_STATE_FAILED {
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break jobs
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			job, claimed, err := q.claim(ctx, job.ID, owner)
+			if err == nil && claimed {
+				err = q.process(ctx, job)
+			}
+
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// claim Leases the job to the owner, failing to claim it if it is finished or leased by another owner.
+func (q *JobQueue) claim(ctx context.Context, ID, owner string) (QueuedJob, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, err := q.Get(ctx, ID)
+	if errors.Is(err, common.ErrQueuedJobNotFound) {
+		// removed since the Run started
+		return job, false, nil
+	}
+
+	if err != nil {
+		return job, false, err
+	}
+
+	if job.State == common.QUEUE_STATE_DONE || job.State == common.QUEUE_STATE_FAILED {
+		return job, false, nil
+	}
+
+	if job.Owner != "" && job.Owner != owner && q.Client.clock().Now().Before(job.LeaseExpiresAt) {
+		return job, false, nil
+	}
+
+	job.Owner = owner
+	return job, true, q.save(ctx, &job)
+}
+
+// release Frees the job lease, so the next Run doesn't wait for it to expire.
+func (q *JobQueue) release(ctx context.Context, job *QueuedJob) error {
+	job.Owner = ""
+	job.LeaseExpiresAt = time.Time{}
+	return q.save(context.WithoutCancel(ctx), job)
+}
+
+// process Runs the job steps until it is done or failed, sleeping the backoff between failed attempts.
+// Only Store and context errors are returned, the steps errors are saved on the job.
+func (q *JobQueue) process(ctx context.Context, job QueuedJob) error {
+	for {
+		var err error
+		switch job.State {
+		case common.QUEUE_STATE_PENDING:
+			err = q.submit(ctx, &job)
+		case common.QUEUE_STATE_UPLOADING:
+			err = q.upload(ctx, &job)
+		case common.QUEUE_STATE_SUBMITTED:
+			err = q.wait(ctx, &job)
+		default:
+			return q.release(ctx, &job)
+		}
+
+		if ctx.Err() != nil {
+			_ = q.release(ctx, &job)
+			return ctx.Err()
+		}
+
+		if err != nil {
+			err = q.fail(ctx, &job, err)
+			if err != nil {
+				return err
+			}
+
+			if job.State == common.QUEUE_STATE_FAILED {
+				continue
+			}
+
+			select {
+			case <-q.Client.clock().After(q.backoff(job.Attempts)):
+			case <-ctx.Done():
+				_ = q.release(ctx, &job)
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// submit Creates the job, saving it as uploading before the uploads, and as submitted after them.
+// Jobs without an idempotency key use their Store key, so a job created right before a crash
+// is not duplicated when sent again.
+func (q *JobQueue) submit(ctx context.Context, job *QueuedJob) error {
+	req := job.Request
+	if req.Options.IdempotencyKey == "" {
+		req.Options.IdempotencyKey = q.jobKey(job.ID)
+	}
+
+	created, err := q.Client.submitJobSigned(ctx, req.jobRequest(), req.Options.Params(), func(response SignedUrlResponse) error {
+		job.State = common.QUEUE_STATE_UPLOADING
+		job.JobID = response.Id
+		job.StatusURL = response.StatusURL
+		job.URLs = response.URLs
+		return q.save(ctx, job)
+	})
+	if errors.Is(err, common.ErrAuditFailed) {
+		// the job was created, sending it again would duplicate it
+		created.Warnings = append(created.Warnings, err)
+	} else if err != nil {
+		return err
+	}
+
+	return q.submitted(ctx, job, created)
+}
+
+// upload Uploads the files of a job created before a restart to its signed URLs again.
+func (q *JobQueue) upload(ctx context.Context, job *QueuedJob) error {
+	req := job.Request
+	_, uploads, err := q.Client.prepareJob(ctx, req.jobRequest(), req.Options.Params())
+	if err != nil {
+		return err
+	}
+
+	created, err := q.Client.uploadJob(ctx, SignedUrlResponse{Id: job.JobID, StatusURL: job.StatusURL, URLs: job.URLs}, uploads)
+	if err != nil {
+		return err
+	}
+
+	return q.submitted(ctx, job, created)
+}
+
+// submitted Saves the job as submitted, with the warnings of the skipped uploads.
+func (q *JobQueue) submitted(ctx context.Context, job *QueuedJob, created CreatedResponse) error {
+	job.State = common.QUEUE_STATE_SUBMITTED
+	job.URLs = nil
+	job.Attempts = 0
+	job.Error = ""
+	if len(created.Warnings) > 0 {
+		job.Error = created.Warnings[0].Error()
+	}
+
+	return q.save(ctx, job)
+}
+
+// wait Polls the job until it finishes, saving it as done with its result.
+func (q *JobQueue) wait(ctx context.Context, job *QueuedJob) error {
+	result, err := q.Client.WaitForJob(ctx, job.JobID)
+	if err != nil {
+		return err
+	}
+
+	job.State = common.QUEUE_STATE_DONE
+	job.Result = &result
+	return q.save(ctx, job)
+}
+
+// fail Records a failed step, marking the job as failed after MaxAttempts.
+// A Store error on the step is returned instead, as the progress can't be saved.
+func (q *JobQueue) fail(ctx context.Context, job *QueuedJob, stepErr error) error {
+	if errors.Is(stepErr, common.ErrStore) {
+		return stepErr
+	}
+
+	job.Attempts++
+	job.Error = stepErr.Error()
+	if job.Attempts >= q.maxAttempts() {
+		job.State = common.QUEUE_STATE_FAILED
+	}
+
+	return q.save(ctx, job)
+}
+
+// save Saves the job on the Store, renewing its lease if it has an owner.
+func (q *JobQueue) save(ctx context.Context, job *QueuedJob) error {
+	store, err := q.store()
+	if err != nil {
+		return err
+	}
+
+	job.UpdatedAt = q.Client.clock().Now()
+	if job.Owner != "" {
+		job.LeaseExpiresAt = job.UpdatedAt.Add(q.lease())
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return storeError(store.Put(ctx, q.jobKey(job.ID), data))
+}
+
+// index Returns the queued job IDs. Must be called holding the queue lock.
+func (q *JobQueue) index(ctx context.Context) ([]string, error) {
+	store, err := q.store()
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok, err := store.Get(ctx, q.indexKey())
+	if err != nil || !ok {
+		return nil, storeError(err)
+	}
+
+	var IDs []string
+	err = json.Unmarshal(data, &IDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", common.ErrStore, err, q.indexKey())
+	}
+
+	return IDs, nil
+}
+
+// saveIndex Saves the queued job IDs. Must be called holding the queue lock.
+func (q *JobQueue) saveIndex(ctx context.Context, IDs []string) error {
+	store, err := q.store()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(IDs)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return storeError(store.Put(ctx, q.indexKey(), data))
+}
+
+// store Returns the Client Store, failing if none is configured.
+func (q *JobQueue) store() (Store, error) {
+	if q.Client.Store == nil {
+		return nil, fmt.Errorf("%w: no store configured", common.ErrStore)
+	}
+
+	return q.Client.Store, nil
+}
+
+// storeError Wraps the errors of custom stores as ErrStore, so they abort the queue processing.
+func storeError(err error) error {
+	if err == nil || errors.Is(err, common.ErrStore) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", common.ErrStore, err)
+}
+
+// jobRequest Returns the job request reading the files from their paths.
+func (req QueuedJobRequest) jobRequest() JobRequest {
+	job := JobRequest{
+		Service:  req.Service,
+		Document: FileSource(req.FilePath),
+		Metadata: req.Metadata,
+		Options:  req.Options,
+	}
+
+	if req.FacematchFilePath != "" {
+		job.Selfie = FileSource(req.FacematchFilePath)
+	}
+
+	if req.ExtraFilePath != "" {
+		job.ExtraDocument = FileSource(req.ExtraFilePath)
+	}
+
+	return job
+}
+
+// newQueuedJobID Returns a random ID for a queued job.
+func newQueuedJobID() (string, error) {
+	buf := make([]byte, 8)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
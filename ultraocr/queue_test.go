@@ -0,0 +1,258 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestJobQueue(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "doc.pdf")
+	_ = os.WriteFile(doc, []byte("%PDF-1.4"), 0o600)
+	store := NewFileStore(filepath.Join(dir, "store"))
+
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 5 * time.Second
+
+	var failUploads atomic.Bool
+	var created atomic.Int32
+	newQueue := func() *JobQueue {
+		client := newFakeClient(clock, api)
+		client.SetStore(store)
+		client.SetHttpClient(&ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.Method == http.MethodPut && failUploads.Load() {
+					return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+				}
+				if req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/ocr/job/") {
+					created.Add(1)
+					if req.URL.Query().Get(common.KEY_IDEMPOTENCY_KEY) == "" {
+						t.Errorf("JobQueue submission without idempotency key")
+					}
+				}
+				return api.Do(req)
+			},
+		})
+		return NewJobQueue(&client, "test")
+	}
+
+	queue := newQueue()
+	queue.MaxAttempts = 1
+	requests := []QueuedJobRequest{
+		{Service: ServiceRG, FilePath: doc},
+		{Service: ServiceRG, FilePath: doc},
+		{Service: ServiceRG, FilePath: doc, Options: JobOptions{Facematch: true}},
+	}
+	for _, req := range requests {
+		_, err := queue.Add(context.Background(), req)
+		if err != nil {
+			t.Fatalf("JobQueue.Add() error = %v", err)
+		}
+	}
+
+	failUploads.Store(true)
+	err := queue.Run(context.Background())
+	if err != nil {
+		t.Fatalf("JobQueue.Run() error = %v", err)
+	}
+
+	jobs, err := queue.Jobs(context.Background())
+	if err != nil {
+		t.Fatalf("JobQueue.Jobs() error = %v", err)
+	}
+	for i, job := range jobs {
+		if job.State != common.QUEUE_STATE_FAILED || job.Attempts != 1 || job.Error == "" {
+			t.Errorf("JobQueue job %d = %+v, want failed", i, job)
+		}
+		if wantCreated := i < 2; (job.JobID != "") != wantCreated {
+			t.Errorf("JobQueue job %d id = %q, want created %v", i, job.JobID, wantCreated)
+		}
+	}
+
+	// A restarted worker retries the uploads of the created jobs without sending them again.
+	failUploads.Store(false)
+	restarted := newQueue()
+	for _, job := range jobs[:2] {
+		retried, err := restarted.Retry(context.Background(), job.ID)
+		if err != nil || retried.State != common.QUEUE_STATE_UPLOADING {
+			t.Fatalf("JobQueue.Retry() = %+v, %v, want uploading", retried, err)
+		}
+	}
+
+	err = restarted.Run(context.Background())
+	if err != nil {
+		t.Fatalf("JobQueue.Run() error = %v", err)
+	}
+
+	jobs, _ = restarted.Jobs(context.Background())
+	for i, job := range jobs[:2] {
+		if job.State != common.QUEUE_STATE_DONE || job.Result == nil || job.Result.JobID != job.JobID {
+			t.Errorf("JobQueue job %d = %+v, want done", i, job)
+		}
+	}
+	if jobs[2].State != common.QUEUE_STATE_FAILED {
+		t.Errorf("JobQueue missing selfie job state = %s, want failed", jobs[2].State)
+	}
+	if created.Load() != 2 {
+		t.Errorf("JobQueue created %d jobs, want 2", created.Load())
+	}
+
+	err = restarted.Remove(context.Background(), jobs[2].ID)
+	if err != nil {
+		t.Fatalf("JobQueue.Remove() error = %v", err)
+	}
+	_, err = restarted.Get(context.Background(), jobs[2].ID)
+	if !errors.Is(err, common.ErrQueuedJobNotFound) {
+		t.Errorf("JobQueue.Get() removed job error = %v, want %v", err, common.ErrQueuedJobNotFound)
+	}
+	jobs, _ = restarted.Jobs(context.Background())
+	if len(jobs) != 2 {
+		t.Errorf("JobQueue.Jobs() after Remove = %d jobs, want 2", len(jobs))
+	}
+
+	client := NewClient()
+	_, err = NewJobQueue(&client, "test").Add(context.Background(), QueuedJobRequest{Service: ServiceRG, FilePath: doc})
+	if !errors.Is(err, common.ErrStore) {
+		t.Errorf("JobQueue.Add() without store error = %v, want %v", err, common.ErrStore)
+	}
+}
+
+func TestJobQueueBackoff(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "doc.pdf")
+	_ = os.WriteFile(doc, []byte("%PDF-1.4"), 0o600)
+
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+
+	client := newFakeClient(clock, api)
+	client.SetStore(NewFileStore(filepath.Join(dir, "store")))
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPut {
+				return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+			}
+			return api.Do(req)
+		},
+	})
+
+	queue := NewJobQueue(&client, "test")
+	queue.Backoff = time.Second
+	_, err := queue.Add(context.Background(), QueuedJobRequest{Service: ServiceRG, FilePath: doc})
+	if err != nil {
+		t.Fatalf("JobQueue.Add() error = %v", err)
+	}
+
+	start := clock.Now()
+	err = queue.Run(context.Background())
+	if err != nil {
+		t.Fatalf("JobQueue.Run() error = %v", err)
+	}
+
+	// 1s and 2s between the 3 attempts
+	if elapsed := clock.Now().Sub(start); elapsed != 3*time.Second {
+		t.Errorf("JobQueue.Run() slept %v, want 3s", elapsed)
+	}
+
+	jobs, _ := queue.Jobs(context.Background())
+	if len(jobs) != 1 || jobs[0].State != common.QUEUE_STATE_FAILED || jobs[0].Attempts != 3 || jobs[0].Owner != "" {
+		t.Errorf("JobQueue jobs = %+v, want failed after 3 attempts and released", jobs)
+	}
+}
+
+func TestJobQueueLease(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "doc.pdf")
+	_ = os.WriteFile(doc, []byte("%PDF-1.4"), 0o600)
+
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+
+	var created atomic.Int32
+	client := newFakeClient(clock, api)
+	client.SetStore(NewFileStore(filepath.Join(dir, "store")))
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/ocr/job/") {
+				created.Add(1)
+			}
+			return api.Do(req)
+		},
+	})
+
+	queue := NewJobQueue(&client, "test")
+	queue.Lease = time.Minute
+
+	// A job leased by a crashed worker is skipped until its lease expires.
+	leased, err := queue.Add(context.Background(), QueuedJobRequest{Service: ServiceRG, FilePath: doc})
+	if err != nil {
+		t.Fatalf("JobQueue.Add() error = %v", err)
+	}
+	leased.Owner = "crashed"
+	err = queue.save(context.Background(), &leased)
+	if err != nil {
+		t.Fatalf("JobQueue.save() error = %v", err)
+	}
+
+	err = queue.Run(context.Background())
+	if err != nil {
+		t.Fatalf("JobQueue.Run() error = %v", err)
+	}
+	leased, _ = queue.Get(context.Background(), leased.ID)
+	if leased.State != common.QUEUE_STATE_PENDING || created.Load() != 0 {
+		t.Errorf("JobQueue leased job = %+v, created %d jobs, want skipped", leased, created.Load())
+	}
+
+	clock.Advance(2 * time.Minute)
+	err = queue.Run(context.Background())
+	if err != nil {
+		t.Fatalf("JobQueue.Run() error = %v", err)
+	}
+	leased, _ = queue.Get(context.Background(), leased.ID)
+	if leased.State != common.QUEUE_STATE_DONE || leased.Owner != "" || created.Load() != 1 {
+		t.Errorf("JobQueue expired lease job = %+v, created %d jobs, want done and released", leased, created.Load())
+	}
+
+	// Concurrent Run calls don't process the same jobs.
+	for range 5 {
+		_, err := queue.Add(context.Background(), QueuedJobRequest{Service: ServiceRG, FilePath: doc})
+		if err != nil {
+			t.Fatalf("JobQueue.Add() error = %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := queue.Run(context.Background())
+			if err != nil {
+				t.Errorf("JobQueue.Run() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	jobs, _ := queue.Jobs(context.Background())
+	for i, job := range jobs {
+		if job.State != common.QUEUE_STATE_DONE {
+			t.Errorf("JobQueue job %d = %+v, want done", i, job)
+		}
+	}
+	if created.Load() != 6 {
+		t.Errorf("JobQueue created %d jobs, want 6", created.Load())
+	}
+}
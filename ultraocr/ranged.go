@@ -0,0 +1,186 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// objectStorageSignatures Query params of presigned S3, Google Cloud Storage and Azure Blob URLs.
+// These storages keep only the last PUT to an URL, ignoring Content-Range, so ranged parts would
+// overwrite each other.
+var objectStorageSignatures = []string{"x-amz-signature", "awsaccesskeyid", "x-goog-signature", "googleaccessid", "sig"}
+
+// SetRangedUpload Changes the uploads of large documents to concurrent ranged uploads (Default disabled, a single PUT).
+// Only for upload backends assembling the Content-Range PUTs of an URL, like a custom upload gateway:
+// presigned S3, Google Cloud Storage and Azure Blob URLs are still uploaded with a single PUT.
+func (client *Client) SetRangedUpload(ranged RangedUpload) {
+	client.RangedUpload = &ranged
+}
+
+// rangedUploadSupported Returns if the URL may receive ranged parts, being false for object storage presigned URLs.
+func rangedUploadSupported(rawURL string) bool {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for key := range parsed.Query() {
+		if slices.Contains(objectStorageSignatures, strings.ToLower(key)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *RangedUpload) partSize() int64 {
+	if r.PartSize <= 0 {
+		return common.DEFAULT_PART_SIZE
+	}
+
+	return r.PartSize
+}
+
+func (r *RangedUpload) parallelism() int {
+	if r.Parallelism <= 0 {
+		return common.DEFAULT_PART_PARALLELISM
+	}
+
+	return r.Parallelism
+}
+
+// uploadRanged Uploads the source parts concurrently, canceling the other parts on the first failure.
+// With UploadRetry, each part is retried alone, so an unstable link doesn't restart the whole upload.
+func (client *Client) uploadRanged(ctx context.Context, url string, src Source, size int64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	partSize := client.RangedUpload.partSize()
+	contentType := uploadContentType(src)
+	slots := make(chan struct{}, client.RangedUpload.parallelism())
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+parts:
+	for offset := int64(0); offset < size; offset += partSize {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break parts
+		}
+
+		wg.Add(1)
+		go func(offset, length int64) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := client.retryUpload(ctx, url, false, func(url string) error {
+				return client.uploadPart(ctx, url, src, offset, length, size, contentType)
+			})
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(offset, min(partSize, size-offset))
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// uploadPart Uploads length bytes of the source from offset, with its Content-Range header,
+// the Content-Type of the whole source, if known, and the part checksum with UploadChecksums.
+func (client *Client) uploadPart(ctx context.Context, url string, src Source, offset, length, size int64, contentType string) error {
+	var checksum Checksum
+	if client.UploadChecksums {
+		part, err := openRange(src, offset, length)
+		if err != nil {
+			return err
+		}
+
+		checksum, err = readerChecksum(part)
+		part.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	body, err := openRange(src, offset, length)
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
+	if err != nil {
+		return common.ErrMountingRequest
+	}
+
+	req.ContentLength = length
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, size))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	checksum.setHeaders(req, client.UploadChecksumHeaders)
+
+	res, err := client.send(req, false)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrDoingRequest, err)
+	}
+
+	defer res.Body.Close()
+
+	if redirected(res) {
+		return newRedirectError(res, stripQuery(url))
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return client.newAPIError(res, stripQuery(url))
+	}
+
+	return nil
+}
+
+// openRange Opens a part of the source, skipping the content before it on sources not implementing RangeSource.
+func openRange(src Source, offset, length int64) (io.ReadCloser, error) {
+	if ranged, ok := src.(RangeSource); ok {
+		return ranged.OpenRange(offset, length)
+	}
+
+	body, err := src.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.CopyN(io.Discard, body, offset)
+	if err != nil {
+		body.Close()
+		return nil, common.ErrReadFile
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(body, length), body}, nil
+}
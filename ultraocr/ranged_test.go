@@ -0,0 +1,259 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+// plainSource A source without OpenRange.
+type plainSource struct {
+	data []byte
+}
+
+func (s plainSource) Name() string {
+	return "plain"
+}
+
+func (s plainSource) Size() int64 {
+	return int64(len(s.data))
+}
+
+func (s plainSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.data)), nil
+}
+
+func TestRangedUpload(t *testing.T) {
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	path := filepath.Join(t.TempDir(), "batch.pdf")
+	err := os.WriteFile(path, data, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		src       Source
+		ranged    RangedUpload
+		failPart  string
+		wantParts int
+		wantErr   error
+	}{
+		{
+			name:      "bytes",
+			src:       BytesSource("batch.pdf", data),
+			ranged:    RangedUpload{PartSize: 300, Parallelism: 2},
+			wantParts: 4,
+		},
+		{
+			name:      "file",
+			src:       FileSource(path),
+			ranged:    RangedUpload{PartSize: 100},
+			wantParts: 10,
+		},
+		{
+			name:      "source without ranges",
+			src:       plainSource{data: data},
+			ranged:    RangedUpload{PartSize: 400, Parallelism: 8},
+			wantParts: 3,
+		},
+		{
+			name:      "small document",
+			src:       BytesSource("batch.pdf", data),
+			ranged:    RangedUpload{},
+			wantParts: 1,
+		},
+		{
+			name:     "failed part",
+			src:      BytesSource("batch.pdf", data),
+			ranged:   RangedUpload{PartSize: 300, Parallelism: 1},
+			failPart: "bytes 300-599/1000",
+			wantErr:  common.ErrInvalidStatusCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			uploaded := make([]byte, len(data))
+			parts := 0
+
+			client := NewClient()
+			client.SetRangedUpload(tt.ranged)
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					contentRange := req.Header.Get("Content-Range")
+					if tt.failPart != "" && contentRange == tt.failPart {
+						return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+					}
+
+					body, _ := io.ReadAll(req.Body)
+					start, end, total := 0, len(body)-1, len(data)
+					if contentRange != "" {
+						_, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total)
+						if err != nil || int(req.ContentLength) != len(body) {
+							t.Errorf("Content-Range = %v, ContentLength = %v, error = %v", contentRange, req.ContentLength, err)
+						}
+					}
+					if total != len(data) || end-start+1 != len(body) {
+						t.Errorf("Content-Range = %v, body = %v bytes", contentRange, len(body))
+					}
+
+					mu.Lock()
+					copy(uploaded[start:], body)
+					parts += 1
+					mu.Unlock()
+
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			})
+
+			err := client.UploadSource(context.Background(), "https://bucket.example.com/batch?signature=123", tt.src)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.UploadSource() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if parts != tt.wantParts {
+				t.Errorf("client.UploadSource() parts = %v, want %v", parts, tt.wantParts)
+			}
+			if !bytes.Equal(uploaded, data) {
+				t.Errorf("client.UploadSource() uploaded data differs")
+			}
+		})
+	}
+}
+
+func TestRangedUploadObjectStorage(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 1000)
+
+	tests := []struct {
+		name      string
+		url       string
+		wantParts int
+	}{
+		{name: "upload gateway", url: "https://uploads.example.com/batch?signature=123", wantParts: 4},
+		{name: "s3", url: "https://bucket.s3.amazonaws.com/batch?X-Amz-Credential=key&X-Amz-Signature=123", wantParts: 1},
+		{name: "s3 v2", url: "https://bucket.s3.amazonaws.com/batch?AWSAccessKeyId=key&Signature=123", wantParts: 1},
+		{name: "gcs", url: "https://storage.googleapis.com/bucket/batch?X-Goog-Signature=123", wantParts: 1},
+		{name: "azure", url: "https://account.blob.core.windows.net/container/batch?sv=2022-11-02&sig=123", wantParts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			parts := 0
+
+			client := NewClient()
+			client.SetRangedUpload(RangedUpload{PartSize: 300})
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					if tt.wantParts == 1 && (req.Header.Get("Content-Range") != "" || !bytes.Equal(body, data)) {
+						t.Errorf("upload Content-Range = %q, body = %v bytes, want a single PUT", req.Header.Get("Content-Range"), len(body))
+					}
+
+					mu.Lock()
+					parts += 1
+					mu.Unlock()
+
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			})
+
+			err := client.UploadSource(context.Background(), tt.url, BytesSource("batch.pdf", data))
+			if err != nil {
+				t.Fatalf("client.UploadSource() error = %v", err)
+			}
+			if parts != tt.wantParts {
+				t.Errorf("client.UploadSource() parts = %v, want %v", parts, tt.wantParts)
+			}
+		})
+	}
+}
+
+func TestRangedUploadRetry(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 1000)
+
+	tests := []struct {
+		name         string
+		parallelism  int
+		failStatus   int
+		wantErr      error
+		wantAttempts map[string]int
+	}{
+		{
+			name:        "transient part failure",
+			parallelism: 2,
+			failStatus:  503,
+			wantAttempts: map[string]int{
+				"bytes 0-299/1000":   1,
+				"bytes 300-599/1000": 2,
+				"bytes 600-899/1000": 1,
+				"bytes 900-999/1000": 1,
+			},
+		},
+		{
+			name:         "forbidden part not renewed",
+			parallelism:  1,
+			failStatus:   403,
+			wantErr:      common.ErrInvalidStatusCode,
+			wantAttempts: map[string]int{"bytes 0-299/1000": 1, "bytes 300-599/1000": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			attempts := map[string]int{}
+
+			client := NewClient()
+			client.Clock = ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			client.SetRangedUpload(RangedUpload{PartSize: 300, Parallelism: tt.parallelism})
+			client.SetUploadRetry(UploadRetry{
+				RenewURL: func(ctx context.Context, url string) (string, error) {
+					t.Errorf("RenewURL() called on a ranged upload part")
+					return url, nil
+				},
+			})
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					_, _ = io.Copy(io.Discard, req.Body)
+					contentRange := req.Header.Get("Content-Range")
+
+					mu.Lock()
+					attempts[contentRange] += 1
+					attempt := attempts[contentRange]
+					mu.Unlock()
+
+					if contentRange == "bytes 300-599/1000" && attempt == 1 {
+						return &http.Response{StatusCode: tt.failStatus, Body: http.NoBody}, nil
+					}
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			})
+
+			err := client.UploadSource(context.Background(), "https://bucket.example.com/upload", BytesSource("batch.pdf", data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.UploadSource() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(attempts, tt.wantAttempts) {
+				t.Errorf("part attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package ultraocr
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetRateLimitRetry Changes the retries of API requests answered with 429 Too Many Requests
+// (Default 3 attempts, see RateLimitRetry).
+func (client *Client) SetRateLimitRetry(retry RateLimitRetry) {
+	client.RateLimitRetry = &retry
+}
+
+// rateLimitRetry Returns the Client RateLimitRetry with the defaults applied.
+func (client *Client) rateLimitRetry() RateLimitRetry {
+	var retry RateLimitRetry
+	if client.RateLimitRetry != nil {
+		retry = *client.RateLimitRetry
+	}
+
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = common.DEFAULT_RATE_ATTEMPTS
+	}
+
+	if retry.Backoff <= 0 {
+		retry.Backoff = common.DEFAULT_RATE_BACKOFF
+	}
+
+	if retry.MaxDelay <= 0 {
+		retry.MaxDelay = common.DEFAULT_RATE_MAX_DELAY
+	}
+
+	return retry
+}
+
+// delay Returns how long to wait before retrying a 429 response on attempt (starting at 1), and the
+// delay asked by the API, if any.
+func (r RateLimitRetry) delay(res *http.Response, attempt int, now time.Time) (delay, retryAfter time.Duration) {
+	retryAfter, ok := parseRetryAfter(res.Header.Get(common.HEADER_RETRY_AFTER), now)
+	if ok {
+		return retryAfter, retryAfter
+	}
+
+	return min(r.Backoff<<(attempt-1), r.MaxDelay), 0
+}
+
+// parseRetryAfter Parses a Retry-After header, in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// rateLimitError Returns the RateLimitError of a 429 response, reading and closing its body.
+func (client *Client) rateLimitError(res *http.Response, url string, attempts int, retryAfter time.Duration) *common.RateLimitError {
+	defer res.Body.Close()
+
+	return &common.RateLimitError{
+		Attempts:   attempts,
+		RetryAfter: retryAfter,
+		Err:        client.newAPIError(res, url),
+	}
+}
@@ -0,0 +1,198 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestRateLimitRetry(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		retry          *RateLimitRetry
+		retryAfter     []string
+		statuses       []int
+		wantDelays     []time.Duration
+		wantErr        bool
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:       "retry after seconds",
+			retryAfter: []string{"2"},
+			statuses:   []int{429, 200},
+			wantDelays: []time.Duration{2 * time.Second},
+		},
+		{
+			name:       "retry after date",
+			retryAfter: []string{start.Add(5 * time.Second).Format(http.TimeFormat)},
+			statuses:   []int{429, 200},
+			wantDelays: []time.Duration{5 * time.Second},
+		},
+		{
+			name:       "backoff without retry after",
+			statuses:   []int{429, 429, 200},
+			wantDelays: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:       "exhausted",
+			statuses:   []int{429, 429, 429, 200},
+			wantDelays: []time.Duration{time.Second, 2 * time.Second},
+			wantErr:    true,
+		},
+		{
+			name:           "retry after over the max delay",
+			retryAfter:     []string{"3600"},
+			statuses:       []int{429, 200},
+			wantDelays:     []time.Duration{},
+			wantErr:        true,
+			wantRetryAfter: time.Hour,
+		},
+		{
+			name:       "custom",
+			retry:      &RateLimitRetry{MaxAttempts: 4, Backoff: time.Minute, MaxDelay: 90 * time.Second},
+			statuses:   []int{429, 429, 429, 200},
+			wantDelays: []time.Duration{time.Minute, 90 * time.Second, 90 * time.Second},
+		},
+		{
+			name:       "disabled",
+			retry:      &RateLimitRetry{MaxAttempts: 1},
+			statuses:   []int{429, 200},
+			wantDelays: []time.Duration{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			requestIDs := map[string]bool{}
+
+			client := NewClient()
+			client.Token = "token"
+			client.ExpiresAt = start.Add(time.Hour)
+			client.RateLimitRetry = tt.retry
+			client.SetClock(ultraocrtest.NewAutoClock(start))
+			client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				if string(body) != `{"a":"1"}` {
+					t.Errorf("request body = %s, want the body on every attempt", body)
+				}
+
+				requestIDs[req.Header.Get(common.HEADER_REQUEST_ID)] = true
+
+				res := &http.Response{StatusCode: tt.statuses[attempts], Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`))}
+				if attempts < len(tt.retryAfter) {
+					res.Header.Set(common.HEADER_RETRY_AFTER, tt.retryAfter[attempts])
+				}
+
+				attempts++
+				return res, nil
+			}})
+
+			delays := []time.Duration{}
+			client.SetHooks(Hooks{OnRetry: func(event RetryEvent) {
+				if event.Phase != common.PHASE_REQUEST {
+					t.Errorf("RetryEvent.Phase = %v, want %v", event.Phase, common.PHASE_REQUEST)
+				}
+
+				delays = append(delays, event.Delay)
+			}})
+
+			response, err := client.post(context.Background(), "https://ultraocr.apps.nuveo.ai/v2/ocr/job/send/rg", map[string]string{"a": "1"}, nil)
+			if !reflect.DeepEqual(delays, tt.wantDelays) {
+				t.Errorf("retry delays = %v, want %v", delays, tt.wantDelays)
+			}
+
+			if len(requestIDs) != 1 {
+				t.Errorf("request IDs = %v, want the same on every attempt", requestIDs)
+			}
+
+			if !tt.wantErr {
+				if err != nil || response.status != 200 {
+					t.Fatalf("client.post() = %v, %v, want 200", response.status, err)
+				}
+				return
+			}
+
+			var rateErr *common.RateLimitError
+			var apiErr *common.APIError
+			if !errors.Is(err, common.ErrRateLimited) || !errors.Is(err, common.ErrInvalidStatusCode) ||
+				!errors.As(err, &rateErr) || !errors.As(err, &apiErr) || apiErr.StatusCode != 429 {
+				t.Fatalf("client.post() error = %v, want a RateLimitError", err)
+			}
+
+			if rateErr.Attempts != attempts || rateErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RateLimitError = %+v, want %d attempts and retry after %v", rateErr, attempts, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{value: "", wantOk: false},
+		{value: "10", want: 10 * time.Second, wantOk: true},
+		{value: " 0 ", want: 0, wantOk: true},
+		{value: "-5", want: 0, wantOk: true},
+		{value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute, wantOk: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOk: true},
+		{value: "soon", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parseRetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRateLimitRetryRefreshesToken(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+	client.RateLimitRetry = &RateLimitRetry{MaxAttempts: 2, Backoff: time.Second, MaxDelay: 2 * time.Hour}
+
+	tokens := []string{}
+	client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "/token") {
+			return api.Do(req)
+		}
+
+		tokens = append(tokens, req.Header.Get("Authorization"))
+		if len(tokens) == 1 {
+			// the token expires during the sleep
+			header := http.Header{common.HEADER_RETRY_AFTER: []string{"3700"}}
+			return &http.Response{StatusCode: 429, Header: header, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		}
+
+		return api.Do(req)
+	}})
+
+	_, err := client.GetJobResult(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+	if len(tokens) != 2 {
+		t.Fatalf("client.GetJobResult() sent %d requests, error = %v, want 2", len(tokens), err)
+	}
+
+	if tokens[0] == tokens[1] || api.AuthCount() != 2 {
+		t.Errorf("retry Authorization = %v, %d auths, want a refreshed token", tokens, api.AuthCount())
+	}
+}
@@ -0,0 +1,122 @@
+package ultraocr
+
+import (
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// bearerToken Returns the token sent on the request Authorization header.
+func bearerToken(req *http.Request) string {
+	token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return token
+}
+
+// bearerPattern Matches bearer tokens, like on Authorization headers echoed on messages.
+var bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+
+// SetRedaction Changes the extra headers, body fields and patterns masked on the debug entries and
+// errors, besides the credentials, bearer tokens and signed URLs query strings, always masked.
+func (client *Client) SetRedaction(redaction Redaction) {
+	client.Redaction = &redaction
+}
+
+// redaction Returns the Client Redaction, or an empty one.
+func (client *Client) redaction() Redaction {
+	if client.Redaction == nil {
+		return Redaction{}
+	}
+
+	return *client.Redaction
+}
+
+// redactString Masks the secrets, the bearer tokens and the Redaction patterns on s.
+// The secrets are given by the caller, as the Client ones can only be read holding the auth lock.
+func (client *Client) redactString(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, common.REDACTED)
+		}
+	}
+
+	s = bearerPattern.ReplaceAllString(s, "${1}"+common.REDACTED)
+	for _, pattern := range client.redaction().Patterns {
+		s = pattern.ReplaceAllString(s, common.REDACTED)
+	}
+
+	return s
+}
+
+// redactHeaders Returns a copy of the headers with the sensitive and Redaction headers masked.
+func (client *Client) redactHeaders(header http.Header) http.Header {
+	return sanitizeHeaders(header, client.redaction().Headers...)
+}
+
+// redactBody Returns the body with the secrets and the sensitive and Redaction fields masked,
+// truncated to DEBUG_BODY_LIMIT.
+func (client *Client) redactBody(data []byte, secrets ...string) string {
+	return sanitizeBody([]byte(client.redactString(string(data), secrets...)), client.redaction().Fields...)
+}
+
+// redactError Returns the request error without the URL query string, which has the signature of
+// signed URLs, and with its message masked by redactString. The original error stays on its chain,
+// so errors.Is and errors.As keep working.
+func (client *Client) redactError(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	if urlErr, ok := err.(*neturl.Error); ok {
+		redacted := *urlErr
+		redacted.URL = stripQuery(redacted.URL)
+		err = &redacted
+	}
+
+	msg := err.Error()
+	if redacted := client.redactString(msg, secrets...); redacted != msg {
+		return &redactedError{msg: redacted, err: err}
+	}
+
+	return err
+}
+
+// redactedError Error with a masked message.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// String Describes the Client without its secret and token, so logging it doesn't leak them.
+func (client Client) String() string {
+	secret, token := "", ""
+	if client.ClientSecret != "" {
+		secret = common.REDACTED
+	}
+
+	if client.Token != "" {
+		token = common.REDACTED
+	}
+
+	return fmt.Sprintf(
+		"ultraocr.Client{BaseURL: %q, AuthBaseURL: %q, ClientID: %q, ClientSecret: %q, Token: %q, ExpiresAt: %s}",
+		client.BaseURL, client.AuthBaseURL, client.ClientID, secret, token, client.ExpiresAt.Format(time.RFC3339),
+	)
+}
+
+// GoString Describes the Client like String, for the %#v verb.
+func (client Client) GoString() string {
+	return client.String()
+}
@@ -0,0 +1,213 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestRedactError(t *testing.T) {
+	errTransport := errors.New("connection reset")
+
+	tests := []struct {
+		name      string
+		redaction *Redaction
+		err       error
+		leaked    []string
+		want      string
+	}{
+		{
+			name:   "signed URL",
+			err:    &url.Error{Op: "Put", URL: "https://bucket.s3.amazonaws.com/doc?X-Amz-Signature=sig", Err: errTransport},
+			leaked: []string{"X-Amz-Signature", "sig"},
+			want:   `failed to request: Put "https://bucket.s3.amazonaws.com/doc": connection reset`,
+		},
+		{
+			name:   "bearer token",
+			err:    fmt.Errorf("proxy rejected Authorization: Bearer abc.def: %w", errTransport),
+			leaked: []string{"abc.def"},
+			want:   "failed to request: proxy rejected Authorization: Bearer REDACTED: connection reset",
+		},
+		{
+			name:      "pattern",
+			redaction: &Redaction{Patterns: []*regexp.Regexp{regexp.MustCompile(`key-\d+`)}},
+			err:       fmt.Errorf("invalid key-123: %w", errTransport),
+			leaked:    []string{"key-123"},
+			want:      "failed to request: invalid REDACTED: connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			client.Redaction = tt.redaction
+			client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+				return nil, tt.err
+			}})
+
+			err := client.UploadFileBase64(context.Background(), "https://bucket.s3.amazonaws.com/doc?X-Amz-Signature=sig", "aGVsbG8=")
+			if !errors.Is(err, errTransport) {
+				t.Fatalf("client.UploadFileBase64() error = %v, want %v", err, errTransport)
+			}
+
+			for _, leaked := range tt.leaked {
+				if strings.Contains(err.Error(), leaked) {
+					t.Errorf("client.UploadFileBase64() error = %v, leaks %q", err, leaked)
+				}
+			}
+
+			if err.Error() != tt.want {
+				t.Errorf("client.UploadFileBase64() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactCredentials(t *testing.T) {
+	errTransport := errors.New("connection reset")
+
+	tests := []struct {
+		name     string
+		failAuth bool
+		leaked   string
+	}{
+		{name: "client secret", failAuth: true, leaked: "secret-value"},
+		{name: "token", leaked: "token-value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			client.SetAutoRefresh("id", "secret-value", 60)
+			client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "/token") && !tt.failAuth {
+					return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"token": "token-value"}`))}, nil
+				}
+
+				return nil, fmt.Errorf("echo secret-value token-value: %w", errTransport)
+			}})
+
+			_, err := client.GetJobResult(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+			if !errors.Is(err, errTransport) {
+				t.Fatalf("client.GetJobResult() error = %v, want %v", err, errTransport)
+			}
+
+			if strings.Contains(err.Error(), tt.leaked) {
+				t.Errorf("client.GetJobResult() error = %v, leaks %q", err, tt.leaked)
+			}
+		})
+	}
+}
+
+func TestRedactAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		path   string
+		leaked string
+	}{
+		{
+			name:   "auth error echoing the request",
+			status: http.StatusBadRequest,
+			body:   `{"message":"invalid ClientSecret secret-value","ClientID":"id","ClientSecret":"secret-value"}`,
+			path:   "/token",
+			leaked: "secret-value",
+		},
+		{
+			name:   "request error echoing the token",
+			status: http.StatusUnauthorized,
+			body:   `invalid token token-value`,
+			path:   "/ocr/job/result/",
+			leaked: "token-value",
+		},
+		{
+			name:   "rate limit echoing the token",
+			status: http.StatusTooManyRequests,
+			body:   `{"message":"too many requests for Bearer token-value"}`,
+			path:   "/ocr/job/result/",
+			leaked: "token-value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			client.SetAutoRefresh("id", "secret-value", 60)
+			client.SetRateLimitRetry(RateLimitRetry{MaxAttempts: 1})
+			client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+				if strings.Contains(req.URL.Path, tt.path) {
+					return &http.Response{StatusCode: tt.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(tt.body))}, nil
+				}
+
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"token": "token-value"}`))}, nil
+			}})
+
+			_, err := client.GetJobResult(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+			var apiErr *common.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("client.GetJobResult() error = %v, want an APIError %d", err, tt.status)
+			}
+
+			if strings.Contains(err.Error(), tt.leaked) || strings.Contains(string(apiErr.Body), tt.leaked) {
+				t.Errorf("client.GetJobResult() error = %v, leaks %q", err, tt.leaked)
+			}
+		})
+	}
+}
+
+func TestRedactDebug(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+	client.SetDebugBuffer(2)
+	client.SetRedaction(Redaction{
+		Headers:  []string{common.HEADER_REQUEST_ID},
+		Fields:   []string{"metadata"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`https://[^"]*`)},
+	})
+
+	_, err := client.SendJobSingleStep(context.Background(), "rg", "aGVsbG8=", "", "", map[string]any{"cpf": "123"}, nil)
+	if err != nil {
+		t.Fatalf("client.SendJobSingleStep() error = %v", err)
+	}
+
+	entries := client.DebugSnapshot()
+	send := entries[len(entries)-1]
+	if got := send.RequestHeaders.Get(common.HEADER_REQUEST_ID); got != common.REDACTED {
+		t.Errorf("X-Request-Id = %q, want %q", got, common.REDACTED)
+	}
+
+	if strings.Contains(send.RequestBody, "123") {
+		t.Errorf("request body = %s, want the metadata redacted", send.RequestBody)
+	}
+
+	if strings.Contains(send.ResponseBody, "https://") || !strings.Contains(send.ResponseBody, common.REDACTED) {
+		t.Errorf("response body = %s, want the URLs redacted", send.ResponseBody)
+	}
+}
+
+func TestClientString(t *testing.T) {
+	client := NewClient()
+	client.SetAutoRefresh("id", "secret-value", 60)
+	client.Token = "token-value"
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		for _, value := range []any{client, &client} {
+			got := fmt.Sprintf(format, value)
+			if strings.Contains(got, "secret-value") || strings.Contains(got, "token-value") || !strings.Contains(got, `ClientID: "id"`) {
+				t.Errorf("fmt.Sprintf(%q, %T) = %s, want the credentials redacted", format, value, got)
+			}
+		}
+	}
+}
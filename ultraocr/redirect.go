@@ -0,0 +1,59 @@
+package ultraocr
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// maxRedirects Limits the redirects followed on a request, as the net/http default policy.
+const maxRedirects = 10
+
+// httpClient Returns the Client HTTP client, applying the SDK redirect policy to net/http clients
+// without their own policy.
+func (client *Client) httpClient() HttpClient {
+	httpClient, ok := client.HttpClient.(*http.Client)
+	if !ok || httpClient.CheckRedirect != nil {
+		return client.HttpClient
+	}
+
+	withPolicy := *httpClient
+	withPolicy.CheckRedirect = redirectPolicy
+	return &withPolicy
+}
+
+// redirectPolicy Follows redirects only for requests without body (GET and HEAD), so documents,
+// credentials and metadata are never re-sent to another URL. The redirect response is returned
+// instead, failing as an unexpected status code. The authorization is dropped across hosts.
+func redirectPolicy(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	original := via[0]
+	if original.Method != http.MethodGet && original.Method != http.MethodHead {
+		return http.ErrUseLastResponse
+	}
+
+	if !strings.EqualFold(req.URL.Host, original.URL.Host) {
+		req.Header.Del("Authorization")
+	}
+
+	return nil
+}
+
+// redirected Checks if the response is a redirect.
+func redirected(res *http.Response) bool {
+	return res.StatusCode >= http.StatusMultipleChoices && res.StatusCode < http.StatusBadRequest
+}
+
+// newRedirectError Creates an error for a redirected upload.
+func newRedirectError(res *http.Response, url string) error {
+	return &common.RedirectError{
+		StatusCode: res.StatusCode,
+		Location:   stripQuery(res.Header.Get("Location")),
+		RequestURL: url,
+	}
+}
@@ -0,0 +1,127 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// redirectingTransport Redirects every request to api.example.com to other.example.com with the status code,
+// recording the requests received by other.example.com.
+func redirectingTransport(status int, received *[]*http.Request) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "api.example.com" {
+			location := "https://other.example.com" + req.URL.Path
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Location": {location + "?signature=secret"}},
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		}
+
+		*received = append(*received, req)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"job_ksuid":"123","status":"done"}`)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestRedirectPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		call         func(client *Client) error
+		wantErr      error
+		wantReceived int
+	}{
+		{
+			name:   "get followed",
+			status: http.StatusFound,
+			call: func(client *Client) error {
+				_, err := client.GetJobResult(context.Background(), "0ujsszwN8NRY24YaXiTIE2VWDTS", "0ujsszwN8NRY24YaXiTIE2VWDTS")
+				return err
+			},
+			wantReceived: 1,
+		},
+		{
+			name:   "post not followed",
+			status: http.StatusTemporaryRedirect,
+			call: func(client *Client) error {
+				_, err := client.SendJobSingleStep(context.Background(), "rg", "ZmlsZQ==", "", "", nil, nil)
+				return err
+			},
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:   "upload not followed",
+			status: http.StatusTemporaryRedirect,
+			call: func(client *Client) error {
+				return client.UploadFileBase64(context.Background(), "https://api.example.com/upload?signature=secret", "ZmlsZQ==")
+			},
+			wantErr: common.ErrUploadRedirected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []*http.Request
+			client := NewClient()
+			client.SetHttpClient(&http.Client{Transport: redirectingTransport(tt.status, &received)})
+			client.BaseURL = "https://api.example.com/v2"
+			client.Token = "123"
+			client.ExpiresAt = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			err := tt.call(&client)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if len(received) != tt.wantReceived {
+				t.Fatalf("redirected requests = %v, want %v", len(received), tt.wantReceived)
+			}
+			for _, req := range received {
+				if req.Header.Get("Authorization") != "" {
+					t.Errorf("redirected request Authorization = %v, want none", req.Header.Get("Authorization"))
+				}
+			}
+		})
+	}
+}
+
+func TestUploadRedirectError(t *testing.T) {
+	var received []*http.Request
+	client := NewClient()
+	client.SetHttpClient(&http.Client{Transport: redirectingTransport(http.StatusMovedPermanently, &received)})
+
+	err := client.UploadFileBase64(context.Background(), "https://api.example.com/upload?signature=secret", "ZmlsZQ==")
+
+	var redirectErr *common.RedirectError
+	if !errors.As(err, &redirectErr) {
+		t.Fatalf("client.UploadFileBase64() error = %v, want a RedirectError", err)
+	}
+	if redirectErr.StatusCode != http.StatusMovedPermanently {
+		t.Errorf("RedirectError.StatusCode = %v, want %v", redirectErr.StatusCode, http.StatusMovedPermanently)
+	}
+	if redirectErr.Location != "https://other.example.com/upload" {
+		t.Errorf("RedirectError.Location = %v, want the location without query", redirectErr.Location)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("RedirectError.Error() = %v, want no signature", err)
+	}
+	if !errors.Is(err, common.ErrInvalidStatusCode) {
+		t.Errorf("client.UploadFileBase64() error = %v, want %v", err, common.ErrInvalidStatusCode)
+	}
+}
@@ -0,0 +1,64 @@
+package ultraocr
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// requestIDKey Context key of the request ID.
+type requestIDKey struct{}
+
+// WithRequestID Returns a context whose requests are sent with the given X-Request-Id, e.g. the ID
+// of the incoming request being handled, to correlate them on support tickets.
+func WithRequestID(ctx context.Context, ID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, ID)
+}
+
+// RequestIDFromContext Returns the request ID set with WithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	ID, ok := ctx.Value(requestIDKey{}).(string)
+	return ID, ok && ID != ""
+}
+
+// setRequestID Sets the X-Request-Id header of the request, if not set, with the context request ID
+// or a new random one.
+func setRequestID(req *http.Request) {
+	if req.Header.Get(common.HEADER_REQUEST_ID) != "" {
+		return
+	}
+
+	ID, ok := RequestIDFromContext(req.Context())
+	if !ok {
+		ID = newRequestID()
+	}
+
+	req.Header.Set(common.HEADER_REQUEST_ID, ID)
+}
+
+// newRequestID Returns a random request ID.
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+// responseRequestID Returns the request ID of the response, the one sent when the server doesn't
+// answer with it.
+func responseRequestID(res *http.Response) string {
+	ID := res.Header.Get(common.HEADER_REQUEST_ID)
+	if ID == "" && res.Request != nil {
+		ID = res.Request.Header.Get(common.HEADER_REQUEST_ID)
+	}
+
+	return ID
+}
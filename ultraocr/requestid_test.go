@@ -0,0 +1,75 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctxID         string
+		serverID      string
+		wantErrID     string
+		wantGenerated bool
+	}{
+		{name: "context", ctxID: "ticket-1", wantErrID: "ticket-1"},
+		{name: "generated", wantGenerated: true},
+		{name: "server answer", ctxID: "ticket-1", serverID: "server-1", wantErrID: "server-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := []string{}
+			client := Client{
+				BaseURL:   "https://api.example.com",
+				Token:     "123",
+				ExpiresAt: time.Now().Add(time.Hour),
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						sent = append(sent, req.Header.Get(common.HEADER_REQUEST_ID))
+						header := http.Header{}
+						if tt.serverID != "" {
+							header.Set(common.HEADER_REQUEST_ID, tt.serverID)
+						}
+						return &http.Response{StatusCode: 500, Header: header, Body: http.NoBody}, nil
+					},
+				},
+			}
+
+			ctx := context.Background()
+			if tt.ctxID != "" {
+				ctx = WithRequestID(ctx, tt.ctxID)
+			}
+
+			_, err := client.GetBatchStatus(ctx, "000000000000000000000000001")
+			_, err2 := client.GetBatchStatus(ctx, "000000000000000000000000001")
+
+			var apiErr, apiErr2 *common.APIError
+			if !errors.As(err, &apiErr) || !errors.As(err2, &apiErr2) {
+				t.Fatalf("client.GetBatchStatus() error = %v, want APIError", err)
+			}
+
+			if tt.wantGenerated {
+				if sent[0] == "" || sent[0] == sent[1] {
+					t.Errorf("sent request IDs = %q, want distinct generated IDs", sent)
+				}
+				if apiErr.RequestID != sent[0] {
+					t.Errorf("APIError.RequestID = %q, want sent %q", apiErr.RequestID, sent[0])
+				}
+				return
+			}
+
+			if sent[0] != tt.ctxID || sent[1] != tt.ctxID {
+				t.Errorf("sent request IDs = %q, want %q", sent, tt.ctxID)
+			}
+			if apiErr.RequestID != tt.wantErrID {
+				t.Errorf("APIError.RequestID = %q, want %q", apiErr.RequestID, tt.wantErrID)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package results
+
+// CNH Result of the CNH (Brazilian driver license) service.
+type CNH struct {
+	Name               Field `json:"Name"`
+	CPF                Field `json:"CPF"`
+	RG                 Field `json:"RG"`
+	BirthDate          Field `json:"BirthDate"`
+	FatherName         Field `json:"FatherName"`
+	MotherName         Field `json:"MotherName"`
+	RegistrationNumber Field `json:"RegistrationNumber"`
+	Category           Field `json:"Category"`
+	FirstLicenseDate   Field `json:"FirstLicenseDate"`
+	IssueDate          Field `json:"IssueDate"`
+	ExpirationDate     Field `json:"ExpirationDate"`
+	IssuePlace         Field `json:"IssuePlace"`
+}
+
+// RG Result of the RG (Brazilian identity card) service.
+type RG struct {
+	Name       Field `json:"Name"`
+	RG         Field `json:"RG"`
+	CPF        Field `json:"CPF"`
+	BirthDate  Field `json:"BirthDate"`
+	BirthPlace Field `json:"BirthPlace"`
+	FatherName Field `json:"FatherName"`
+	MotherName Field `json:"MotherName"`
+	IssueDate  Field `json:"IssueDate"`
+	IssuePlace Field `json:"IssuePlace"`
+}
+
+// CPF Result of the CPF (Brazilian taxpayer registry card) service.
+type CPF struct {
+	Name      Field `json:"Name"`
+	CPF       Field `json:"CPF"`
+	BirthDate Field `json:"BirthDate"`
+}
+
+// ProofOfAddress Result of proof of address services, like utility bills.
+type ProofOfAddress struct {
+	Name         Field `json:"Name"`
+	Street       Field `json:"Street"`
+	Number       Field `json:"Number"`
+	Complement   Field `json:"Complement"`
+	Neighborhood Field `json:"Neighborhood"`
+	City         Field `json:"City"`
+	State        Field `json:"State"`
+	ZipCode      Field `json:"ZipCode"`
+	IssueDate    Field `json:"IssueDate"`
+}
+
+// Invoice Result of the invoice (Brazilian NF-e) service.
+type Invoice struct {
+	Number            Field `json:"Number"`
+	AccessKey         Field `json:"AccessKey"`
+	IssueDate         Field `json:"IssueDate"`
+	IssuerName        Field `json:"IssuerName"`
+	IssuerCNPJ        Field `json:"IssuerCNPJ"`
+	RecipientName     Field `json:"RecipientName"`
+	RecipientDocument Field `json:"RecipientDocument"`
+	TotalValue        Field `json:"TotalValue"`
+}
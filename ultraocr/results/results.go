@@ -0,0 +1,166 @@
+// Package results implements typed structs for the results of common services, decoded from
+// the JobResultResponse document, so fields are read without navigating maps.
+package results
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/brazil"
+)
+
+// Results errors.
+var (
+	ErrNoDocument      = errors.New("result has no document")
+	ErrInvalidDocument = errors.New("invalid result document")
+)
+
+// Field A document field read by the OCR, with its confidence (0 to 100).
+// Values that are not strings are kept as their JSON text.
+type Field struct {
+	Value string  `json:"value"`
+	Conf  float64 `json:"conf"`
+}
+
+// UnmarshalJSON Decodes a field, accepting any value type.
+func (f *Field) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Value json.RawMessage `json:"value"`
+		Conf  float64         `json:"conf"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	f.Conf = raw.Conf
+	f.Value = ""
+	if len(raw.Value) == 0 || bytes.Equal(raw.Value, []byte("null")) {
+		return nil
+	}
+
+	if raw.Value[0] == '"' {
+		return json.Unmarshal(raw.Value, &f.Value)
+	}
+
+	f.Value = string(raw.Value)
+	return nil
+}
+
+// Empty Checks if the field has no value.
+func (f Field) Empty() bool {
+	return strings.TrimSpace(f.Value) == ""
+}
+
+// Digits Returns only the digits of the value, like on CPF and CNPJ fields.
+func (f Field) Digits() string {
+	return brazil.OnlyDigits(f.Value)
+}
+
+// Date Parses a dd/mm/yyyy value, failing with brazil.ErrInvalidDate otherwise.
+func (f Field) Date() (time.Time, error) {
+	return brazil.ParseDate(f.Value)
+}
+
+// Page A page of a result document, with its typed Data and every field found on it by name,
+// including fields not on the typed Data.
+type Page[T any] struct {
+	Page   int              `json:"Page"`
+	Data   T                `json:"Data"`
+	Fields map[string]Field `json:"-"`
+}
+
+// UnmarshalJSON Decodes the page, its typed Data and the fields found on it.
+func (p *Page[T]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Page int             `json:"Page"`
+		Data json.RawMessage `json:"Data"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	p.Page = raw.Page
+	p.Fields = map[string]Field{}
+	if len(raw.Data) == 0 || bytes.Equal(raw.Data, []byte("null")) {
+		return nil
+	}
+
+	err = json.Unmarshal(raw.Data, &p.Data)
+	if err != nil {
+		return err
+	}
+
+	var values map[string]json.RawMessage
+	err = json.Unmarshal(raw.Data, &values)
+	if err != nil {
+		return nil
+	}
+
+	for name, value := range values {
+		var object map[string]json.RawMessage
+		if json.Unmarshal(value, &object) != nil {
+			continue
+		}
+
+		if _, ok := object["value"]; !ok {
+			continue
+		}
+
+		var field Field
+		if json.Unmarshal(value, &field) == nil {
+			p.Fields[name] = field
+		}
+	}
+
+	return nil
+}
+
+// Decode Decodes the pages of a result document as T, like Decode[results.CNH](result).
+// Fails with ErrNoDocument if the result has no document, or ErrInvalidDocument if it doesn't match T.
+func Decode[T any](result ultraocr.JobResultResponse) ([]Page[T], error) {
+	if result.Result.Document == nil {
+		return nil, ErrNoDocument
+	}
+
+	data, err := json.Marshal(result.Result.Document)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		data = append(append([]byte("["), trimmed...), ']')
+	}
+
+	var pages []Page[T]
+	err = json.Unmarshal(data, &pages)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	if len(pages) == 0 {
+		return nil, ErrNoDocument
+	}
+
+	return pages, nil
+}
+
+// First Decodes the first page of a result document as T, see Decode.
+func First[T any](result ultraocr.JobResultResponse) (T, error) {
+	pages, err := Decode[T](result)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return pages[0].Data, nil
+}
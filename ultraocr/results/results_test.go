@@ -0,0 +1,136 @@
+package results
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		document  string
+		wantPages int
+		wantErr   error
+	}{
+		{
+			name: "pages",
+			document: `[
+				{"Page":1,"Data":{"Name":{"conf":99,"value":"Maria"},"CPF":{"conf":98,"value":"529.982.247-25"},"BirthDate":{"conf":97,"value":"31/12/1990"},"Extra":{"conf":50,"value":10}}},
+				{"Page":2,"Data":{"Name":{"conf":90,"value":"Joao"}}}
+			]`,
+			wantPages: 2,
+		},
+		{
+			name:      "single page",
+			document:  `{"Page":1,"Data":{"Name":{"conf":99,"value":"Maria"}}}`,
+			wantPages: 1,
+		},
+		{
+			name:     "empty",
+			document: `[]`,
+			wantErr:  ErrNoDocument,
+		},
+		{
+			name:     "no document",
+			document: `null`,
+			wantErr:  ErrNoDocument,
+		},
+		{
+			name:     "invalid",
+			document: `"text"`,
+			wantErr:  ErrInvalidDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result ultraocr.JobResultResponse
+			err := json.Unmarshal([]byte(`{"result":{"Document":`+tt.document+`}}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pages, err := Decode[CNH](result)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(pages) != tt.wantPages {
+				t.Fatalf("Decode() pages = %v, want %v", len(pages), tt.wantPages)
+			}
+			if err != nil {
+				return
+			}
+
+			first := pages[0]
+			if first.Page != 1 || first.Data.Name.Value != "Maria" || first.Fields["Name"] != first.Data.Name {
+				t.Errorf("Decode() first page = %+v", first)
+			}
+		})
+	}
+}
+
+func TestDecodeFields(t *testing.T) {
+	result := ultraocr.JobResultResponse{
+		Result: ultraocr.Result{
+			Document: []map[string]any{
+				{
+					"Page": 1,
+					"Data": map[string]any{
+						"CPF":       map[string]any{"conf": 98, "value": "529.982.247-25"},
+						"BirthDate": map[string]any{"conf": 97, "value": "31/12/1990"},
+						"Extra":     map[string]any{"conf": 50, "value": 10},
+						"Empty":     map[string]any{"conf": 0, "value": nil},
+						"Raw":       "not a field",
+					},
+				},
+			},
+		},
+	}
+
+	pages, err := Decode[CPF](result)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	cpf := pages[0].Data
+	if cpf.CPF.Digits() != "52998224725" || cpf.CPF.Conf != 98 {
+		t.Errorf("CPF = %+v", cpf.CPF)
+	}
+
+	date, err := cpf.BirthDate.Date()
+	if err != nil || !date.Equal(time.Date(1990, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("BirthDate.Date() = %v, %v", date, err)
+	}
+
+	if !cpf.Name.Empty() {
+		t.Errorf("Name = %+v, want empty", cpf.Name)
+	}
+
+	fields := pages[0].Fields
+	if fields["Extra"].Value != "10" || !fields["Empty"].Empty() || len(fields) != 4 {
+		t.Errorf("Fields = %+v", fields)
+	}
+}
+
+func TestFirst(t *testing.T) {
+	result := ultraocr.JobResultResponse{
+		Result: ultraocr.Result{
+			Document: []any{
+				map[string]any{"Page": 1, "Data": map[string]any{"TotalValue": map[string]any{"value": "R$ 10,00"}}},
+			},
+		},
+	}
+
+	invoice, err := First[Invoice](result)
+	if err != nil || invoice.TotalValue.Value != "R$ 10,00" {
+		t.Errorf("First() = %+v, %v", invoice, err)
+	}
+
+	_, err = First[Invoice](ultraocr.JobResultResponse{})
+	if !errors.Is(err, ErrNoDocument) {
+		t.Errorf("First() error = %v, want %v", err, ErrNoDocument)
+	}
+}
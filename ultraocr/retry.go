@@ -0,0 +1,67 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// RetryFailedJobs Sends again, as individual jobs of the batch service, the documents of the batch jobs
+// finished with the error status. The documents are given by the resolver, which receives the failed
+// job result, and are sent with the job client data as metadata. Returns the new job ID of each
+// retried job ID, with the resolver and submission errors joined. Fails with ErrBatchNotFinished
+// while the batch is processing.
+func (client *Client) RetryFailedJobs(ctx context.Context, batchID string, resolve JobDocumentResolver) (map[string]string, error) {
+	status, err := client.GetBatchStatus(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Status.IsTerminal() {
+		return nil, fmt.Errorf("%w: %s is %s", common.ErrBatchNotFinished, batchID, status.Status)
+	}
+
+	var (
+		errs   []error
+		failed []string
+		inputs []JobInput
+	)
+
+	for _, job := range status.Jobs {
+		if !job.Status.IsError() {
+			continue
+		}
+
+		result, err := client.GetJobResult(ctx, batchID, job.JobID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", job.JobID, err))
+			continue
+		}
+
+		src, err := resolve(ctx, result)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", job.JobID, err))
+			continue
+		}
+
+		metadata, _ := result.ClientData.(map[string]any)
+		failed = append(failed, job.JobID)
+		inputs = append(inputs, JobInput{Document: src, Metadata: metadata})
+	}
+
+	retried := map[string]string{}
+	for i, result := range client.SubmitMany(ctx, status.Service, inputs, 0) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", failed[i], result.Err))
+		}
+
+		// jobs created but not audited are kept, see ErrAuditFailed
+		if result.Created.Id != "" {
+			retried[failed[i]] = result.Created.Id
+		}
+	}
+
+	return retried, errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestRetryFailedJobs(t *testing.T) {
+	errNotFound := errors.New("document not found")
+
+	tests := []struct {
+		name        string
+		processing  time.Duration
+		failed      []int
+		unresolved  []int
+		wantRetried []int
+		wantErr     error
+	}{
+		{name: "no failures"},
+		{name: "failed jobs", failed: []int{0, 2}, wantRetried: []int{0, 2}},
+		{name: "unresolved document", failed: []int{0, 1}, unresolved: []int{1}, wantRetried: []int{0}, wantErr: errNotFound},
+		{name: "processing", processing: time.Hour, wantErr: common.ErrBatchNotFinished},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			api := ultraocrtest.NewFakeAPI(clock)
+			api.JobsPerBatch = 3
+			api.ProcessingTime = tt.processing
+			client := newFakeClient(clock, api)
+
+			batch, err := client.SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG, Document: StringSource("batch", "batch")})
+			if err != nil {
+				t.Fatalf("client.SubmitBatch() error = %v", err)
+			}
+			status, err := client.GetBatchStatus(context.Background(), batch.Id)
+			if err != nil {
+				t.Fatalf("client.GetBatchStatus() error = %v", err)
+			}
+			for _, i := range tt.failed {
+				api.SetJobStatus(status.Jobs[i].JobID, common.STATUS_ERROR)
+			}
+
+			retried, err := client.RetryFailedJobs(context.Background(), batch.Id, func(ctx context.Context, job JobResultResponse) (Source, error) {
+				for _, i := range tt.unresolved {
+					if job.JobID == status.Jobs[i].JobID {
+						return nil, errNotFound
+					}
+				}
+				return StringSource(job.JobID, "document"), nil
+			})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("client.RetryFailedJobs() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(retried) != len(tt.wantRetried) {
+				t.Errorf("client.RetryFailedJobs() = %v, want %d retried", retried, len(tt.wantRetried))
+			}
+			for _, i := range tt.wantRetried {
+				newID, ok := retried[status.Jobs[i].JobID]
+				if !ok || newID == status.Jobs[i].JobID {
+					t.Errorf("client.RetryFailedJobs() job %d new ID = %q", i, newID)
+				}
+			}
+		})
+	}
+}
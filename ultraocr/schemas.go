@@ -2,7 +2,13 @@
 package ultraocr
 
 import (
+	"context"
+	"database/sql"
+	"image"
+	"io"
 	"net/http"
+	"regexp"
+	"sync"
 	"time"
 )
 
@@ -11,22 +17,594 @@ type HttpClient interface {
 }
 
 type Client struct {
-	BaseURL      string
-	AuthBaseURL  string
-	Token        string
-	ClientID     string
-	ClientSecret string
-	AutoRefresh  bool
-	Expires      int
-	Timeout      int
-	Interval     int
-	ExpiresAt    time.Time
-	HttpClient   HttpClient
+	BaseURL               string
+	AuthBaseURL           string
+	Token                 string
+	ClientID              string
+	ClientSecret          string
+	AutoRefresh           bool
+	Expires               int
+	Timeout               int
+	Interval              int
+	JobsConcurrency       int
+	ErrorBudget           int
+	SoftFailExtra         bool
+	CheckContentType      bool
+	UploadChecksums       bool
+	UploadChecksumHeaders []string
+	UseContextDeadline    bool
+	JobDeadline           time.Duration
+	ExpiresAt             time.Time
+	RefreshSkew           time.Duration
+	HttpClient            HttpClient
+	Clock                 Clock
+	PollStrategy          PollStrategy
+	Health                *HealthPolicy
+	Hooks                 Hooks
+	Metrics               Metrics
+	Events                *EventBus
+	Audit                 AuditSink
+	Credentials           CredentialsProvider
+	TokenStore            TokenStore
+	Store                 Store
+	Sink                  ResultSink
+	UploadFunc            UploadFunc
+	RangedUpload          *RangedUpload
+	UploadRetry           *UploadRetry
+	RateLimitRetry        *RateLimitRetry
+	Redaction             *Redaction
+	Serializer            MetadataSerializer
+	Schemas               map[Service]*MetadataSchema
+	SelfieCheck           *SelfieCheck
+	Preprocess            *Preprocess
+	Transformers          []ResultTransformer
+
+	authMu  *sync.Mutex
+	flights *flightGroup
+	limiter *limiter
+	debug   *debugBuffer
+}
+
+// Clock Source of time used on token expiration and pooling, replaceable on tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// PollStrategy Returns how long to sleep before the next status request on waits, attempt starting at 0.
+type PollStrategy interface {
+	Delay(attempt int) time.Duration
+}
+
+// Poller Polls a status until it is done, sleeping between polls. It is used by the Client waits
+// (see Client.NewPoller) and can be driven directly on any status, like a status URL.
+// Zero values use the defaults: a fixed Interval (default 1s) without Strategy, no Timeout,
+// unlimited MaxAttempts, failing on the first error without ErrorBudget or Health and the system clock.
+// ErrorBudget is how many consecutive transient errors (network errors, 429 and 5xx) are tolerated.
+// Resource and ID identify the polled item on the Hooks events and Metrics.
+type Poller struct {
+	Interval           time.Duration
+	Strategy           PollStrategy
+	Timeout            time.Duration
+	UseContextDeadline bool
+	MaxAttempts        int
+	ErrorBudget        int
+	Health             *HealthPolicy
+	Hooks              Hooks
+	Metrics            Metrics
+	OnTick             func(tick PollTick)
+	Clock              Clock
+	Resource           string
+	ID                 string
+}
+
+// PollFunc Requests a status, returning true when it is done.
+type PollFunc func(ctx context.Context) (bool, error)
+
+// PollTick Describes a poll, passed to the Poller OnTick hook, attempt starting at 0.
+type PollTick struct {
+	Attempt int
+	Done    bool
+	Err     error
+}
+
+// HealthPolicy Tolerates API server errors (5xx) on waits. After Threshold consecutive errors
+// (default 3) the API is degraded: the wait is suspended, polling every Backoff (default 30s)
+// without consuming the timeout, up to MaxSuspension (zero means until the context is done).
+type HealthPolicy struct {
+	Threshold     int
+	Backoff       time.Duration
+	MaxSuspension time.Duration
+}
+
+// DegradedEvent Describes a wait suspended by API server errors.
+type DegradedEvent struct {
+	Resource string
+	ID       string
+	Failures int
+	Since    time.Time
+	Err      error
+}
+
+// Hooks Functions called on Client events, nil functions are ignored.
+// They are called synchronously, so they must not block.
+type Hooks struct {
+	OnDegraded      func(event DegradedEvent)
+	OnRecovered     func(event DegradedEvent)
+	OnUploadSkipped func(event UploadSkippedEvent)
+	OnRequest       func(event RequestEvent)
+	OnResponse      func(event ResponseEvent)
+	OnRetry         func(event RetryEvent)
+	OnAuthRefresh   func(event AuthRefreshEvent)
+}
+
+// RequestEvent Describes a request about to be sent. Signed URLs have their query string removed.
+type RequestEvent struct {
+	Method    string
+	URL       string
+	RequestID string
+	Time      time.Time
+}
+
+// ResponseEvent Describes the response of a request, or the error sending it, with its latency.
+// RequestID is the one answered by the server, or the one sent if it doesn't answer one.
+type ResponseEvent struct {
+	Method     string
+	URL        string
+	StatusCode int
+	RequestID  string
+	Latency    time.Duration
+	Err        error
+}
+
+// RetryEvent Describes a retry scheduled after a transient error, on the request (429 answers),
+// upload or wait Phase. Target is the request URL, the upload URL without query string, or the
+// waited resource ID. Attempt starts at 0.
+type RetryEvent struct {
+	Phase   string
+	Target  string
+	Attempt int
+	Delay   time.Duration
+	Err     error
+}
+
+// AuthRefreshEvent Describes a token request, with the new token expiration or the error getting it.
+type AuthRefreshEvent struct {
+	ExpiresAt time.Time
+	Err       error
+}
+
+// Metrics Receives the Client measurements, to export them to a monitoring system, e.g. with the
+// metrics/prometheus package. Endpoints are request routes with the IDs replaced by "{id}", or
+// "upload" for the signed URLs, so they have a bounded number of values.
+// StatusCode is zero and err is set when the request is not answered.
+type Metrics interface {
+	ObserveRequest(endpoint string, statusCode int, latency time.Duration, err error)
+	IncRetry(phase string)
+	ObserveWait(resource string, wait time.Duration, err error)
+}
+
+// Event A job lifecycle event emitted on the Client EventBus: JobSubmitted, UploadCompleted,
+// StatusChanged, JobDone or JobFailed.
+type Event interface {
+	isEvent()
+}
+
+// JobSubmitted Emitted when a job or batch is created and its files are uploaded.
+type JobSubmitted struct {
+	Resource string
+	ID       string
+	Service  Service
+	Time     time.Time
+}
+
+// UploadCompleted Emitted when a document of a job or batch is uploaded.
+type UploadCompleted struct {
+	ID       string
+	Document string
+	Time     time.Time
+}
+
+// StatusChanged Emitted when a wait sees a new status of a job or batch. Previous is empty on
+// the first status seen.
+type StatusChanged struct {
+	Resource string
+	ID       string
+	Previous Status
+	Status   Status
+	Time     time.Time
+}
+
+// JobDone Emitted when a waited job finishes with the done status.
+type JobDone struct {
+	JobID  string
+	Result JobResultResponse
+	Time   time.Time
+}
+
+// JobFailed Emitted when a waited job finishes with the error status.
+type JobFailed struct {
+	JobID  string
+	Error  string
+	Result JobResultResponse
+	Time   time.Time
+}
+
+func (JobSubmitted) isEvent()    {}
+func (UploadCompleted) isEvent() {}
+func (StatusChanged) isEvent()   {}
+func (JobDone) isEvent()         {}
+func (JobFailed) isEvent()       {}
+
+// UploadSkippedEvent Describes an optional upload that failed without aborting the submission.
+type UploadSkippedEvent struct {
+	JobID    string
+	Document string
+	Err      error
+}
+
+// TrackedItem A job or batch followed by a Tracker.
+type TrackedItem struct {
+	Resource    string    `json:"resource"`
+	BatchID     string    `json:"batch_ksuid"`
+	JobID       string    `json:"job_ksuid,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// TrackerState Jobs and batches not finished yet of a Tracker, handed between processes with Export and Import.
+type TrackerState struct {
+	Version int           `json:"version"`
+	Items   []TrackedItem `json:"items"`
+}
+
+// Redaction Extra values masked by REDACTED on the debug entries and request errors: Headers (by
+// name), top level JSON body Fields and Patterns matched on bodies and error messages. The Client
+// secret and token, bearer tokens, credential headers and signed URLs query strings are always masked.
+type Redaction struct {
+	Headers  []string
+	Fields   []string
+	Patterns []*regexp.Regexp
+}
+
+// DebugEntry A request kept on the Client debug buffer, without credentials and tokens.
+type DebugEntry struct {
+	Time            time.Time     `json:"time"`
+	Duration        time.Duration `json:"duration"`
+	Method          string        `json:"method"`
+	URL             string        `json:"url"`
+	RequestHeaders  http.Header   `json:"request_headers,omitempty"`
+	RequestBody     string        `json:"request_body,omitempty"`
+	StatusCode      int           `json:"status_code,omitempty"`
+	RequestID       string        `json:"request_id,omitempty"`
+	ResponseHeaders http.Header   `json:"response_headers,omitempty"`
+	ResponseBody    string        `json:"response_body,omitempty"`
+	Err             string        `json:"error,omitempty"`
+}
+
+// Source A document to upload. It can be opened many times, e.g. to retry an upload.
+// Size returns -1 when unknown.
+type Source interface {
+	Name() string
+	Size() int64
+	Open() (io.ReadCloser, error)
+}
+
+// RangeSource A Source able to open a part of its content, used on ranged uploads without
+// reading the content before the part.
+type RangeSource interface {
+	Source
+	OpenRange(offset, length int64) (io.ReadCloser, error)
+}
+
+// RangedUpload Splits documents larger than PartSize (default 8 MiB) into parts uploaded concurrently,
+// up to Parallelism parts at a time (default 4), each one a PUT with a Content-Range header.
+// Only for backends assembling ranged PUTs to an URL, object storage presigned URLs get a single PUT.
+type RangedUpload struct {
+	PartSize    int64
+	Parallelism int
+}
+
+// UploadRetry Retries failed uploads to the signed URLs on network errors, timeouts, 429 and 5xx,
+// up to MaxAttempts (default 3), sleeping Backoff (default 500ms) doubled on each retry.
+// The source is opened again on each attempt. RenewURL, if set, is called when an upload is
+// forbidden (403), like when the signed URL expired, returning a new URL for the next attempts.
+// Parts of ranged uploads are retried alone, without renewing the URL.
+type UploadRetry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	RenewURL    func(ctx context.Context, url string) (string, error)
+}
+
+// RateLimitRetry Retries the API requests answered with 429 Too Many Requests, up to MaxAttempts
+// (default 3), waiting the Retry-After delay or, without it, Backoff (default 1s) doubled on each retry.
+// A Retry-After longer than MaxDelay (default 1m) isn't waited. MaxAttempts of 1 disables the retries.
+type RateLimitRetry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxDelay    time.Duration
+}
+
+// UploadFunc Uploads a source to a signed URL, replacing the Client default upload.
+type UploadFunc func(ctx context.Context, url string, src Source) error
+
+// MetadataSerializer Converts custom metadata values to JSON compatible values.
+// Returns false to let the Client handle the value.
+type MetadataSerializer func(value any) (any, bool, error)
+
+// MetadataSchema A JSON Schema subset to validate the metadata of a service before sending it.
+// Type is one of "object", "array", "string", "number", "integer", "boolean" or "null", empty accepting any.
+// AdditionalProperties false rejects object fields not in Properties.
+type MetadataSchema struct {
+	Type                 string                     `json:"type,omitempty"`
+	Properties           map[string]*MetadataSchema `json:"properties,omitempty"`
+	Required             []string                   `json:"required,omitempty"`
+	AdditionalProperties *bool                      `json:"additionalProperties,omitempty"`
+	Items                *MetadataSchema            `json:"items,omitempty"`
+	Enum                 []any                      `json:"enum,omitempty"`
+}
+
+// JobOptions Typed query params for job and batch submissions.
+// Facematch and ExtraDocument request the facematch and extra document files of jobs.
+// Base64 sends the files as base64 data instead of file paths.
+// CallbackURL is called by the API when the job or batch finishes, see the webhook package.
+// IdempotencyKey identifies the submission, so the API returns the job or batch already created
+// with the same key instead of a duplicate, e.g. on retries or redelivered queue messages.
+// Extra holds any other raw query param. The typed options set replace it, while unset (false or empty)
+// ones keep its value: Extra can enable the facematch, extra document and base64 flags, not disable them.
+type JobOptions struct {
+	Facematch      bool
+	ExtraDocument  bool
+	Base64         bool
+	CallbackURL    string
+	IdempotencyKey string
+	Extra          map[string]string
+}
+
+// JobRequest A job submission. Selfie and ExtraDocument are only uploaded when requested on the Options.
+// With the Base64 option, the sources have the files as base64 data.
+type JobRequest struct {
+	Service       Service
+	Document      Source
+	Selfie        Source
+	ExtraDocument Source
+	Metadata      map[string]any
+	Options       JobOptions
+}
+
+// JobInput A job of SubmitMany, sent to the service given there.
+type JobInput struct {
+	Document      Source
+	Selfie        Source
+	ExtraDocument Source
+	Metadata      map[string]any
+	Options       JobOptions
+}
+
+// BatchRequest A batch submission. With the Base64 option, the source has the file as base64 data.
+type BatchRequest struct {
+	Service  Service
+	Document Source
+	Metadata []map[string]any
+	Options  JobOptions
+}
+
+// BatchDownloadOptions Options of batch results downloads. Concurrency defaults to the Client jobs concurrency.
+// With NDJSON, the results are written as lines of a single "<batch ID>.ndjson" file instead of a
+// "<job ID>.json" file per job.
+type BatchDownloadOptions struct {
+	Concurrency int
+	NDJSON      bool
+}
+
+// BatchDownload Outcome of a batch results download. Skipped jobs were downloaded before, and
+// pending jobs are not finished yet, so they are downloaded on a later call.
+type BatchDownload struct {
+	Downloaded int
+	Skipped    int
+	Pending    int
+	Failed     map[string]error
+}
+
+// BatchSummary Counts of the batch jobs per status, with the IDs of the failed jobs.
+type BatchSummary struct {
+	Total   int
+	Counts  map[Status]int
+	Errored []string
+}
+
+// ConcurrencyLimits Maximum in flight requests per endpoint class, zero means unlimited.
+type ConcurrencyLimits struct {
+	Submissions int
+	Polls       int
+	Uploads     int
 }
 
+// Store Key value storage used to persist the SDK state, like export checkpoints.
+// Get returns false if the key was never saved or was deleted.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Put(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// ResultSink Persists finished job results. When set on the Client, the bulk helpers (GetBatchResults,
+// WaitForJobsDone and the batch waits) store each result as it finishes.
+type ResultSink interface {
+	Store(ctx context.Context, result JobResultResponse) error
+}
+
+// Credentials Client ID and secret used to request tokens.
+type Credentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// CredentialsProvider Fetches the Client credentials, like from a secrets manager. With auto refresh,
+// they are fetched again on every token refresh, so rotated secrets are picked up.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// AuditSink Records the submissions and finished result retrievals of a Client, e.g. on an append-only
+// store for audit trails. Every result method records them: the job result (also raw, by fields and by
+// status URL), the waits and the job listings, once per finished job. A failing Record fails the audited
+// call, which still returns the created job or the result along with the error. On submissions the
+// error wraps ErrAuditFailed: the job or batch was created and must not be submitted again.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// AuditRecord Audited submission or result retrieval. Actor is the Client ID, RequestID the
+// context request ID (see WithRequestID) and MetadataHash the hex SHA-256 of the metadata JSON:
+// the submitted metadata or the result client data, empty without metadata.
+type AuditRecord struct {
+	Action       string    `json:"action"`
+	Actor        string    `json:"actor"`
+	Time         time.Time `json:"time"`
+	Service      Service   `json:"service"`
+	Resource     string    `json:"resource"`
+	ID           string    `json:"id"`
+	Status       Status    `json:"status,omitempty"`
+	RequestID    string    `json:"request_id,omitempty"`
+	MetadataHash string    `json:"metadata_hash,omitempty"`
+}
+
+// SQLExecer Executes SQL statements, like *sql.DB and *sql.Tx.
+type SQLExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// JobDocumentResolver Returns the document of a failed batch job to send it again, e.g. found by
+// the job Filename or ClientData, as the SDK doesn't keep the submitted files.
+type JobDocumentResolver func(ctx context.Context, job JobResultResponse) (Source, error)
+
+// JobsHandler Handles a page of jobs on exports, a returned error stops the export.
+type JobsHandler func(ctx context.Context, jobs []JobResultResponse) error
+
+// JobsExportCheckpoint Progress of a jobs export, saved on the Client Store after each page.
+type JobsExportCheckpoint struct {
+	ID            string    `json:"id"`
+	Start         string    `json:"start"`
+	End           string    `json:"end"`
+	NextPageToken string    `json:"next_page_token,omitempty"`
+	Pages         int       `json:"pages"`
+	Jobs          int       `json:"jobs"`
+	Done          bool      `json:"done"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CachedToken A token saved on a TokenStore.
+type CachedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenStore Persists tokens, so different processes can reuse an unexpired token.
+type TokenStore interface {
+	Load(ctx context.Context, key string) (CachedToken, bool, error)
+	Save(ctx context.Context, key string, token CachedToken) error
+}
+
+// FaceDetector Counts the faces found on an image, used to pre-check facematch selfies.
+type FaceDetector interface {
+	DetectFaces(ctx context.Context, img image.Image) (int, error)
+}
+
+// SelfieCheck Local checks applied to facematch selfies before uploading them.
+// Zero values disable the related check.
+type SelfieCheck struct {
+	MinWidth  int
+	MinHeight int
+	Detector  FaceDetector
+}
+
+// Preprocess Re-encodes oversized JPEG and PNG documents as JPEG before submissions, lowering the
+// quality from Quality (default 85) to MinQuality (default 50) and then the resolution until they
+// fit on MaxBytes (default 4MiB). Images with a side over MaxDimension (default no limit) are
+// downscaled first. Single step jobs also fit their files on the API body limit.
+type Preprocess struct {
+	MaxBytes     int64
+	MaxDimension int
+	Quality      int
+	MinQuality   int
+}
+
+// SubmitterOptions Options of a Submitter: Workers submitting at a time (default the Client jobs
+// concurrency), the maximum submissions per second on RateLimit (default unlimited) and the pending
+// jobs on QueueSize (default 100). With Wait, the workers also wait the job results.
+type SubmitterOptions struct {
+	Workers   int
+	RateLimit float64
+	QueueSize int
+	Wait      bool
+}
+
+// SubmitResult The outcome of a job of a Submitter or SubmitMany. Result is only set with the Submitter Wait.
+type SubmitResult struct {
+	Request JobRequest
+	Created CreatedResponse
+	Result  *JobResultResponse
+	Err     error
+}
+
+// QueuedJobRequest A job to add on a JobQueue. The files are referenced by path, so the
+// queue can be persisted.
+type QueuedJobRequest struct {
+	Service           Service
+	FilePath          string
+	FacematchFilePath string
+	ExtraFilePath     string
+	Metadata          map[string]any
+	Options           JobOptions
+}
+
+// QueuedJob A job of a JobQueue and its progress, saved on the Client Store after each step.
+// JobID, StatusURL and URLs are set once the job is created, and Result once it finishes.
+type QueuedJob struct {
+	ID             string             `json:"id"`
+	Request        QueuedJobRequest   `json:"request"`
+	State          string             `json:"state"`
+	JobID          string             `json:"job_id,omitempty"`
+	StatusURL      string             `json:"status_url,omitempty"`
+	URLs           map[string]string  `json:"urls,omitempty"`
+	Attempts       int                `json:"attempts"`
+	Error          string             `json:"error,omitempty"`
+	Result         *JobResultResponse `json:"result,omitempty"`
+	Owner          string             `json:"owner,omitempty"`
+	LeaseExpiresAt time.Time          `json:"lease_expires_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// DirectoryOptions Options of directory processing. Only files with one of the Extensions
+// (default PDF, JPEG, PNG and TIFF ones) are sent, with the job Options and Metadata, up to
+// Concurrency at a time (default the Client jobs concurrency).
+type DirectoryOptions struct {
+	Recursive   bool
+	Concurrency int
+	Extensions  []string
+	Options     JobOptions
+	Metadata    map[string]any
+}
+
+// FileResult The outcome of a file of a processed directory. JobID is empty when the
+// submission failed.
+type FileResult struct {
+	Path   string
+	JobID  string
+	Result JobResultResponse
+	Err    error
+}
+
+// ResultTransformer Post-processes a job result after it is fetched from the API.
+type ResultTransformer func(result *JobResultResponse) error
+
 type Response struct {
-	body   []byte
-	status int
+	body      []byte
+	status    int
+	url       string
+	requestID string
+	errorBody []byte
 }
 
 type tokenResponse struct {
@@ -40,24 +618,36 @@ type SignedUrlResponse struct {
 	URLs      map[string]string `json:"urls"`
 }
 
+// CreatedResponse A created job or batch. Warnings has the optional uploads that failed without
+// aborting the submission, see Client.SetSoftFailExtra.
 type CreatedResponse struct {
-	Id        string `json:"id"`
-	StatusURL string `json:"status_url"`
+	Id        string              `json:"id"`
+	StatusURL string              `json:"status_url"`
+	Warnings  []error             `json:"-"`
+	Checksums map[string]Checksum `json:"-"`
+	Batches   []CreatedResponse   `json:"-"`
+}
+
+// Checksum Digests of an uploaded source, base64 encoded as sent on the Content-MD5 and
+// x-amz-checksum-sha256 headers, so they compare with the values kept by the storage.
+type Checksum struct {
+	MD5    string `json:"md5"`
+	SHA256 string `json:"sha256"`
 }
 
 type BatchStatusJobs struct {
 	JobID     string `json:"job_ksuid"`
 	CreatedAt string `json:"created_at"`
 	ResultURL string `json:"result_url"`
-	Status    string `json:"status"`
+	Status    Status `json:"status"`
 	Error     string `json:"error,omitempty"`
 }
 
 type BatchStatusResponse struct {
 	BatchID   string            `json:"batch_ksuid"`
 	CreatedAt string            `json:"created_at"`
-	Service   string            `json:"service"`
-	Status    string            `json:"status"`
+	Service   Service           `json:"service"`
+	Status    Status            `json:"status"`
 	Error     string            `json:"error,omitempty"`
 	Jobs      []BatchStatusJobs `json:"jobs"`
 }
@@ -72,8 +662,8 @@ type JobResultResponse struct {
 	Result           Result      `json:"result,omitempty"`
 	JobID            string      `json:"job_ksuid"`
 	CreatedAt        string      `json:"created_at"`
-	Service          string      `json:"service"`
-	Status           string      `json:"status"`
+	Service          Service     `json:"service"`
+	Status           Status      `json:"status"`
 	Error            string      `json:"error,omitempty"`
 	ProcessTime      string      `json:"process_time,omitempty"`
 	Filename         string      `json:"filename,omitempty"`
@@ -82,7 +672,144 @@ type JobResultResponse struct {
 	Validation       interface{} `json:"validation,omitempty"`
 }
 
+// JobRef Identifies a job, with the batch ID equal to the job ID for simple jobs.
+type JobRef struct {
+	BatchID string
+	JobID   string
+}
+
+// JobResult Outcome of an asynchronous job wait.
+type JobResult struct {
+	BatchID string
+	JobID   string
+	Result  JobResultResponse
+	Err     error
+}
+
+// BatchResult Outcome of an asynchronous batch wait.
+type BatchResult struct {
+	BatchID string
+	Status  BatchStatusResponse
+	Err     error
+}
+
+// StatusURLResponse Status fetched from a status URL, with Job set for job status URLs
+// and Batch set for batch status URLs.
+type StatusURLResponse struct {
+	Resource string
+	Status   Status
+	Job      *JobResultResponse
+	Batch    *BatchStatusResponse
+}
+
+// JobsFilter Server side filters of GetJobsWithFilter, sent as query params. Empty fields don't filter.
+// ClientData matches the metadata sent with the jobs.
+type JobsFilter struct {
+	Status           Status
+	Service          Service
+	ValidationStatus string
+	ClientData       string
+}
+
+// JobsQuery Query of FindJobs. Filter is applied by the API and ClientData on the client:
+// a job matches when its client_data has every ClientData value, comparing nested objects the same way.
+type JobsQuery struct {
+	Start      string
+	End        string
+	Filter     JobsFilter
+	ClientData map[string]any
+}
+
+// BatchesFilter Server side filters of GetBatches, sent as query params. Empty fields don't filter.
+type BatchesFilter struct {
+	Status  Status
+	Service Service
+}
+
+// JobsChunkOptions Options of GetJobsInChunks. Days is the size of each chunk (default 7) and Concurrency
+// how many chunks are got at once (default the Client jobs concurrency).
+type JobsChunkOptions struct {
+	Days        int
+	Concurrency int
+	Filter      JobsFilter
+}
+
 type GetJobsResponse struct {
 	Jobs          []JobResultResponse `json:"jobs"`
 	NextPageToken string              `json:"nextPageToken"`
 }
+
+type GetBatchesResponse struct {
+	Batches       []BatchStatusResponse `json:"batches"`
+	NextPageToken string                `json:"nextPageToken"`
+}
+
+// ServiceInfo A service available to the client, with the submission options it supports.
+type ServiceInfo struct {
+	Name          Service `json:"name"`
+	Description   string  `json:"description"`
+	Facematch     bool    `json:"facematch"`
+	ExtraDocument bool    `json:"extra_document"`
+}
+
+// ServiceCatalog The services available to the client, see ListServices.
+type ServiceCatalog []ServiceInfo
+
+// UsageResponse Documents processed on a month, in total and per service, and the plan quota.
+// Quota and Remaining are zero on plans without a quota.
+type UsageResponse struct {
+	Period    string          `json:"period"`
+	Processed int             `json:"processed"`
+	Services  map[Service]int `json:"services"`
+	Quota     int             `json:"quota"`
+	Remaining int             `json:"remaining"`
+}
+
+// ManifestFile A file submitted on a batch, with its hash to verify it later.
+type ManifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ManifestJob A job produced by a batch.
+type ManifestJob struct {
+	JobID  string `json:"job_ksuid"`
+	Status Status `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Manifest Describes a batch submission (files, metadata, params and produced jobs),
+// making the batch a reproducible artifact for audits and resubmissions.
+type Manifest struct {
+	Version   int               `json:"version"`
+	Service   Service           `json:"service"`
+	CreatedAt time.Time         `json:"created_at"`
+	BatchID   string            `json:"batch_ksuid,omitempty"`
+	StatusURL string            `json:"status_url,omitempty"`
+	Files     []ManifestFile    `json:"files"`
+	Metadata  []map[string]any  `json:"metadata,omitempty"`
+	Params    map[string]string `json:"params,omitempty"`
+	Jobs      []ManifestJob     `json:"jobs,omitempty"`
+}
+
+// ShadowTarget A client and service to submit a document to on shadow mode.
+type ShadowTarget struct {
+	Client  *Client
+	Service Service
+}
+
+// ResultDiff A difference between primary and shadow results, on a path like "Document[0].Data.Name.value".
+type ResultDiff struct {
+	Path    string
+	Primary any
+	Shadow  any
+}
+
+// ShadowResult Results of a document submitted to primary and shadow targets.
+type ShadowResult struct {
+	Primary   JobResultResponse
+	Shadow    JobResultResponse
+	ShadowErr error
+	Diffs     []ResultDiff
+}
@@ -0,0 +1,90 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder for selfie checks
+	_ "image/jpeg" // register JPEG decoder for selfie checks
+	_ "image/png"  // register PNG decoder for selfie checks
+	"io"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetSelfieCheck Enables local checks on facematch selfies before uploading them.
+func (client *Client) SetSelfieCheck(check SelfieCheck) {
+	client.SelfieCheck = &check
+}
+
+// CheckSelfie Runs the Client selfie checks on an image.
+// Returns nil if no check is configured or the image passes all of them.
+func (client *Client) CheckSelfie(ctx context.Context, r io.Reader) error {
+	check := client.SelfieCheck
+	if check == nil {
+		return nil
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return fmt.Errorf("%w: file is not a supported image", common.ErrInvalidSelfie)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() < check.MinWidth || bounds.Dy() < check.MinHeight {
+		return fmt.Errorf(
+			"%w: resolution %dx%d is below the minimum %dx%d",
+			common.ErrInvalidSelfie,
+			bounds.Dx(),
+			bounds.Dy(),
+			check.MinWidth,
+			check.MinHeight,
+		)
+	}
+
+	if check.Detector == nil {
+		return nil
+	}
+
+	faces, err := check.Detector.DetectFaces(ctx, img)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrInvalidSelfie, err)
+	}
+
+	if faces != 1 {
+		return fmt.Errorf("%w: expected one face, found %d", common.ErrInvalidSelfie, faces)
+	}
+
+	return nil
+}
+
+func (client *Client) checkSelfieBase64(ctx context.Context, data string) error {
+	if client.SelfieCheck == nil {
+		return nil
+	}
+
+	return client.CheckSelfie(ctx, base64.NewDecoder(base64.StdEncoding, strings.NewReader(data)))
+}
+
+// checkSelfieSource Checks the selfie of a source, decoding it first if it is base64 data.
+func (client *Client) checkSelfieSource(ctx context.Context, src Source, encoded bool) error {
+	if client.SelfieCheck == nil {
+		return nil
+	}
+
+	body, err := src.Open()
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	var r io.Reader = body
+	if encoded {
+		r = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	return client.CheckSelfie(ctx, r)
+}
@@ -0,0 +1,127 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+type FaceDetectorMock struct {
+	MockDetectFaces func(ctx context.Context, img image.Image) (int, error)
+}
+
+func (d *FaceDetectorMock) DetectFaces(ctx context.Context, img image.Image) (int, error) {
+	return d.MockDetectFaces(ctx, img)
+}
+
+func newTestPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func facesDetector(faces int, err error) FaceDetector {
+	return &FaceDetectorMock{
+		MockDetectFaces: func(ctx context.Context, img image.Image) (int, error) {
+			return faces, err
+		},
+	}
+}
+
+func TestCheckSelfie(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   *SelfieCheck
+		data    []byte
+		wantErr bool
+	}{
+		{
+			name: "no check configured",
+			data: []byte("not an image"),
+		},
+		{
+			name:  "success",
+			check: &SelfieCheck{MinWidth: 10, MinHeight: 10, Detector: facesDetector(1, nil)},
+			data:  newTestPNG(20, 20),
+		},
+		{
+			name:    "not an image",
+			check:   &SelfieCheck{},
+			data:    []byte("not an image"),
+			wantErr: true,
+		},
+		{
+			name:    "resolution too small",
+			check:   &SelfieCheck{MinWidth: 30, MinHeight: 10},
+			data:    newTestPNG(20, 20),
+			wantErr: true,
+		},
+		{
+			name:    "no face detected",
+			check:   &SelfieCheck{Detector: facesDetector(0, nil)},
+			data:    newTestPNG(20, 20),
+			wantErr: true,
+		},
+		{
+			name:    "many faces detected",
+			check:   &SelfieCheck{Detector: facesDetector(2, nil)},
+			data:    newTestPNG(20, 20),
+			wantErr: true,
+		},
+		{
+			name:    "detector failure",
+			check:   &SelfieCheck{Detector: facesDetector(0, errors.New("error"))},
+			data:    newTestPNG(20, 20),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				SelfieCheck: tt.check,
+			}
+			err := client.CheckSelfie(context.Background(), bytes.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.CheckSelfie() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, common.ErrInvalidSelfie) {
+				t.Errorf("client.CheckSelfie() error = %v, want %v", err, common.ErrInvalidSelfie)
+			}
+		})
+	}
+}
+
+func TestSendJobBase64SelfieCheck(t *testing.T) {
+	requests := 0
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				requests += 1
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123"}`))),
+				}, nil
+			},
+		},
+	}
+	client.SetSelfieCheck(SelfieCheck{MinWidth: 100, MinHeight: 100})
+
+	params := map[string]string{common.KEY_FACEMATCH: common.FLAG_TRUE}
+	selfie := base64.StdEncoding.EncodeToString(newTestPNG(20, 20))
+
+	_, err := client.SendJobBase64(context.Background(), "rg", "123", selfie, "", nil, params)
+	if !errors.Is(err, common.ErrInvalidSelfie) {
+		t.Errorf("client.SendJobBase64() error = %v, want %v", err, common.ErrInvalidSelfie)
+	}
+	if requests != 0 {
+		t.Errorf("client.SendJobBase64() requests = %v, want 0", requests)
+	}
+}
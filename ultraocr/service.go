@@ -0,0 +1,109 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// Service Document type processed by a job or batch, like ServiceRG. Services not listed here
+// can still be used converting their name, like Service("name").
+type Service string
+
+// Known services.
+const (
+	ServiceRG             Service = "rg"
+	ServiceCNH            Service = "cnh"
+	ServiceCPF            Service = "cpf"
+	ServiceCRLV           Service = "crlv"
+	ServiceCTPS           Service = "ctps"
+	ServiceRNE            Service = "rne"
+	ServicePassport       Service = "passport"
+	ServiceInvoice        Service = "invoice"
+	ServiceIDTypification Service = "idtypification"
+)
+
+// KnownServices Returns the services known by the SDK.
+func KnownServices() []Service {
+	return []Service{
+		ServiceRG,
+		ServiceCNH,
+		ServiceCPF,
+		ServiceCRLV,
+		ServiceCTPS,
+		ServiceRNE,
+		ServicePassport,
+		ServiceInvoice,
+		ServiceIDTypification,
+	}
+}
+
+// IsKnown Checks if the service is one of the services known by the SDK.
+func (s Service) IsKnown() bool {
+	for _, known := range KnownServices() {
+		if s == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String Returns the service name as used by the API.
+func (s Service) String() string {
+	return string(s)
+}
+
+// ListServices Gets the services available to the authenticated client.
+func (client *Client) ListServices(ctx context.Context) (ServiceCatalog, error) {
+	url := fmt.Sprintf("%s/ocr/services", client.BaseURL)
+	response, err := client.get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.status != 200 {
+		return nil, response.apiError()
+	}
+
+	var res struct {
+		Services ServiceCatalog `json:"services"`
+	}
+	err = json.Unmarshal(response.body, &res)
+	if err != nil {
+		return nil, common.ErrParsingResponse
+	}
+
+	return res.Services, nil
+}
+
+// Lookup Returns the service info, false if the service is not available.
+func (c ServiceCatalog) Lookup(service Service) (ServiceInfo, bool) {
+	for _, info := range c {
+		if info.Name == service {
+			return info, true
+		}
+	}
+
+	return ServiceInfo{}, false
+}
+
+// Validate Checks if the service is available and supports the facematch and extra document options.
+func (c ServiceCatalog) Validate(service Service, opts JobOptions) error {
+	info, ok := c.Lookup(service)
+	if !ok {
+		return fmt.Errorf("%w: %q", common.ErrUnavailableService, service)
+	}
+
+	if opts.Facematch && !info.Facematch {
+		return fmt.Errorf("%w: %q has no facematch", common.ErrUnsupportedOption, service)
+	}
+
+	if opts.ExtraDocument && !info.ExtraDocument {
+		return fmt.Errorf("%w: %q has no extra document", common.ErrUnsupportedOption, service)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestService(t *testing.T) {
+	tests := []struct {
+		service   Service
+		wantKnown bool
+	}{
+		{service: ServiceRG, wantKnown: true},
+		{service: ServiceCNH, wantKnown: true},
+		{service: ServiceInvoice, wantKnown: true},
+		{service: "RG"},
+		{service: "cnhh"},
+		{service: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.service.String(), func(t *testing.T) {
+			if got := tt.service.IsKnown(); got != tt.wantKnown {
+				t.Errorf("Service.IsKnown() = %v, want %v", got, tt.wantKnown)
+			}
+		})
+	}
+}
+
+func TestListServices(t *testing.T) {
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/ocr/services" {
+					t.Errorf("path = %v, want /ocr/services", req.URL.Path)
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body: io.NopCloser(bytes.NewReader([]byte(`{"services":[
+						{"name":"rg","description":"Brazilian ID","facematch":true,"extra_document":true},
+						{"name":"invoice","description":"Invoices"}
+					]}`))),
+				}, nil
+			},
+		},
+	}
+
+	catalog, err := client.ListServices(context.Background())
+	if err != nil {
+		t.Fatalf("client.ListServices() error = %v", err)
+	}
+
+	want := ServiceCatalog{
+		{Name: ServiceRG, Description: "Brazilian ID", Facematch: true, ExtraDocument: true},
+		{Name: ServiceInvoice, Description: "Invoices"},
+	}
+	if !reflect.DeepEqual(catalog, want) {
+		t.Fatalf("client.ListServices() = %+v, want %+v", catalog, want)
+	}
+
+	tests := []struct {
+		name    string
+		service Service
+		opts    JobOptions
+		wantErr error
+	}{
+		{name: "available", service: ServiceRG, opts: JobOptions{Facematch: true, ExtraDocument: true}},
+		{name: "no options", service: ServiceInvoice},
+		{name: "unavailable", service: ServiceCNH, wantErr: common.ErrUnavailableService},
+		{name: "no facematch", service: ServiceInvoice, opts: JobOptions{Facematch: true}, wantErr: common.ErrUnsupportedOption},
+		{name: "no extra document", service: ServiceInvoice, opts: JobOptions{ExtraDocument: true}, wantErr: common.ErrUnsupportedOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := catalog.Validate(tt.service, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ServiceCatalog.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
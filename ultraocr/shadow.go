@@ -0,0 +1,144 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ShadowJob Creates and waits a job on primary and shadow targets in parallel, returning both results
+// and their differences. Useful to compare service versions or environments during migrations.
+// Only the primary target errors are returned, shadow errors are saved on ShadowResult.ShadowErr.
+// When the primary target fails, the shadow job is canceled instead of waited.
+func ShadowJob(ctx context.Context,
+	primary,
+	shadow ShadowTarget,
+	filePath,
+	facematchFilePath,
+	extraFilePath string,
+	metadata map[string]any,
+	params map[string]string,
+) (ShadowResult, error) {
+	var wg sync.WaitGroup
+	var result ShadowResult
+
+	shadowCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		result.Shadow, result.ShadowErr = shadow.Client.CreateAndWaitJob(
+			shadowCtx, shadow.Service, filePath, facematchFilePath, extraFilePath, metadata, params,
+		)
+	}()
+
+	var err error
+	result.Primary, err = primary.Client.CreateAndWaitJob(
+		ctx, primary.Service, filePath, facematchFilePath, extraFilePath, metadata, params,
+	)
+	if err != nil {
+		cancel()
+	}
+
+	wg.Wait()
+
+	if err != nil {
+		return ShadowResult{}, err
+	}
+
+	if result.ShadowErr == nil {
+		result.Diffs = DiffResults(result.Primary, result.Shadow)
+	}
+
+	return result, nil
+}
+
+// DiffResults Compares the status and extracted document of two job results.
+func DiffResults(primary, shadow JobResultResponse) []ResultDiff {
+	diffs := []ResultDiff{}
+
+	for _, field := range []struct {
+		path    string
+		primary any
+		shadow  any
+	}{
+		{"Status", string(primary.Status), string(shadow.Status)},
+		{"Error", primary.Error, shadow.Error},
+		{"ValidationStatus", primary.ValidationStatus, shadow.ValidationStatus},
+	} {
+		if field.primary != field.shadow {
+			diffs = append(diffs, ResultDiff{Path: field.path, Primary: field.primary, Shadow: field.shadow})
+		}
+	}
+
+	return diffValues("Document", normalizeJSON(primary.Result.Document), normalizeJSON(shadow.Result.Document), diffs)
+}
+
+// normalizeJSON Converts a value to its generic JSON representation, so typed and untyped documents compare equal.
+func normalizeJSON(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	err = json.Unmarshal(data, &normalized)
+	if err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+func diffValues(path string, primary, shadow any, diffs []ResultDiff) []ResultDiff {
+	switch p := primary.(type) {
+	case map[string]any:
+		s, ok := shadow.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := []string{}
+		for k := range p {
+			keys = append(keys, k)
+		}
+		for k := range s {
+			if _, ok := p[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			diffs = diffValues(path+"."+k, p[k], s[k], diffs)
+		}
+		return diffs
+	case []any:
+		s, ok := shadow.([]any)
+		if !ok {
+			break
+		}
+
+		for i := 0; i < max(len(p), len(s)); i++ {
+			var pi, si any
+			if i < len(p) {
+				pi = p[i]
+			}
+			if i < len(s) {
+				si = s[i]
+			}
+			diffs = diffValues(fmt.Sprintf("%s[%d]", path, i), pi, si, diffs)
+		}
+		return diffs
+	}
+
+	if !reflect.DeepEqual(primary, shadow) {
+		diffs = append(diffs, ResultDiff{Path: path, Primary: primary, Shadow: shadow})
+	}
+
+	return diffs
+}
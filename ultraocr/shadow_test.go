@@ -0,0 +1,115 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestDiffResults(t *testing.T) {
+	primary := JobResultResponse{
+		Status: "done",
+		Result: Result{
+			Document: []map[string]any{
+				{"Page": 1, "Data": map[string]any{"Name": map[string]any{"value": "Maria", "conf": 99}}},
+			},
+		},
+	}
+	shadow := JobResultResponse{
+		Status: "done",
+		Result: Result{
+			Document: []any{
+				map[string]any{"Page": 1, "Data": map[string]any{"Name": map[string]any{"value": "Mario", "conf": 99}, "CPF": map[string]any{"value": "1"}}},
+			},
+		},
+	}
+
+	want := []ResultDiff{
+		{Path: "Document[0].Data.CPF", Primary: nil, Shadow: map[string]any{"value": "1"}},
+		{Path: "Document[0].Data.Name.value", Primary: "Maria", Shadow: "Mario"},
+	}
+	if got := DiffResults(primary, shadow); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffResults() = %v, want %v", got, want)
+	}
+}
+
+func TestShadowJob(t *testing.T) {
+	f, _ := os.CreateTemp(t.TempDir(), "")
+	f.Close()
+
+	clock := ultraocrtest.NewAutoClock(time.Now())
+	primaryAPI := ultraocrtest.NewFakeAPI(clock)
+	primaryAPI.Document = []map[string]any{{"Data": map[string]any{"Name": map[string]any{"value": "Maria"}}}}
+	shadowAPI := ultraocrtest.NewFakeAPI(clock)
+	shadowAPI.Document = []map[string]any{{"Data": map[string]any{"Name": map[string]any{"value": "Mario"}}}}
+
+	primary := newFakeClient(clock, primaryAPI)
+	shadow := newFakeClient(clock, shadowAPI)
+
+	got, err := ShadowJob(
+		context.Background(),
+		ShadowTarget{Client: &primary, Service: "rg"},
+		ShadowTarget{Client: &shadow, Service: "rg_v2"},
+		f.Name(), "", "", nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("ShadowJob() error = %v", err)
+	}
+	if got.ShadowErr != nil {
+		t.Fatalf("ShadowJob() shadow error = %v", got.ShadowErr)
+	}
+
+	want := []ResultDiff{
+		{Path: "Document[0].Data.Name.value", Primary: "Maria", Shadow: "Mario"},
+	}
+	if !reflect.DeepEqual(got.Diffs, want) {
+		t.Errorf("ShadowJob() diffs = %v, want %v", got.Diffs, want)
+	}
+	if got.Shadow.Service != "rg_v2" {
+		t.Errorf("ShadowJob() shadow service = %v, want rg_v2", got.Shadow.Service)
+	}
+}
+
+func TestShadowJobPrimaryFailure(t *testing.T) {
+	f, _ := os.CreateTemp(t.TempDir(), "")
+	f.Close()
+
+	primaryClock := ultraocrtest.NewAutoClock(time.Now())
+	primary := newFakeClient(primaryClock, ultraocrtest.NewFakeAPI(primaryClock))
+	primary.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+	}})
+
+	// the shadow clock never advances, so its job is waited until canceled
+	shadowClock := ultraocrtest.NewFakeClock(time.Now())
+	shadowAPI := ultraocrtest.NewFakeAPI(shadowClock)
+	shadowAPI.ProcessingTime = time.Hour
+	shadow := newFakeClient(shadowClock, shadowAPI)
+
+	done := make(chan error)
+	go func() {
+		_, err := ShadowJob(
+			context.Background(),
+			ShadowTarget{Client: &primary, Service: "rg"},
+			ShadowTarget{Client: &shadow, Service: "rg_v2"},
+			f.Name(), "", "", nil, nil,
+		)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, common.ErrInvalidStatusCode) {
+			t.Errorf("ShadowJob() error = %v, want %v", err, common.ErrInvalidStatusCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ShadowJob() waited the shadow job after the primary failed")
+	}
+}
@@ -0,0 +1,89 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetResultSink Changes the ResultSink where the bulk helpers store the finished results.
+func (client *Client) SetResultSink(sink ResultSink) {
+	client.Sink = sink
+}
+
+// storeResult Stores the result on the Client sink, if any and if the job finished.
+func (client *Client) storeResult(ctx context.Context, result JobResultResponse) error {
+	if client.Sink == nil || !result.Status.IsTerminal() {
+		return nil
+	}
+
+	err := client.Sink.Store(ctx, result)
+	if err != nil {
+		return fmt.Errorf("%w: job %s: %w", common.ErrResultSink, result.JobID, err)
+	}
+
+	return nil
+}
+
+// FileSink ResultSink writing each result on a "<job ID>.json" file of a directory, replaced atomically.
+type FileSink struct {
+	Dir string
+}
+
+// NewFileSink Creates a ResultSink writing results on the given directory, created if needed.
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{Dir: dir}
+}
+
+// Store Writes the result file.
+func (s *FileSink) Store(ctx context.Context, result JobResultResponse) error {
+	err := ValidateID(result.JobID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.Dir, 0o700)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(s.Dir, result.JobID+".json"), data)
+}
+
+// SQLSink ResultSink inserting each result with a SQL statement. The statement receives the job ID,
+// service, status, creation time and the result as JSON text, in this order.
+type SQLSink struct {
+	DB    SQLExecer
+	Query string
+}
+
+// NewSQLSink Creates a ResultSink executing the query on the database. An empty query uses
+// common.SQL_SINK_QUERY, with ? placeholders; drivers with other placeholders need their own query,
+// like "INSERT INTO results VALUES ($1, $2, $3, $4, $5) ON CONFLICT (job_id) DO UPDATE ...".
+func NewSQLSink(db SQLExecer, query string) *SQLSink {
+	if query == "" {
+		query = common.SQL_SINK_QUERY
+	}
+
+	return &SQLSink{DB: db, Query: query}
+}
+
+// Store Executes the query with the result.
+func (s *SQLSink) Store(ctx context.Context, result JobResultResponse) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.ExecContext(ctx, s.Query, result.JobID, string(result.Service), string(result.Status), result.CreatedAt, string(data))
+	return err
+}
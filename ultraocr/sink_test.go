@@ -0,0 +1,130 @@
+package ultraocr
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// recordingSink Records the stored job IDs, failing for failJobID.
+type recordingSink struct {
+	mu        sync.Mutex
+	stored    []string
+	failJobID string
+}
+
+func (s *recordingSink) Store(ctx context.Context, result JobResultResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if result.JobID == s.failJobID {
+		return errors.New("sink down")
+	}
+
+	s.stored = append(s.stored, result.JobID)
+	return nil
+}
+
+type execerFunc func(ctx context.Context, query string, args ...any) (sql.Result, error)
+
+func (f execerFunc) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return f(ctx, query, args...)
+}
+
+func TestFileSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "results")
+	sink := NewFileSink(dir)
+	result := JobResultResponse{JobID: "0ujsszwN8NRY24YaXiTIE2VWDTS", Status: StatusDone, Service: "rg"}
+
+	err := sink.Store(context.Background(), result)
+	if err != nil {
+		t.Fatalf("FileSink.Store() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, result.JobID+".json"))
+	if err != nil {
+		t.Fatalf("result file error = %v", err)
+	}
+
+	var got JobResultResponse
+	err = json.Unmarshal(data, &got)
+	if err != nil || !reflect.DeepEqual(got, result) {
+		t.Errorf("result file = %s, want %+v", data, result)
+	}
+
+	err = sink.Store(context.Background(), JobResultResponse{JobID: "../x"})
+	if !errors.Is(err, common.ErrInvalidID) {
+		t.Errorf("FileSink.Store() error = %v, want %v", err, common.ErrInvalidID)
+	}
+}
+
+func TestSQLSink(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+	}{
+		{
+			name:      "default query",
+			wantQuery: common.SQL_SINK_QUERY,
+		},
+		{
+			name:      "custom query",
+			query:     "INSERT INTO results VALUES ($1, $2, $3, $4, $5)",
+			wantQuery: "INSERT INTO results VALUES ($1, $2, $3, $4, $5)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			var args []any
+			sink := NewSQLSink(execerFunc(func(ctx context.Context, q string, a ...any) (sql.Result, error) {
+				query, args = q, a
+				return nil, nil
+			}), tt.query)
+
+			result := JobResultResponse{JobID: "1", Service: "rg", Status: StatusDone, CreatedAt: "2024-01-01"}
+			err := sink.Store(context.Background(), result)
+			if err != nil {
+				t.Fatalf("SQLSink.Store() error = %v", err)
+			}
+
+			data, _ := json.Marshal(result)
+			if want := []any{"1", "rg", "done", "2024-01-01", string(data)}; !reflect.DeepEqual(args, want) {
+				t.Errorf("SQLSink.Store() args = %v, want %v", args, want)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("SQLSink.Store() query = %v, want %v", query, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestResultSinkBatch(t *testing.T) {
+	client, _, status := newFakeBatch(t)
+	sink := &recordingSink{}
+	client.SetResultSink(sink)
+
+	_, err := client.WaitForBatchDone(context.Background(), status.BatchID, true)
+	if err != nil {
+		t.Fatalf("client.WaitForBatchDone() error = %v", err)
+	}
+	if len(sink.stored) != len(status.Jobs) {
+		t.Errorf("stored = %v, want %v jobs", sink.stored, len(status.Jobs))
+	}
+
+	sink.stored = nil
+	sink.failJobID = status.Jobs[1].JobID
+	_, err = client.GetBatchResults(context.Background(), status.BatchID)
+	if !errors.Is(err, common.ErrResultSink) {
+		t.Errorf("client.GetBatchResults() error = %v, want %v", err, common.ErrResultSink)
+	}
+}
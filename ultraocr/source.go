@@ -0,0 +1,210 @@
+package ultraocr
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetUploadFunc Changes the function used to upload documents to the signed URLs,
+// keeping the rest of the submission flow (Default a PUT using the Client HTTP client).
+func (client *Client) SetUploadFunc(upload UploadFunc) {
+	client.UploadFunc = upload
+}
+
+type fileSource struct {
+	path string
+}
+
+// FileSource Creates a source reading a file from the given path.
+func FileSource(path string) Source {
+	return fileSource{path: path}
+}
+
+func (s fileSource) Name() string {
+	return filepath.Base(s.path)
+}
+
+func (s fileSource) Size() int64 {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return -1
+	}
+
+	return info.Size()
+}
+
+// Open Opens the file, streamed on uploads instead of read in memory.
+func (s fileSource) Open() (io.ReadCloser, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, common.ErrReadFile
+	}
+
+	return file, nil
+}
+
+func (s fileSource) OpenRange(offset, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, common.ErrReadFile
+	}
+
+	return sectionReadCloser{io.NewSectionReader(file, offset, length), file}, nil
+}
+
+// sectionReadCloser Reads a section of a file, closing the file.
+type sectionReadCloser struct {
+	*io.SectionReader
+	io.Closer
+}
+
+type fsSource struct {
+	fsys fs.FS
+	path string
+}
+
+// FSSource Creates a source reading a file from a file system, like an embed.FS,
+// a zip archive or a fstest.MapFS.
+func FSSource(fsys fs.FS, name string) Source {
+	return fsSource{fsys: fsys, path: name}
+}
+
+func (s fsSource) Name() string {
+	return path.Base(s.path)
+}
+
+func (s fsSource) Size() int64 {
+	info, err := fs.Stat(s.fsys, s.path)
+	if err != nil {
+		return -1
+	}
+
+	return info.Size()
+}
+
+func (s fsSource) Open() (io.ReadCloser, error) {
+	file, err := s.fsys.Open(s.path)
+	if err != nil {
+		return nil, common.ErrReadFile
+	}
+
+	return file, nil
+}
+
+// OpenRange Opens a part of the file, reading only the part on files implementing io.ReaderAt.
+func (s fsSource) OpenRange(offset, length int64) (io.ReadCloser, error) {
+	file, err := s.fsys.Open(s.path)
+	if err != nil {
+		return nil, common.ErrReadFile
+	}
+
+	if at, ok := file.(io.ReaderAt); ok {
+		return sectionReadCloser{io.NewSectionReader(at, offset, length), file}, nil
+	}
+
+	_, err = io.CopyN(io.Discard, file, offset)
+	if err != nil {
+		file.Close()
+		return nil, common.ErrReadFile
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+type bytesSource struct {
+	name string
+	data []byte
+}
+
+// BytesSource Creates a source from data in memory.
+func BytesSource(name string, data []byte) Source {
+	return bytesSource{name: name, data: data}
+}
+
+func (s bytesSource) Name() string {
+	return s.name
+}
+
+func (s bytesSource) Size() int64 {
+	return int64(len(s.data))
+}
+
+func (s bytesSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.data)), nil
+}
+
+func (s bytesSource) OpenRange(offset, length int64) (io.ReadCloser, error) {
+	end := min(offset+length, int64(len(s.data)))
+	return io.NopCloser(bytes.NewReader(s.data[offset:end])), nil
+}
+
+type stringSource struct {
+	name string
+	data string
+}
+
+// StringSource Creates a source from a string, like base64 data.
+func StringSource(name string, data string) Source {
+	return stringSource{name: name, data: data}
+}
+
+func (s stringSource) Name() string {
+	return s.name
+}
+
+func (s stringSource) Size() int64 {
+	return int64(len(s.data))
+}
+
+func (s stringSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(s.data)), nil
+}
+
+func (s stringSource) OpenRange(offset, length int64) (io.ReadCloser, error) {
+	end := min(offset+length, int64(len(s.data)))
+	return io.NopCloser(strings.NewReader(s.data[offset:end])), nil
+}
+
+type streamSource struct {
+	name string
+	open func() (io.ReadCloser, int64, error)
+	size *atomic.Int64
+}
+
+// StreamSource Creates a source from a function opening a stream and returning its size (-1 when unknown),
+// like an object of a storage service. The size is unknown until the first Open.
+func StreamSource(name string, open func() (io.ReadCloser, int64, error)) Source {
+	size := &atomic.Int64{}
+	size.Store(-1)
+
+	return streamSource{name: name, open: open, size: size}
+}
+
+func (s streamSource) Name() string {
+	return s.name
+}
+
+func (s streamSource) Size() int64 {
+	return s.size.Load()
+}
+
+func (s streamSource) Open() (io.ReadCloser, error) {
+	body, size, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+
+	s.size.Store(size)
+	return body, nil
+}
@@ -0,0 +1,227 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestSources(t *testing.T) {
+	f, _ := os.CreateTemp(t.TempDir(), "")
+	_, _ = f.WriteString("file")
+	f.Close()
+	fsys := fstest.MapFS{"docs/fs.pdf": {Data: []byte("fs file")}}
+
+	tests := []struct {
+		name     string
+		src      Source
+		wantSize int64
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "file",
+			src:      FileSource(f.Name()),
+			wantSize: 4,
+			want:     "file",
+		},
+		{
+			name:     "missing file",
+			src:      FileSource(f.Name() + "1"),
+			wantSize: -1,
+			wantErr:  true,
+		},
+		{
+			name:     "fs",
+			src:      FSSource(fsys, "docs/fs.pdf"),
+			wantSize: 7,
+			want:     "fs file",
+		},
+		{
+			name:     "missing fs file",
+			src:      FSSource(fsys, "docs/missing.pdf"),
+			wantSize: -1,
+			wantErr:  true,
+		},
+		{
+			name: "stream",
+			src: StreamSource("stream", func() (io.ReadCloser, int64, error) {
+				return io.NopCloser(strings.NewReader("stream")), 6, nil
+			}),
+			wantSize: -1,
+			want:     "stream",
+		},
+		{
+			name:     "bytes",
+			src:      BytesSource("bytes", []byte("bytes")),
+			wantSize: 5,
+			want:     "bytes",
+		},
+		{
+			name:     "string",
+			src:      StringSource("string", "string"),
+			wantSize: 6,
+			want:     "string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.src.Size(); got != tt.wantSize {
+				t.Errorf("Source.Size() = %v, want %v", got, tt.wantSize)
+			}
+
+			r, err := tt.src.Open()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Source.Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			defer r.Close()
+			got, _ := io.ReadAll(r)
+			if string(got) != tt.want {
+				t.Errorf("Source.Open() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSendFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/doc.pdf":    {Data: []byte("document")},
+		"docs/selfie.jpg": {Data: []byte("selfie")},
+	}
+	params := map[string]string{common.KEY_FACEMATCH: common.FLAG_TRUE}
+
+	recorder := &submissionRecorder{}
+	client := recorder.client()
+	_, err := client.SendJobFS(context.Background(), fsys, ServiceRG, "docs/doc.pdf", "docs/selfie.jpg", "", nil, params)
+	if err != nil {
+		t.Fatalf("client.SendJobFS() error = %v", err)
+	}
+	want := map[string]string{"/document": "document", "/selfie": "selfie"}
+	if !reflect.DeepEqual(recorder.uploads, want) {
+		t.Errorf("client.SendJobFS() uploads = %v, want %v", recorder.uploads, want)
+	}
+
+	recorder = &submissionRecorder{}
+	client = recorder.client()
+	_, err = client.SendBatchFS(context.Background(), fsys, ServiceRG, "docs/doc.pdf", nil, nil)
+	if err != nil {
+		t.Fatalf("client.SendBatchFS() error = %v", err)
+	}
+	want = map[string]string{"/document": "document"}
+	if !reflect.DeepEqual(recorder.uploads, want) {
+		t.Errorf("client.SendBatchFS() uploads = %v, want %v", recorder.uploads, want)
+	}
+
+	_, err = client.SendBatchFS(context.Background(), fsys, ServiceRG, "docs/missing.pdf", nil, nil)
+	if !errors.Is(err, common.ErrReadFile) {
+		t.Errorf("client.SendBatchFS() error = %v, want %v", err, common.ErrReadFile)
+	}
+}
+
+func TestUploadFunc(t *testing.T) {
+	var mu sync.Mutex
+	uploaded := map[string]string{}
+	client := &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.Method == http.MethodPut {
+					return nil, errors.New("default upload used")
+				}
+
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123","urls":{"document":"doc","selfie":"selfie","extra_document":"extra"}}`))),
+				}, nil
+			},
+		},
+	}
+	client.SetUploadFunc(func(ctx context.Context, url string, src Source) error {
+		r, err := src.Open()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		data, _ := io.ReadAll(r)
+		mu.Lock()
+		uploaded[url] = string(data)
+		mu.Unlock()
+		return nil
+	})
+
+	params := map[string]string{
+		common.KEY_FACEMATCH: common.FLAG_TRUE,
+		common.KEY_EXTRA:     common.FLAG_TRUE,
+	}
+	_, err := client.SendJobBase64(context.Background(), "rg", "doc64", "selfie64", "extra64", nil, params)
+	if err != nil {
+		t.Fatalf("client.SendJobBase64() error = %v", err)
+	}
+
+	want := map[string]string{
+		"doc":    "doc64",
+		"selfie": "selfie64",
+		"extra":  "extra64",
+	}
+	if !reflect.DeepEqual(uploaded, want) {
+		t.Errorf("uploaded = %v, want %v", uploaded, want)
+	}
+}
+
+func TestUploadContentLength(t *testing.T) {
+	f, _ := os.CreateTemp(t.TempDir(), "")
+	_, _ = f.Write(bytes.Repeat([]byte("a"), 1<<20))
+	f.Close()
+
+	tests := []struct {
+		name       string
+		src        Source
+		wantLength int64
+		wantFile   bool
+	}{
+		{name: "file", src: FileSource(f.Name()), wantLength: 1 << 20, wantFile: true},
+		{name: "string", src: StringSource("string", "string"), wantLength: 6},
+		{name: "empty", src: BytesSource("empty", nil), wantLength: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var length int64
+			var streamed bool
+			var received int64
+			client := NewClient()
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					length = req.ContentLength
+					_, streamed = req.Body.(*os.File)
+					received, _ = io.Copy(io.Discard, req.Body)
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			})
+
+			err := client.UploadSource(context.Background(), "https://upload.example.com", tt.src)
+			if err != nil {
+				t.Fatalf("client.UploadSource() error = %v", err)
+			}
+			if length != tt.wantLength || received != tt.wantLength {
+				t.Errorf("Content-Length = %v with %v bytes, want %v", length, received, tt.wantLength)
+			}
+			if streamed != tt.wantFile {
+				t.Errorf("body streamed from file = %v, want %v", streamed, tt.wantFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package azblob implements sources reading documents from Azure Blob Storage containers.
+package azblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"path"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// ErrInvalidURL Error returned for URLs not in the https://account.blob.core.windows.net/container/blob format.
+var ErrInvalidURL = errors.New("invalid blob URL")
+
+// API Reads blobs, returning the blob body and its size (-1 when unknown).
+type API interface {
+	DownloadBlob(ctx context.Context, container, blob string) (io.ReadCloser, int64, error)
+}
+
+// APIFunc Adapts a function to the API interface, like a call to the Azure SDK DownloadStream.
+type APIFunc func(ctx context.Context, container, blob string) (io.ReadCloser, int64, error)
+
+// DownloadBlob Calls the function.
+func (f APIFunc) DownloadBlob(ctx context.Context, container, blob string) (io.ReadCloser, int64, error) {
+	return f(ctx, container, blob)
+}
+
+// Blob The location of a blob on the storage account of the API.
+type Blob struct {
+	Container string
+	Name      string
+}
+
+// ParseURL Parses a blob URL, like https://account.blob.core.windows.net/container/dir/blob.pdf.
+// The account and any query, like a SAS token, are ignored, as the API is bound to an account.
+func ParseURL(url string) (Blob, error) {
+	parsed, err := neturl.Parse(url)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return Blob{}, fmt.Errorf("%w: %q", ErrInvalidURL, url)
+	}
+
+	container, name, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if container == "" || name == "" {
+		return Blob{}, fmt.Errorf("%w: %q has no container or blob name", ErrInvalidURL, url)
+	}
+
+	return Blob{Container: container, Name: name}, nil
+}
+
+// String Returns the container/name path of the blob.
+func (b Blob) String() string {
+	return fmt.Sprintf("%s/%s", b.Container, b.Name)
+}
+
+// Source Creates a source streaming the blob, downloaded again on each Open (e.g. on upload retries).
+func Source(ctx context.Context, api API, blob Blob) ultraocr.Source {
+	return ultraocr.StreamSource(path.Base(blob.Name), func() (io.ReadCloser, int64, error) {
+		body, size, err := api.DownloadBlob(ctx, blob.Container, blob.Name)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %s: %w", common.ErrReadFile, blob, err)
+		}
+
+		return body, size, nil
+	})
+}
+
+// SourceURL Creates a source streaming the blob of a blob URL.
+func SourceURL(ctx context.Context, api API, url string) (ultraocr.Source, error) {
+	blob, err := ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return Source(ctx, api, blob), nil
+}
+
+// SendJob Sends a job reading the files from blobs, like ultraocr.Client SendJob does with paths.
+// Requires the files (facematch and extra file if requested on params) blob URLs.
+func SendJob(ctx context.Context,
+	client *ultraocr.Client,
+	api API,
+	service ultraocr.Service,
+	fileURL,
+	facematchFileURL,
+	extraFileURL string,
+	metadata map[string]any,
+	opts ultraocr.JobOptions,
+) (ultraocr.CreatedResponse, error) {
+	req := ultraocr.JobRequest{Service: service, Metadata: metadata, Options: opts}
+
+	var err error
+	req.Document, err = SourceURL(ctx, api, fileURL)
+	if err != nil {
+		return ultraocr.CreatedResponse{}, err
+	}
+
+	if opts.Facematch {
+		req.Selfie, err = SourceURL(ctx, api, facematchFileURL)
+		if err != nil {
+			return ultraocr.CreatedResponse{}, err
+		}
+	}
+
+	if opts.ExtraDocument {
+		req.ExtraDocument, err = SourceURL(ctx, api, extraFileURL)
+		if err != nil {
+			return ultraocr.CreatedResponse{}, err
+		}
+	}
+
+	return client.SubmitJob(ctx, req)
+}
+
+// SendBatch Sends a batch reading the file from a blob, like ultraocr.Client SendBatch does with a path.
+// Requires the file blob URL.
+func SendBatch(ctx context.Context,
+	client *ultraocr.Client,
+	api API,
+	service ultraocr.Service,
+	fileURL string,
+	metadata []map[string]any,
+	opts ultraocr.JobOptions,
+) (ultraocr.CreatedResponse, error) {
+	document, err := SourceURL(ctx, api, fileURL)
+	if err != nil {
+		return ultraocr.CreatedResponse{}, err
+	}
+
+	return client.SubmitBatch(ctx, ultraocr.BatchRequest{
+		Service:  service,
+		Document: document,
+		Metadata: metadata,
+		Options:  opts,
+	})
+}
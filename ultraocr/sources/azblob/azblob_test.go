@@ -0,0 +1,85 @@
+package azblob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+const account = "https://account.blob.core.windows.net"
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    Blob
+		wantErr bool
+	}{
+		{url: account + "/docs/2024/doc.pdf", want: Blob{Container: "docs", Name: "2024/doc.pdf"}},
+		{url: account + "/docs/doc.pdf?sv=2022&sig=abc", want: Blob{Container: "docs", Name: "doc.pdf"}},
+		{url: account + "/docs", wantErr: true},
+		{url: "docs/doc.pdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := ParseURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	blobs := map[string]string{"docs/doc.pdf": "document"}
+	var reads []string
+	api := APIFunc(func(ctx context.Context, container, blob string) (io.ReadCloser, int64, error) {
+		data, ok := blobs[container+"/"+blob]
+		if !ok {
+			return nil, 0, errors.New("BlobNotFound")
+		}
+
+		reads = append(reads, blob)
+		return io.NopCloser(strings.NewReader(data)), int64(len(data)), nil
+	})
+
+	client := ultraocr.NewClient()
+	client.SetHttpClient(ultraocrtest.NewFakeAPI(nil))
+	client.Token = "token"
+	client.ExpiresAt = time.Now().Add(time.Hour)
+	ctx := context.Background()
+
+	_, err := SendJob(ctx, &client, api, ultraocr.ServiceRG, account+"/docs/doc.pdf", "", "", nil, ultraocr.JobOptions{})
+	if err != nil {
+		t.Fatalf("SendJob() error = %v", err)
+	}
+
+	_, err = SendBatch(ctx, &client, api, ultraocr.ServiceRG, account+"/docs/doc.pdf", nil, ultraocr.JobOptions{})
+	if err != nil {
+		t.Fatalf("SendBatch() error = %v", err)
+	}
+
+	if len(reads) != 2 {
+		t.Errorf("blobs read = %v, want 2 reads", reads)
+	}
+
+	_, err = SendJob(ctx, &client, api, ultraocr.ServiceRG, account+"/docs/doc.pdf", "", account+"/docs/missing.pdf", nil,
+		ultraocr.JobOptions{ExtraDocument: true})
+	if !errors.Is(err, common.ErrReadFile) {
+		t.Errorf("SendJob() error = %v, want %v", err, common.ErrReadFile)
+	}
+
+	_, err = SendBatch(ctx, &client, api, ultraocr.ServiceRG, "docs/doc.pdf", nil, ultraocr.JobOptions{})
+	if !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("SendBatch() error = %v, want %v", err, ErrInvalidURL)
+	}
+}
@@ -0,0 +1,139 @@
+// Package s3 implements sources streaming S3 objects to the UltraOCR upload URLs.
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"path"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// ErrInvalidURI Error returned for URIs not in the s3://bucket/key format.
+var ErrInvalidURI = errors.New("invalid S3 URI")
+
+// API Reads S3 objects, returning the object body and its size (-1 when unknown).
+type API interface {
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, int64, error)
+}
+
+// APIFunc Adapts a function to the API interface, like a call to the AWS SDK GetObject.
+type APIFunc func(ctx context.Context, bucket, key string) (io.ReadCloser, int64, error)
+
+// GetObject Calls the function.
+func (f APIFunc) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, int64, error) {
+	return f(ctx, bucket, key)
+}
+
+// Object The location of an S3 object.
+type Object struct {
+	Bucket string
+	Key    string
+}
+
+// ParseURI Parses a s3://bucket/key URI.
+func ParseURI(uri string) (Object, error) {
+	parsed, err := neturl.Parse(uri)
+	if err != nil || parsed.Scheme != "s3" || parsed.Host == "" {
+		return Object{}, fmt.Errorf("%w: %q", ErrInvalidURI, uri)
+	}
+
+	key := strings.TrimPrefix(parsed.Path, "/")
+	if key == "" {
+		return Object{}, fmt.Errorf("%w: %q has no key", ErrInvalidURI, uri)
+	}
+
+	return Object{Bucket: parsed.Host, Key: key}, nil
+}
+
+// String Returns the s3://bucket/key URI of the object.
+func (o Object) String() string {
+	return fmt.Sprintf("s3://%s/%s", o.Bucket, o.Key)
+}
+
+// Source Creates a source streaming the object, read again on each Open (e.g. on upload retries).
+func Source(ctx context.Context, api API, object Object) ultraocr.Source {
+	return ultraocr.StreamSource(path.Base(object.Key), func() (io.ReadCloser, int64, error) {
+		body, size, err := api.GetObject(ctx, object.Bucket, object.Key)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %s: %w", common.ErrReadFile, object, err)
+		}
+
+		return body, size, nil
+	})
+}
+
+// SourceURI Creates a source streaming the object of a s3://bucket/key URI.
+func SourceURI(ctx context.Context, api API, uri string) (ultraocr.Source, error) {
+	object, err := ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return Source(ctx, api, object), nil
+}
+
+// SendJob Sends a job reading the files from S3, like ultraocr.Client SendJob does with paths.
+// Requires the files (facematch and extra file if requested on params) s3://bucket/key URIs.
+func SendJob(ctx context.Context,
+	client *ultraocr.Client,
+	api API,
+	service ultraocr.Service,
+	fileURI,
+	facematchFileURI,
+	extraFileURI string,
+	metadata map[string]any,
+	opts ultraocr.JobOptions,
+) (ultraocr.CreatedResponse, error) {
+	req := ultraocr.JobRequest{Service: service, Metadata: metadata, Options: opts}
+
+	var err error
+	req.Document, err = SourceURI(ctx, api, fileURI)
+	if err != nil {
+		return ultraocr.CreatedResponse{}, err
+	}
+
+	if opts.Facematch {
+		req.Selfie, err = SourceURI(ctx, api, facematchFileURI)
+		if err != nil {
+			return ultraocr.CreatedResponse{}, err
+		}
+	}
+
+	if opts.ExtraDocument {
+		req.ExtraDocument, err = SourceURI(ctx, api, extraFileURI)
+		if err != nil {
+			return ultraocr.CreatedResponse{}, err
+		}
+	}
+
+	return client.SubmitJob(ctx, req)
+}
+
+// SendBatch Sends a batch reading the file from S3, like ultraocr.Client SendBatch does with a path.
+// Requires the file s3://bucket/key URI.
+func SendBatch(ctx context.Context,
+	client *ultraocr.Client,
+	api API,
+	service ultraocr.Service,
+	fileURI string,
+	metadata []map[string]any,
+	opts ultraocr.JobOptions,
+) (ultraocr.CreatedResponse, error) {
+	document, err := SourceURI(ctx, api, fileURI)
+	if err != nil {
+		return ultraocr.CreatedResponse{}, err
+	}
+
+	return client.SubmitBatch(ctx, ultraocr.BatchRequest{
+		Service:  service,
+		Document: document,
+		Metadata: metadata,
+		Options:  opts,
+	})
+}
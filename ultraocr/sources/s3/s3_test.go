@@ -0,0 +1,86 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    Object
+		wantErr bool
+	}{
+		{uri: "s3://bucket/docs/doc.pdf", want: Object{Bucket: "bucket", Key: "docs/doc.pdf"}},
+		{uri: "s3://bucket/", wantErr: true},
+		{uri: "s3:///doc.pdf", wantErr: true},
+		{uri: "https://bucket.s3.amazonaws.com/doc.pdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			got, err := ParseURI(tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseURI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseURI() = %v, want %v", got, tt.want)
+			}
+			if err == nil && got.String() != tt.uri {
+				t.Errorf("Object.String() = %v, want %v", got.String(), tt.uri)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	objects := map[string]string{"bucket/doc.pdf": "document", "bucket/selfie.jpg": "selfie"}
+	var reads []string
+	api := APIFunc(func(ctx context.Context, bucket, key string) (io.ReadCloser, int64, error) {
+		data, ok := objects[bucket+"/"+key]
+		if !ok {
+			return nil, 0, errors.New("NoSuchKey")
+		}
+
+		reads = append(reads, key)
+		return io.NopCloser(strings.NewReader(data)), int64(len(data)), nil
+	})
+
+	client := ultraocr.NewClient()
+	client.SetHttpClient(ultraocrtest.NewFakeAPI(nil))
+	client.Token = "token"
+	client.ExpiresAt = time.Now().Add(time.Hour)
+	ctx := context.Background()
+
+	_, err := SendJob(ctx, &client, api, ultraocr.ServiceRG, "s3://bucket/doc.pdf", "", "", nil, ultraocr.JobOptions{})
+	if err != nil {
+		t.Fatalf("SendJob() error = %v", err)
+	}
+
+	_, err = SendBatch(ctx, &client, api, ultraocr.ServiceRG, "s3://bucket/doc.pdf", nil, ultraocr.JobOptions{})
+	if err != nil {
+		t.Fatalf("SendBatch() error = %v", err)
+	}
+
+	if len(reads) != 2 {
+		t.Errorf("objects read = %v, want 2 reads", reads)
+	}
+
+	_, err = SendJob(ctx, &client, api, ultraocr.ServiceRG, "s3://bucket/doc.pdf", "s3://bucket/missing.jpg", "", nil,
+		ultraocr.JobOptions{Facematch: true})
+	if !errors.Is(err, common.ErrReadFile) {
+		t.Errorf("SendJob() error = %v, want %v", err, common.ErrReadFile)
+	}
+
+	_, err = SendBatch(ctx, &client, api, ultraocr.ServiceRG, "bucket/doc.pdf", nil, ultraocr.JobOptions{})
+	if !errors.Is(err, ErrInvalidURI) {
+		t.Errorf("SendBatch() error = %v, want %v", err, ErrInvalidURI)
+	}
+}
@@ -0,0 +1,74 @@
+package ultraocr
+
+import "github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+
+// Status Status of a job or batch.
+type Status string
+
+// Job and batch statuses.
+const (
+	StatusWaiting    Status = "waiting"
+	StatusProcessing Status = "processing"
+	StatusValidating Status = "validating"
+	StatusDone       Status = common.STATUS_DONE
+	StatusError      Status = common.STATUS_ERROR
+	StatusCancelled  Status = common.STATUS_CANCELLED
+)
+
+// IsTerminal Checks if the status will not change anymore (done, error or cancelled).
+func (s Status) IsTerminal() bool {
+	return s == StatusDone || s == StatusError || s == StatusCancelled
+}
+
+// IsDone Checks if the job or batch finished successfully.
+func (s Status) IsDone() bool {
+	return s == StatusDone
+}
+
+// IsError Checks if the job or batch failed.
+func (s Status) IsError() bool {
+	return s == StatusError
+}
+
+// IsCancelled Checks if the job or batch was cancelled.
+func (s Status) IsCancelled() bool {
+	return s == StatusCancelled
+}
+
+// IsKnown Checks if the status is one of the statuses known by the SDK.
+func (s Status) IsKnown() bool {
+	switch s {
+	case StatusWaiting, StatusProcessing, StatusValidating, StatusDone, StatusError, StatusCancelled:
+		return true
+	}
+
+	return false
+}
+
+// String Returns the status as sent by the API.
+func (s Status) String() string {
+	return string(s)
+}
+
+// Summary Counts the batch jobs per status, listing the failed jobs in the batch order.
+func (b BatchStatusResponse) Summary() BatchSummary {
+	summary := BatchSummary{
+		Total:   len(b.Jobs),
+		Counts:  map[Status]int{},
+		Errored: []string{},
+	}
+
+	for _, job := range b.Jobs {
+		summary.Counts[job.Status] += 1
+		if job.Status.IsError() {
+			summary.Errored = append(summary.Errored, job.JobID)
+		}
+	}
+
+	return summary
+}
+
+// Finished Returns how many jobs will not change anymore (done, error or cancelled).
+func (s BatchSummary) Finished() int {
+	return s.Counts[StatusDone] + s.Counts[StatusError] + s.Counts[StatusCancelled]
+}
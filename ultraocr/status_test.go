@@ -0,0 +1,107 @@
+package ultraocr
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		status       Status
+		wantTerminal bool
+		wantDone     bool
+		wantError    bool
+		wantCanceled bool
+		wantKnown    bool
+	}{
+		{status: StatusWaiting, wantKnown: true},
+		{status: StatusProcessing, wantKnown: true},
+		{status: StatusValidating, wantKnown: true},
+		{status: StatusDone, wantTerminal: true, wantDone: true, wantKnown: true},
+		{status: StatusError, wantTerminal: true, wantError: true, wantKnown: true},
+		{status: StatusCancelled, wantTerminal: true, wantCanceled: true, wantKnown: true},
+		{status: "archived"},
+		{status: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if got := tt.status.IsTerminal(); got != tt.wantTerminal {
+				t.Errorf("Status.IsTerminal() = %v, want %v", got, tt.wantTerminal)
+			}
+			if got := tt.status.IsDone(); got != tt.wantDone {
+				t.Errorf("Status.IsDone() = %v, want %v", got, tt.wantDone)
+			}
+			if got := tt.status.IsError(); got != tt.wantError {
+				t.Errorf("Status.IsError() = %v, want %v", got, tt.wantError)
+			}
+			if got := tt.status.IsCancelled(); got != tt.wantCanceled {
+				t.Errorf("Status.IsCancelled() = %v, want %v", got, tt.wantCanceled)
+			}
+			if got := tt.status.IsKnown(); got != tt.wantKnown {
+				t.Errorf("Status.IsKnown() = %v, want %v", got, tt.wantKnown)
+			}
+		})
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	var result JobResultResponse
+	err := json.Unmarshal([]byte(`{"job_ksuid":"123","status":"validating"}`), &result)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if result.Status != StatusValidating || result.Status.IsTerminal() {
+		t.Errorf("JobResultResponse.Status = %v, want %v not terminal", result.Status, StatusValidating)
+	}
+
+	data, err := json.Marshal(BatchStatusResponse{BatchID: "123", Status: StatusDone})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"batch_ksuid":"123","created_at":"","service":"","status":"done","jobs":null}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestBatchSummary(t *testing.T) {
+	tests := []struct {
+		name         string
+		jobs         []BatchStatusJobs
+		want         BatchSummary
+		wantFinished int
+	}{
+		{
+			name: "empty",
+			want: BatchSummary{Counts: map[Status]int{}, Errored: []string{}},
+		},
+		{
+			name: "mixed",
+			jobs: []BatchStatusJobs{
+				{JobID: "a", Status: StatusDone},
+				{JobID: "b", Status: StatusError},
+				{JobID: "c", Status: StatusProcessing},
+				{JobID: "d", Status: StatusDone},
+				{JobID: "e", Status: StatusWaiting},
+				{JobID: "f", Status: StatusError},
+			},
+			want: BatchSummary{
+				Total:   6,
+				Counts:  map[Status]int{StatusDone: 2, StatusError: 2, StatusProcessing: 1, StatusWaiting: 1},
+				Errored: []string{"b", "f"},
+			},
+			wantFinished: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BatchStatusResponse{Jobs: tt.jobs}.Summary()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BatchStatusResponse.Summary() = %+v, want %+v", got, tt.want)
+			}
+			if got.Finished() != tt.wantFinished {
+				t.Errorf("BatchSummary.Finished() = %v, want %v", got.Finished(), tt.wantFinished)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strings"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// statusURLRef Resource and IDs of a status URL.
+type statusURLRef struct {
+	resource string
+	batchID  string
+	jobID    string
+}
+
+// parseStatusURL Parses a status URL returned on job and batch creation, like
+// ".../ocr/job/result/JOB_ID", ".../ocr/job/result/BATCH_ID/JOB_ID" or ".../ocr/batch/status/BATCH_ID".
+// The URL must be on the Client BaseURL (same scheme, host and path prefix) and have valid IDs, so
+// a tampered URL, like one from a webhook payload, never receives the Client token.
+func (client *Client) parseStatusURL(statusURL string) (statusURLRef, error) {
+	invalid := fmt.Errorf("%w: %q", common.ErrInvalidStatusURL, stripQuery(statusURL))
+
+	u, err := neturl.Parse(statusURL)
+	if err != nil {
+		return statusURLRef{}, invalid
+	}
+
+	base, err := neturl.Parse(client.BaseURL)
+	if err != nil || !strings.EqualFold(u.Scheme, base.Scheme) || !strings.EqualFold(u.Host, base.Host) || u.User != nil {
+		return statusURLRef{}, fmt.Errorf("%w: %q is not on the base URL", common.ErrInvalidStatusURL, stripQuery(statusURL))
+	}
+
+	path, ok := strings.CutPrefix(u.Path, strings.TrimRight(base.Path, "/")+"/ocr/")
+	if !ok {
+		return statusURLRef{}, fmt.Errorf("%w: %q is not on the base URL", common.ErrInvalidStatusURL, stripQuery(statusURL))
+	}
+
+	var ref statusURLRef
+	parts := strings.Split(strings.TrimRight(path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == common.RESOURCE_BATCH && parts[1] == "status":
+		ref = statusURLRef{resource: common.RESOURCE_BATCH, batchID: parts[2]}
+	case len(parts) == 4 && parts[0] == common.RESOURCE_JOB && parts[1] == "result":
+		ref = statusURLRef{resource: common.RESOURCE_JOB, batchID: parts[2], jobID: parts[3]}
+	case len(parts) == 3 && parts[0] == common.RESOURCE_JOB && parts[1] == "result":
+		ref = statusURLRef{resource: common.RESOURCE_JOB, batchID: parts[2], jobID: parts[2]}
+	default:
+		return statusURLRef{}, invalid
+	}
+
+	err = ValidateID(ref.batchID)
+	if err == nil && ref.jobID != "" {
+		err = ValidateID(ref.jobID)
+	}
+
+	if err != nil {
+		return statusURLRef{}, fmt.Errorf("%w: %w", invalid, err)
+	}
+
+	return ref, nil
+}
+
+// GetFromStatusURL Gets the job result or batch status on the status URL returned on the job or batch
+// creation. Fails with ErrInvalidStatusURL if it is not a job or batch status URL of the Client BaseURL.
+func (client *Client) GetFromStatusURL(ctx context.Context, statusURL string) (StatusURLResponse, error) {
+	ref, err := client.parseStatusURL(statusURL)
+	if err != nil {
+		return StatusURLResponse{}, err
+	}
+
+	if ref.resource == common.RESOURCE_BATCH {
+		batch, err := client.GetBatchStatus(ctx, ref.batchID)
+		if err != nil {
+			return StatusURLResponse{}, err
+		}
+
+		return StatusURLResponse{Resource: ref.resource, Status: batch.Status, Batch: &batch}, nil
+	}
+
+	job, err := client.GetJobResult(ctx, ref.batchID, ref.jobID)
+	if err != nil {
+		return StatusURLResponse{}, err
+	}
+
+	return StatusURLResponse{Resource: ref.resource, Status: job.Status, Job: &job}, nil
+}
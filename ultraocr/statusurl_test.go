@@ -0,0 +1,302 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestParseStatusURL(t *testing.T) {
+	const (
+		batchID = "0ujsszwN8NRY24YaXiTIE2VWDTS"
+		jobID   = "0ujsswThIGTUYm2K8FjOOfXtY1K"
+	)
+
+	tests := []struct {
+		name      string
+		baseURL   string
+		statusURL string
+		want      statusURLRef
+		wantErr   bool
+	}{
+		{
+			name:      "job",
+			statusURL: "https://ultraocr.apis.nuveo.ai/v2/ocr/job/result/" + jobID,
+			want:      statusURLRef{resource: common.RESOURCE_JOB, batchID: jobID, jobID: jobID},
+		},
+		{
+			name:      "batch job",
+			statusURL: "https://ultraocr.apis.nuveo.ai/v2/ocr/job/result/" + batchID + "/" + jobID + "/",
+			want:      statusURLRef{resource: common.RESOURCE_JOB, batchID: batchID, jobID: jobID},
+		},
+		{
+			name:      "batch",
+			baseURL:   "https://gateway.internal/ultraocr/v2",
+			statusURL: "https://gateway.internal/ultraocr/v2/ocr/batch/status/" + batchID,
+			want:      statusURLRef{resource: common.RESOURCE_BATCH, batchID: batchID},
+		},
+		{
+			name:      "foreign host",
+			statusURL: "https://attacker.example.com/v2/ocr/job/result/" + jobID,
+			wantErr:   true,
+		},
+		{
+			name:      "host suffix",
+			statusURL: "https://ultraocr.apis.nuveo.ai.example.com/v2/ocr/job/result/" + jobID,
+			wantErr:   true,
+		},
+		{
+			name:      "other scheme",
+			statusURL: "http://ultraocr.apis.nuveo.ai/v2/ocr/job/result/" + jobID,
+			wantErr:   true,
+		},
+		{
+			name:      "user info",
+			statusURL: "https://user@ultraocr.apis.nuveo.ai/v2/ocr/job/result/" + jobID,
+			wantErr:   true,
+		},
+		{
+			name:      "outside the base path",
+			baseURL:   "https://gateway.internal/ultraocr/v2",
+			statusURL: "https://gateway.internal/other/v2/ocr/batch/status/" + batchID,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid ID",
+			statusURL: "https://ultraocr.apis.nuveo.ai/v2/ocr/job/result/123",
+			wantErr:   true,
+		},
+		{
+			name:      "traversal ID",
+			statusURL: "https://ultraocr.apis.nuveo.ai/v2/ocr/job/result/..%2F..%2Fusage",
+			wantErr:   true,
+		},
+		{
+			name:      "missing ID",
+			statusURL: "https://ultraocr.apis.nuveo.ai/v2/ocr/job/result/",
+			wantErr:   true,
+		},
+		{
+			name:      "unknown path",
+			statusURL: "https://ultraocr.apis.nuveo.ai/v2/ocr/job/send/rg",
+			wantErr:   true,
+		},
+		{
+			name:      "relative",
+			statusURL: "/v2/ocr/job/result/" + jobID,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			if tt.baseURL != "" {
+				_ = client.SetBaseURL(tt.baseURL)
+			}
+
+			got, err := client.parseStatusURL(tt.statusURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("client.parseStatusURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, common.ErrInvalidStatusURL) {
+				t.Errorf("client.parseStatusURL() error = %v, want %v", err, common.ErrInvalidStatusURL)
+			}
+			if got != tt.want {
+				t.Errorf("client.parseStatusURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitFromStatusURL(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		create  func(client *Client) (string, error)
+		wantErr error
+	}{
+		{
+			name: "single step job",
+			create: func(client *Client) (string, error) {
+				res, err := client.SendJobSingleStep(context.Background(), "rg", "ZmlsZQ==", "", "", nil, nil)
+				return res.StatusURL, err
+			},
+		},
+		{
+			name: "signed url job",
+			create: func(client *Client) (string, error) {
+				res, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_JOB, nil, nil)
+				return res.StatusURL, err
+			},
+		},
+		{
+			name: "batch",
+			create: func(client *Client) (string, error) {
+				res, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_BATCH, nil, nil)
+				return res.StatusURL, err
+			},
+			wantErr: common.ErrInvalidStatusURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewAutoClock(start)
+			api := ultraocrtest.NewFakeAPI(clock)
+			api.ProcessingTime = 5 * time.Second
+			client := newFakeClient(clock, api)
+
+			statusURL, err := tt.create(&client)
+			if err != nil {
+				t.Fatalf("create error = %v", err)
+			}
+
+			result, err := client.WaitFromStatusURL(context.Background(), statusURL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.WaitFromStatusURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && result.Status != common.STATUS_DONE {
+				t.Errorf("client.WaitFromStatusURL() status = %v, want %v", result.Status, common.STATUS_DONE)
+			}
+		})
+	}
+}
+
+func TestStatusURLForeignHost(t *testing.T) {
+	statusURL := "https://attacker.example.com/v2/ocr/job/result/0ujsszwN8NRY24YaXiTIE2VWDTS"
+
+	requests := 0
+	client := NewClient()
+	client.SetAutoRefresh("id", "secret", 60)
+	client.SetHttpClient(&ClientMock{MockDo: func(req *http.Request) (*http.Response, error) {
+		requests++
+		return nil, errors.New("unexpected request to " + req.URL.String())
+	}})
+
+	calls := map[string]func() error{
+		"WaitFromStatusURL": func() error {
+			_, err := client.WaitFromStatusURL(context.Background(), statusURL)
+			return err
+		},
+		"GetFromStatusURL": func() error {
+			_, err := client.GetFromStatusURL(context.Background(), statusURL)
+			return err
+		},
+		"PollStatusURL": func() error {
+			var result JobResultResponse
+			return client.PollStatusURL(context.Background(), client.NewPoller(common.RESOURCE_JOB, ""), statusURL, &result)
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			err := call()
+			if !errors.Is(err, common.ErrInvalidStatusURL) {
+				t.Errorf("client.%s() error = %v, want %v", name, err, common.ErrInvalidStatusURL)
+			}
+		})
+	}
+
+	if requests != 0 {
+		t.Errorf("requests = %d, want none, not even the authentication", requests)
+	}
+}
+
+func TestWaitForJob(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	api.ProcessingTime = 5 * time.Second
+	client := newFakeClient(clock, api)
+	jobID := api.AddJob("rg", common.STATUS_DONE)
+
+	result, err := client.WaitForJob(context.Background(), jobID)
+	if err != nil {
+		t.Fatalf("client.WaitForJob() error = %v", err)
+	}
+	if result.JobID != jobID || result.Status != common.STATUS_DONE {
+		t.Errorf("client.WaitForJob() = %+v, want job %v done", result, jobID)
+	}
+}
+
+func TestGetFromStatusURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		create       func(client *Client) (string, error)
+		wantResource string
+		wantErr      error
+	}{
+		{
+			name: "single step job",
+			create: func(client *Client) (string, error) {
+				res, err := client.SendJobSingleStep(context.Background(), "rg", "ZmlsZQ==", "", "", nil, nil)
+				return res.StatusURL, err
+			},
+			wantResource: common.RESOURCE_JOB,
+		},
+		{
+			name: "signed url job",
+			create: func(client *Client) (string, error) {
+				res, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_JOB, nil, nil)
+				return res.StatusURL, err
+			},
+			wantResource: common.RESOURCE_JOB,
+		},
+		{
+			name: "signed url batch",
+			create: func(client *Client) (string, error) {
+				res, err := client.GenerateSignedUrl(context.Background(), "rg", common.RESOURCE_BATCH, nil, nil)
+				return res.StatusURL, err
+			},
+			wantResource: common.RESOURCE_BATCH,
+		},
+		{
+			name: "invalid",
+			create: func(client *Client) (string, error) {
+				return client.BaseURL + "/ocr/job/send/rg", nil
+			},
+			wantErr: common.ErrInvalidStatusURL,
+		},
+		{
+			name: "foreign host",
+			create: func(client *Client) (string, error) {
+				return "https://attacker.example.com/v2/ocr/job/result/0ujsszwN8NRY24YaXiTIE2VWDTS", nil
+			},
+			wantErr: common.ErrInvalidStatusURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := ultraocrtest.NewFakeAPI(nil)
+			client := newFakeClient(nil, api)
+
+			statusURL, err := tt.create(&client)
+			if err != nil {
+				t.Fatalf("create error = %v", err)
+			}
+
+			got, err := client.GetFromStatusURL(context.Background(), statusURL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.GetFromStatusURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.Resource != tt.wantResource {
+				t.Errorf("client.GetFromStatusURL() resource = %v, want %v", got.Resource, tt.wantResource)
+			}
+
+			switch got.Resource {
+			case common.RESOURCE_JOB:
+				if got.Job == nil || got.Batch != nil || got.Job.Status != got.Status {
+					t.Errorf("client.GetFromStatusURL() = %+v, want a job", got)
+				}
+			case common.RESOURCE_BATCH:
+				if got.Batch == nil || got.Job != nil || got.Batch.Status != got.Status {
+					t.Errorf("client.GetFromStatusURL() = %+v, want a batch", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetStore Changes the Store used to persist the SDK state, like export checkpoints.
+func (client *Client) SetStore(store Store) {
+	client.Store = store
+}
+
+// MemoryStore Store keeping values in memory.
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+// NewMemoryStore Creates an in memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		values: map[string][]byte{},
+	}
+}
+
+// Get Returns the value saved with the key.
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	return append([]byte(nil), value...), ok, nil
+}
+
+// Put Saves the value with the key.
+func (s *MemoryStore) Put(ctx context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete Removes the value saved with the key.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.values, key)
+	return nil
+}
+
+// FileStore Store keeping each value on a file of a directory, replaced atomically on writes.
+type FileStore struct {
+	Dir string
+}
+
+// NewFileStore Creates a Store saving values on the given directory, created if needed.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{Dir: dir}
+}
+
+// path Returns the file of a key, hex encoded so any key is a valid file name.
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.Dir, hex.EncodeToString([]byte(key)))
+}
+
+// Get Returns the value saved with the key.
+func (s *FileStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return data, true, nil
+}
+
+// Put Saves the value with the key.
+func (s *FileStore) Put(ctx context.Context, key string, value []byte) error {
+	err := os.MkdirAll(s.Dir, 0o700)
+	if err == nil {
+		err = writeFileAtomic(s.path(key), value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return nil
+}
+
+// Delete Removes the value saved with the key.
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %w", common.ErrStore, err)
+	}
+
+	return nil
+}
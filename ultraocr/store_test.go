@@ -0,0 +1,59 @@
+package ultraocr
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStores(t *testing.T) {
+	tests := []struct {
+		name  string
+		store Store
+	}{
+		{
+			name:  "memory",
+			store: NewMemoryStore(),
+		},
+		{
+			name:  "file",
+			store: NewFileStore(filepath.Join(t.TempDir(), "store")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, ok, err := tt.store.Get(ctx, "a/b")
+			if err != nil || ok {
+				t.Fatalf("Store.Get() ok = %v, error = %v, want missing", ok, err)
+			}
+
+			err = tt.store.Put(ctx, "a/b", []byte("value"))
+			if err != nil {
+				t.Fatalf("Store.Put() error = %v", err)
+			}
+
+			got, ok, err := tt.store.Get(ctx, "a/b")
+			if err != nil || !ok || !reflect.DeepEqual(got, []byte("value")) {
+				t.Errorf("Store.Get() = %s, %v, %v, want value", got, ok, err)
+			}
+
+			err = tt.store.Delete(ctx, "a/b")
+			if err != nil {
+				t.Fatalf("Store.Delete() error = %v", err)
+			}
+
+			err = tt.store.Delete(ctx, "a/b")
+			if err != nil {
+				t.Errorf("Store.Delete() missing key error = %v", err)
+			}
+
+			_, ok, err = tt.store.Get(ctx, "a/b")
+			if err != nil || ok {
+				t.Errorf("Store.Get() ok = %v, error = %v, want deleted", ok, err)
+			}
+		})
+	}
+}
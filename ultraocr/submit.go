@@ -0,0 +1,329 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SubmitJob Sends a job described by the request, uploading its documents to the signed URLs.
+// The positional SendJob functions are kept as adapters of this one.
+func (client *Client) SubmitJob(ctx context.Context, req JobRequest) (CreatedResponse, error) {
+	err := req.Options.Validate()
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	return client.submitJob(ctx, req, req.Options.Params())
+}
+
+// SubmitBatch Sends a batch described by the request, uploading its document to the signed URL.
+// The positional SendBatch functions are kept as adapters of this one.
+func (client *Client) SubmitBatch(ctx context.Context, req BatchRequest) (CreatedResponse, error) {
+	err := req.Options.Validate()
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	return client.submitBatch(ctx, req, req.Options.Params())
+}
+
+// SubmitMany Sends many independent jobs to the service, up to concurrency at a time (the Client
+// JobsConcurrency if not positive). Returns an outcome per input, in the inputs order, each one with
+// its own error, so a failed submission doesn't stop the others.
+func (client *Client) SubmitMany(ctx context.Context, service Service, inputs []JobInput, concurrency int) []SubmitResult {
+	if concurrency <= 0 {
+		concurrency = client.jobsConcurrency()
+	}
+
+	results := make([]SubmitResult, len(inputs))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		req := JobRequest{
+			Service:       service,
+			Document:      input.Document,
+			Selfie:        input.Selfie,
+			ExtraDocument: input.ExtraDocument,
+			Metadata:      input.Metadata,
+			Options:       input.Options,
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = SubmitResult{Request: req, Err: ctx.Err()}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			created, err := client.SubmitJob(ctx, req)
+			results[i] = SubmitResult{Request: req, Created: created, Err: err}
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// submitJob Sends a job with the raw query params, ignoring the request options.
+func (client *Client) submitJob(ctx context.Context, req JobRequest, params map[string]string) (CreatedResponse, error) {
+	return client.submitJobSigned(ctx, req, params, nil)
+}
+
+// submitJobSigned Sends a job like submitJob, calling signed, if not nil, once the job is created
+// and before its uploads. An error from signed aborts the submission.
+func (client *Client) submitJobSigned(
+	ctx context.Context,
+	req JobRequest,
+	params map[string]string,
+	signed func(SignedUrlResponse) error,
+) (CreatedResponse, error) {
+	req, uploads, err := client.prepareJob(ctx, req, params)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	response, err := client.GenerateSignedUrl(ctx, req.Service, common.RESOURCE_JOB, req.Metadata, params)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	if signed != nil {
+		err = signed(response)
+		if err != nil {
+			return CreatedResponse{}, err
+		}
+	}
+
+	created, err := client.uploadJob(ctx, response, uploads)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	client.emit(JobSubmitted{Resource: common.RESOURCE_JOB, ID: created.Id, Service: req.Service, Time: client.clock().Now()})
+	return created, client.auditSubmit(ctx, common.RESOURCE_JOB, created.Id, req.Service, req.Metadata)
+}
+
+// prepareJob Validates and preprocesses the job files, returning the request with the files to upload.
+func (client *Client) prepareJob(ctx context.Context, req JobRequest, params map[string]string) (JobRequest, []jobUpload, error) {
+	facematch := params[common.KEY_FACEMATCH] == common.FLAG_TRUE
+	extra := params[common.KEY_EXTRA] == common.FLAG_TRUE
+	encoded := params[common.KEY_BASE64] == common.FLAG_TRUE
+
+	switch {
+	case req.Document == nil:
+		return JobRequest{}, nil, fmt.Errorf("%w: document", common.ErrMissingDocument)
+	case facematch && req.Selfie == nil:
+		return JobRequest{}, nil, fmt.Errorf("%w: selfie", common.ErrMissingDocument)
+	case extra && req.ExtraDocument == nil:
+		return JobRequest{}, nil, fmt.Errorf("%w: extra document", common.ErrMissingDocument)
+	}
+
+	files := []Source{req.Document}
+	if facematch {
+		files = append(files, req.Selfie)
+	}
+
+	if extra {
+		files = append(files, req.ExtraDocument)
+	}
+
+	for _, src := range files {
+		err := client.checkContentType(src, encoded)
+		if err != nil {
+			return JobRequest{}, nil, err
+		}
+	}
+
+	preprocessed := []*Source{&req.Document}
+	if facematch {
+		preprocessed = append(preprocessed, &req.Selfie)
+	}
+
+	if extra {
+		preprocessed = append(preprocessed, &req.ExtraDocument)
+	}
+
+	for _, src := range preprocessed {
+		var err error
+		*src, err = client.preprocessSource(*src, encoded, 0)
+		if err != nil {
+			return JobRequest{}, nil, err
+		}
+	}
+
+	if facematch {
+		err := client.checkSelfieSource(ctx, req.Selfie, encoded)
+		if err != nil {
+			return JobRequest{}, nil, err
+		}
+	}
+
+	uploads := []jobUpload{{document: "document", src: req.Document}}
+	if facematch {
+		uploads = append(uploads, jobUpload{document: "selfie", src: req.Selfie})
+	}
+
+	if extra {
+		uploads = append(uploads, jobUpload{document: "extra_document", src: req.ExtraDocument})
+	}
+
+	return req, uploads, nil
+}
+
+// uploadJob Uploads the files of a created job to its signed URLs.
+func (client *Client) uploadJob(ctx context.Context, response SignedUrlResponse, uploads []jobUpload) (CreatedResponse, error) {
+	uploaded, err := client.uploadJobFiles(ctx, response.URLs, uploads)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	for _, upload := range uploads {
+		if upload.document != "extra_document" || uploaded.extraErr == nil {
+			client.emit(UploadCompleted{ID: response.Id, Document: upload.document, Time: client.clock().Now()})
+		}
+	}
+
+	if uploaded.extraErr != nil {
+		created, err := client.skipUpload(response, "extra_document", uploaded.extraErr)
+		created.Checksums = uploaded.checksums
+		return created, err
+	}
+
+	return CreatedResponse{
+		Id:        response.Id,
+		StatusURL: response.StatusURL,
+		Checksums: uploaded.checksums,
+	}, nil
+}
+
+// submitBatch Sends a batch with the raw query params, ignoring the request options.
+func (client *Client) submitBatch(ctx context.Context, req BatchRequest, params map[string]string) (CreatedResponse, error) {
+	if req.Document == nil {
+		return CreatedResponse{}, fmt.Errorf("%w: document", common.ErrMissingDocument)
+	}
+
+	encoded := params[common.KEY_BASE64] == common.FLAG_TRUE
+	err := client.checkContentType(req.Document, encoded)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	req.Document, err = client.preprocessSource(req.Document, encoded, 0)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	response, err := client.GenerateSignedUrl(ctx, req.Service, common.RESOURCE_BATCH, req.Metadata, params)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	urls := response.URLs
+	checksum, err := client.upload(ctx, urls["document"], req.Document)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	client.emit(UploadCompleted{ID: response.Id, Document: "document", Time: client.clock().Now()})
+
+	created := CreatedResponse{
+		Id:        response.Id,
+		StatusURL: response.StatusURL,
+	}
+	if client.UploadChecksums {
+		created.Checksums = map[string]Checksum{"document": checksum}
+	}
+
+	client.emit(JobSubmitted{Resource: common.RESOURCE_BATCH, ID: created.Id, Service: req.Service, Time: client.clock().Now()})
+	return created, client.auditSubmit(ctx, common.RESOURCE_BATCH, created.Id, req.Service, req.Metadata)
+}
+
+// jobUpload A job file and the name of its signed URL.
+type jobUpload struct {
+	document string
+	src      Source
+}
+
+// jobUploads The outcome of uploaded job files: their checksums, with UploadChecksums, and the
+// extra document failure, with SoftFailExtra.
+type jobUploads struct {
+	checksums map[string]Checksum
+	extraErr  error
+}
+
+// uploadJobFiles Uploads the job files concurrently, cancelling the others on the first failure.
+// With SoftFailExtra, an extra document failure doesn't cancel them and is returned apart.
+func (client *Client) uploadJobFiles(ctx context.Context, urls map[string]string, uploads []jobUpload) (jobUploads, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		uploaded jobUploads
+		err      error
+	)
+
+	if client.UploadChecksums {
+		uploaded.checksums = map[string]Checksum{}
+	}
+
+	var wg sync.WaitGroup
+	for _, upload := range uploads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checksum, uploadErr := client.upload(ctx, urls[upload.document], upload.src)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if uploadErr == nil {
+				if uploaded.checksums != nil {
+					uploaded.checksums[upload.document] = checksum
+				}
+				return
+			}
+
+			if upload.document == "extra_document" && client.SoftFailExtra {
+				uploaded.extraErr = uploadErr
+				return
+			}
+
+			if err == nil {
+				err = uploadErr
+				cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return uploaded, err
+}
+
+// skipUpload Returns the created job with the failed optional upload as a warning when
+// SoftFailExtra is on, otherwise the upload error.
+func (client *Client) skipUpload(response SignedUrlResponse, document string, err error) (CreatedResponse, error) {
+	if !client.SoftFailExtra {
+		return CreatedResponse{}, err
+	}
+
+	if client.Hooks.OnUploadSkipped != nil {
+		client.Hooks.OnUploadSkipped(UploadSkippedEvent{JobID: response.Id, Document: document, Err: err})
+	}
+
+	return CreatedResponse{
+		Id:        response.Id,
+		StatusURL: response.StatusURL,
+		Warnings:  []error{fmt.Errorf("%s: %w", document, err)},
+	}, nil
+}
@@ -0,0 +1,275 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+// submissionRecorder Records the query params and uploads of job submissions.
+type submissionRecorder struct {
+	mu      sync.Mutex
+	params  url.Values
+	uploads map[string]string
+}
+
+func (r *submissionRecorder) client() *Client {
+	r.uploads = map[string]string{}
+	return &Client{
+		HttpClient: &ClientMock{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.Method == http.MethodPut {
+					body, _ := io.ReadAll(req.Body)
+					r.mu.Lock()
+					r.uploads[req.URL.Path] = string(body)
+					r.mu.Unlock()
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				}
+
+				r.params = req.URL.Query()
+				return &http.Response{
+					StatusCode: 200,
+					Body: io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123","urls":{` +
+						`"document":"https://bucket/document","selfie":"https://bucket/selfie","extra_document":"https://bucket/extra"}}`))),
+				}, nil
+			},
+		},
+		Token:     "123",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestSubmitJobAdapters(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{"doc": "document", "selfie": "selfie", "extra": "extra"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	params := map[string]string{common.KEY_FACEMATCH: common.FLAG_TRUE, common.KEY_EXTRA: common.FLAG_TRUE}
+	tests := []struct {
+		name    string
+		send    func(client *Client) (CreatedResponse, error)
+		uploads map[string]string
+	}{
+		{
+			name: "SendJob",
+			send: func(client *Client) (CreatedResponse, error) {
+				return client.SendJob(context.Background(), ServiceCNH,
+					filepath.Join(dir, "doc"), filepath.Join(dir, "selfie"), filepath.Join(dir, "extra"), nil, params)
+			},
+			uploads: map[string]string{"/document": "document", "/selfie": "selfie", "/extra": "extra"},
+		},
+		{
+			name: "SendJobBase64",
+			send: func(client *Client) (CreatedResponse, error) {
+				return client.SendJobBase64(context.Background(), ServiceCNH, "ZG9j", "c2VsZmll", "ZXh0cmE=", nil, params)
+			},
+			uploads: map[string]string{"/document": "ZG9j", "/selfie": "c2VsZmll", "/extra": "ZXh0cmE="},
+		},
+		{
+			name: "SubmitJob",
+			send: func(client *Client) (CreatedResponse, error) {
+				return client.SubmitJob(context.Background(), JobRequest{
+					Service:       ServiceCNH,
+					Document:      BytesSource("doc", []byte("document")),
+					Selfie:        FileSource(filepath.Join(dir, "selfie")),
+					ExtraDocument: StringSource("extra", "extra"),
+					Options:       JobOptions{Facematch: true, ExtraDocument: true},
+				})
+			},
+			uploads: map[string]string{"/document": "document", "/selfie": "selfie", "/extra": "extra"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorder submissionRecorder
+			got, err := tt.send(recorder.client())
+			if err != nil {
+				t.Fatalf("send error = %v", err)
+			}
+			if got.Id != "123" || got.StatusURL != "url/123" {
+				t.Errorf("send = %+v", got)
+			}
+			if recorder.params.Get(common.KEY_FACEMATCH) != common.FLAG_TRUE || recorder.params.Get(common.KEY_EXTRA) != common.FLAG_TRUE {
+				t.Errorf("params = %v", recorder.params)
+			}
+			if !reflect.DeepEqual(recorder.uploads, tt.uploads) {
+				t.Errorf("uploads = %v, want %v", recorder.uploads, tt.uploads)
+			}
+		})
+	}
+}
+
+func TestSubmitJobMissingDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		req  JobRequest
+	}{
+		{
+			name: "document",
+			req:  JobRequest{Service: ServiceRG},
+		},
+		{
+			name: "selfie",
+			req:  JobRequest{Service: ServiceRG, Document: StringSource("doc", "doc"), Options: JobOptions{Facematch: true}},
+		},
+		{
+			name: "extra document",
+			req:  JobRequest{Service: ServiceRG, Document: StringSource("doc", "doc"), Options: JobOptions{ExtraDocument: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorder submissionRecorder
+			_, err := recorder.client().SubmitJob(context.Background(), tt.req)
+			if !errors.Is(err, common.ErrMissingDocument) || recorder.params != nil {
+				t.Errorf("client.SubmitJob() error = %v, want %v before requests", err, common.ErrMissingDocument)
+			}
+		})
+	}
+
+	var recorder submissionRecorder
+	_, err := recorder.client().SubmitBatch(context.Background(), BatchRequest{Service: ServiceRG})
+	if !errors.Is(err, common.ErrMissingDocument) {
+		t.Errorf("client.SubmitBatch() error = %v, want %v", err, common.ErrMissingDocument)
+	}
+}
+
+func TestSubmitBatch(t *testing.T) {
+	var recorder submissionRecorder
+	got, err := recorder.client().SubmitBatch(context.Background(), BatchRequest{
+		Service:  ServiceRG,
+		Document: StringSource("batch", "ZG9j"),
+		Options:  JobOptions{Base64: true},
+	})
+	if err != nil || got.Id != "123" {
+		t.Fatalf("client.SubmitBatch() = %+v, %v", got, err)
+	}
+	if recorder.params.Get(common.KEY_BASE64) != common.FLAG_TRUE || recorder.uploads["/document"] != "ZG9j" {
+		t.Errorf("params = %v, uploads = %v", recorder.params, recorder.uploads)
+	}
+}
+
+func TestSubmitJobConcurrentUploads(t *testing.T) {
+	tests := []struct {
+		name     string
+		failPath string
+		softFail bool
+		wantErr  error
+		wantWarn bool
+	}{
+		{
+			name: "all uploads in flight",
+		},
+		{
+			name:     "selfie failure",
+			failPath: "/selfie",
+			wantErr:  common.ErrInvalidStatusCode,
+		},
+		{
+			name:     "extra soft failure",
+			failPath: "/extra",
+			softFail: true,
+			wantWarn: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arrived := make(chan struct{}, 3)
+			release := make(chan struct{})
+			var once sync.Once
+
+			client := (&submissionRecorder{}).client()
+			client.SetSoftFailExtra(tt.softFail)
+			signed := client.HttpClient.(*ClientMock).MockDo
+			client.HttpClient = &ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPut {
+						return signed(req)
+					}
+
+					// Every upload waits for the three to be in flight, so sequential uploads time out.
+					arrived <- struct{}{}
+					if len(arrived) == 3 {
+						once.Do(func() { close(release) })
+					}
+					select {
+					case <-release:
+					case <-time.After(time.Second):
+						t.Errorf("upload of %v not concurrent", req.URL.Path)
+					}
+
+					if req.URL.Path == tt.failPath {
+						return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+					}
+					return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+				},
+			}
+
+			created, err := client.SubmitJob(context.Background(), JobRequest{
+				Service:       ServiceCNH,
+				Document:      StringSource("document", "document"),
+				Selfie:        StringSource("selfie", "selfie"),
+				ExtraDocument: StringSource("extra", "extra"),
+				Options:       JobOptions{Facematch: true, ExtraDocument: true},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.SubmitJob() error = %v, want %v", err, tt.wantErr)
+			}
+			if (len(created.Warnings) > 0) != tt.wantWarn {
+				t.Errorf("client.SubmitJob() warnings = %v, want warning %v", created.Warnings, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestSubmitMany(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+	client := newFakeClient(clock, api)
+
+	inputs := []JobInput{
+		{Document: StringSource("a", "a")},
+		{Document: StringSource("b", "b"), Options: JobOptions{Facematch: true}},
+		{Document: StringSource("c", "c"), Metadata: map[string]any{"id": 3}},
+	}
+	results := client.SubmitMany(context.Background(), ServiceRG, inputs, 2)
+	if len(results) != len(inputs) {
+		t.Fatalf("client.SubmitMany() = %d results, want %d", len(results), len(inputs))
+	}
+
+	ids := map[string]bool{}
+	for i, result := range results {
+		if result.Request.Document != inputs[i].Document || result.Request.Service != ServiceRG {
+			t.Errorf("client.SubmitMany() result %d request = %+v, want input %d", i, result.Request, i)
+		}
+		if i == 1 {
+			if !errors.Is(result.Err, common.ErrMissingDocument) {
+				t.Errorf("client.SubmitMany() result %d error = %v, want %v", i, result.Err, common.ErrMissingDocument)
+			}
+			continue
+		}
+		if result.Err != nil || result.Created.Id == "" {
+			t.Errorf("client.SubmitMany() result %d = %+v, want created", i, result)
+		}
+		ids[result.Created.Id] = true
+	}
+	if len(ids) != 2 {
+		t.Errorf("client.SubmitMany() created %d distinct jobs, want 2", len(ids))
+	}
+}
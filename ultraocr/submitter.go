@@ -0,0 +1,165 @@
+package ultraocr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// Submitter Submits jobs pushed by producers on a bounded queue with a pool of workers,
+// limiting the submissions rate. The results must be read from Results, or the workers stop
+// once its buffer, as large as the queue, is full.
+type Submitter struct {
+	client  *Client
+	opts    SubmitterOptions
+	queue   chan JobRequest
+	results chan SubmitResult
+	ticker  *time.Ticker
+	done    chan struct{}
+
+	mu      sync.RWMutex
+	closed  bool
+	senders sync.WaitGroup
+	wg      sync.WaitGroup
+}
+
+// NewSubmitter Creates a submitter and starts its workers, running until Close or the context is done.
+func (client *Client) NewSubmitter(ctx context.Context, opts SubmitterOptions) *Submitter {
+	if opts.Workers <= 0 {
+		opts.Workers = client.jobsConcurrency()
+	}
+
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = common.DEFAULT_SUBMITTER_QUEUE
+	}
+
+	s := &Submitter{
+		client:  client,
+		opts:    opts,
+		queue:   make(chan JobRequest, opts.QueueSize),
+		results: make(chan SubmitResult, opts.QueueSize),
+		done:    make(chan struct{}),
+	}
+
+	if opts.RateLimit > 0 {
+		s.ticker = time.NewTicker(time.Duration(float64(time.Second) / opts.RateLimit))
+	}
+
+	for i := 0; i < opts.Workers; i++ {
+		s.wg.Add(1)
+		go s.work(ctx)
+	}
+
+	go func() {
+		s.wg.Wait()
+		if s.ticker != nil {
+			s.ticker.Stop()
+		}
+
+		close(s.results)
+	}()
+
+	return s
+}
+
+// Enqueue Adds a job to the queue, waiting for room when it is full.
+// Fails with ErrSubmitterClosed after Close, also when it is waiting for room.
+func (s *Submitter) Enqueue(ctx context.Context, req JobRequest) error {
+	s.mu.RLock()
+	if s.closed {
+		s.mu.RUnlock()
+		return common.ErrSubmitterClosed
+	}
+
+	// the workers drain the queue only after the senders seen before Close are gone
+	s.senders.Add(1)
+	s.mu.RUnlock()
+	defer s.senders.Done()
+
+	select {
+	case s.queue <- req:
+		return nil
+	case <-s.done:
+		return common.ErrSubmitterClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Results Returns the channel receiving the outcome of each job, closed after Close once the
+// queued jobs are processed.
+func (s *Submitter) Results() <-chan SubmitResult {
+	return s.results
+}
+
+// Close Stops accepting jobs. The queued jobs are still processed.
+func (s *Submitter) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
+
+// work Processes queued jobs until the context is done, or Close once the queue is drained.
+func (s *Submitter) work(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case req := <-s.queue:
+			if !s.process(ctx, req) {
+				return
+			}
+		case <-s.done:
+			s.senders.Wait()
+			for {
+				select {
+				case req := <-s.queue:
+					if !s.process(ctx, req) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// process Submits a job and sends its result, returning false if the context is done first.
+func (s *Submitter) process(ctx context.Context, req JobRequest) bool {
+	result := s.submit(ctx, req)
+
+	select {
+	case s.results <- result:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// submit Submits a job after waiting its turn on the rate limit.
+func (s *Submitter) submit(ctx context.Context, req JobRequest) SubmitResult {
+	if s.ticker != nil {
+		select {
+		case <-s.ticker.C:
+		case <-ctx.Done():
+			return SubmitResult{Request: req, Err: ctx.Err()}
+		}
+	}
+
+	created, err := s.client.SubmitJob(ctx, req)
+	if err != nil || !s.opts.Wait {
+		return SubmitResult{Request: req, Created: created, Err: err}
+	}
+
+	result, err := s.client.WaitForJob(ctx, created.Id)
+	return SubmitResult{Request: req, Created: created, Result: &result, Err: err}
+}
@@ -0,0 +1,110 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestSubmitter(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        SubmitterOptions
+		jobs        int
+		minDuration time.Duration
+	}{
+		{name: "workers", opts: SubmitterOptions{Workers: 3, QueueSize: 2}, jobs: 10},
+		{name: "wait", opts: SubmitterOptions{Workers: 2, Wait: true}, jobs: 4},
+		{name: "rate limit", opts: SubmitterOptions{Workers: 5, RateLimit: 100}, jobs: 5, minDuration: 40 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			api := ultraocrtest.NewFakeAPI(clock)
+			api.ProcessingTime = 5 * time.Second
+			client := newFakeClient(clock, api)
+
+			start := time.Now()
+			submitter := client.NewSubmitter(context.Background(), tt.opts)
+			go func() {
+				for i := 0; i < tt.jobs; i++ {
+					err := submitter.Enqueue(context.Background(), JobRequest{Service: ServiceRG, Document: StringSource("doc", "doc")})
+					if err != nil {
+						t.Errorf("Submitter.Enqueue() error = %v", err)
+					}
+				}
+				submitter.Close()
+			}()
+
+			ids := map[string]bool{}
+			for result := range submitter.Results() {
+				if result.Err != nil {
+					t.Errorf("Submitter result error = %v", result.Err)
+				}
+				if (result.Result != nil) != tt.opts.Wait {
+					t.Errorf("Submitter result = %v, want waited %v", result.Result, tt.opts.Wait)
+				}
+				ids[result.Created.Id] = true
+			}
+
+			if len(ids) != tt.jobs {
+				t.Errorf("Submitter submitted %d jobs, want %d", len(ids), tt.jobs)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minDuration {
+				t.Errorf("Submitter took %v, want at least %v", elapsed, tt.minDuration)
+			}
+
+			err := submitter.Enqueue(context.Background(), JobRequest{})
+			if !errors.Is(err, common.ErrSubmitterClosed) {
+				t.Errorf("Submitter.Enqueue() after Close error = %v, want %v", err, common.ErrSubmitterClosed)
+			}
+		})
+	}
+}
+
+func TestSubmitterCloseAfterCancel(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newFakeClient(clock, ultraocrtest.NewFakeAPI(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	submitter := client.NewSubmitter(ctx, SubmitterOptions{Workers: 1, QueueSize: 1})
+	cancel()
+	for range submitter.Results() {
+	}
+
+	// The workers are gone, so the queue is full after a job and the next Enqueue waits for room.
+	err := submitter.Enqueue(context.Background(), JobRequest{Service: ServiceRG})
+	if err != nil {
+		t.Fatalf("Submitter.Enqueue() error = %v", err)
+	}
+
+	blocked := make(chan error)
+	go func() {
+		blocked <- submitter.Enqueue(context.Background(), JobRequest{Service: ServiceRG})
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		submitter.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Submitter.Close() blocked behind Enqueue")
+	}
+
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, common.ErrSubmitterClosed) {
+			t.Errorf("Submitter.Enqueue() blocked error = %v, want %v", err, common.ErrSubmitterClosed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Submitter.Enqueue() still blocked after Close")
+	}
+}
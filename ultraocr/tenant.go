@@ -0,0 +1,35 @@
+package ultraocr
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// WithCredentials Creates a Client with the same settings, auto refreshing a token with other credentials.
+// Useful for services submitting jobs on behalf of many UltraOCR accounts.
+// The created Client shares the HTTP client, token store and concurrency limits, but has its own token and
+// doesn't share status responses nor the credentials provider.
+func (client *Client) WithCredentials(clientID, clientSecret string) Client {
+	unlock := client.lockAuth()
+	derived := *client
+	unlock()
+
+	expires := client.Expires
+	if expires <= 0 {
+		expires = common.DEFAULT_EXPIRATION_TIME
+	}
+
+	derived.authMu = &sync.Mutex{}
+	derived.flights = &flightGroup{}
+	derived.Token = ""
+	derived.Credentials = nil
+	derived.ClientID = clientID
+	derived.ClientSecret = clientSecret
+	derived.Expires = expires
+	derived.AutoRefresh = true
+	derived.ExpiresAt = time.Time{}
+
+	return derived
+}
@@ -0,0 +1,66 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithCredentials(t *testing.T) {
+	var mu sync.Mutex
+	auths := map[string]int{}
+	tokens := map[string]string{}
+	client := NewClient()
+	client.SetHttpClient(&ClientMock{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if strings.HasSuffix(req.URL.Path, "/token") {
+				var body map[string]any
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				id := body["ClientID"].(string)
+				auths[id] += 1
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"token":"token-` + id + `"}`))),
+				}, nil
+			}
+
+			tokens[req.URL.Path] = req.Header.Get("Authorization")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"batch_ksuid":"0ujsszwN8NRY24YaXiTIE2VWDTS","status":"done"}`))),
+			}, nil
+		},
+	})
+
+	tenantA := client.WithCredentials("a", "secret-a")
+	tenantB := client.WithCredentials("b", "secret-b")
+
+	for i := 0; i < 2; i++ {
+		_, err := tenantA.GetBatchStatus(context.Background(), "batchA000000000000000000000")
+		if err != nil {
+			t.Fatalf("tenantA.GetBatchStatus() error = %v", err)
+		}
+		_, err = tenantB.GetBatchStatus(context.Background(), "batchB000000000000000000000")
+		if err != nil {
+			t.Fatalf("tenantB.GetBatchStatus() error = %v", err)
+		}
+	}
+
+	if auths["a"] != 1 || auths["b"] != 1 {
+		t.Errorf("authentications = %v, want one per tenant", auths)
+	}
+	if tokens["/v2/ocr/batch/status/batchA000000000000000000000"] != "Bearer token-a" || tokens["/v2/ocr/batch/status/batchB000000000000000000000"] != "Bearer token-b" {
+		t.Errorf("tokens = %v", tokens)
+	}
+	if client.Token != "" || client.AutoRefresh {
+		t.Errorf("base client changed: %v", client)
+	}
+}
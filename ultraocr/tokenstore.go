@@ -0,0 +1,217 @@
+package ultraocr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetTokenStore Changes the Client to reuse tokens saved on the store when auto refreshing.
+// Failures accessing the store are ignored, authenticating on the API instead.
+func (client *Client) SetTokenStore(store TokenStore) {
+	client.TokenStore = store
+}
+
+// tokenKey Identifies the Client credentials on a TokenStore without exposing them.
+func (client *Client) tokenKey() string {
+	hash := sha256.Sum256([]byte(client.AuthBaseURL + "\n" + client.ClientID + "\n" + client.ClientSecret))
+	return hex.EncodeToString(hash[:])
+}
+
+// loadCachedToken Uses a stored token if it is not expired. Must be called holding the auth lock.
+func (client *Client) loadCachedToken(ctx context.Context) bool {
+	if client.TokenStore == nil {
+		return false
+	}
+
+	cached, ok, err := client.TokenStore.Load(ctx, client.tokenKey())
+	if err != nil || !ok || client.tokenExpired(cached.ExpiresAt) {
+		return false
+	}
+
+	client.Token = cached.Token
+	client.ExpiresAt = cached.ExpiresAt
+	return true
+}
+
+// saveCachedToken Stores the current token. Must be called holding the auth lock.
+func (client *Client) saveCachedToken(ctx context.Context) {
+	if client.TokenStore == nil {
+		return
+	}
+
+	_ = client.TokenStore.Save(ctx, client.tokenKey(), CachedToken{
+		Token:     client.Token,
+		ExpiresAt: client.ExpiresAt,
+	})
+}
+
+// MemoryTokenStore TokenStore keeping tokens in memory, shared by clients on the same process.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]CachedToken
+}
+
+// NewMemoryTokenStore Creates an in memory TokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[string]CachedToken{},
+	}
+}
+
+// Load Returns the token saved with the key.
+func (s *MemoryTokenStore) Load(ctx context.Context, key string) (CachedToken, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[key]
+	return token, ok, nil
+}
+
+// Save Saves the token with the key.
+func (s *MemoryTokenStore) Save(ctx context.Context, key string, token CachedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = token
+	return nil
+}
+
+// FileTokenStore TokenStore keeping tokens on a JSON file, shared by processes on the same machine.
+// Writes are guarded by a lock file and replace the file atomically, so concurrent processes
+// don't corrupt it.
+type FileTokenStore struct {
+	Path string
+}
+
+// NewFileTokenStore Creates a TokenStore saving tokens on the given file path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// Load Returns the token saved with the key.
+func (s *FileTokenStore) Load(ctx context.Context, key string) (CachedToken, bool, error) {
+	tokens, err := s.read()
+	if err != nil {
+		return CachedToken{}, false, err
+	}
+
+	token, ok := tokens[key]
+	return token, ok, nil
+}
+
+// Save Saves the token with the key, keeping the tokens of other keys.
+func (s *FileTokenStore) Save(ctx context.Context, key string, token CachedToken) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	tokens[key] = token
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrTokenStore, err)
+	}
+
+	err = writeFileAtomic(s.Path, data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrTokenStore, err)
+	}
+
+	return nil
+}
+
+func (s *FileTokenStore) read() (map[string]CachedToken, error) {
+	tokens := map[string]CachedToken{}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrTokenStore, err)
+	}
+
+	err = json.Unmarshal(data, &tokens)
+	if err != nil {
+		// a corrupted cache is discarded, it only holds disposable tokens
+		return map[string]CachedToken{}, nil
+	}
+
+	return tokens, nil
+}
+
+// lock Creates the lock file, waiting while another process holds it.
+// Lock files older than TOKEN_STORE_LOCK_STALE are considered abandoned and removed.
+func (s *FileTokenStore) lock(ctx context.Context) (func(), error) {
+	path := s.Path + ".lock"
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: %w", common.ErrTokenStore, err)
+		}
+
+		info, err := os.Stat(path)
+		if err == nil && time.Since(info.ModTime()) > common.TOKEN_STORE_LOCK_STALE {
+			os.Remove(path)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(common.TOKEN_STORE_LOCK_RETRY):
+		}
+	}
+}
+
+// writeFileAtomic Writes data on a temporary file and renames it, so readers never see partial writes.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o600)
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
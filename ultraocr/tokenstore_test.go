@@ -0,0 +1,94 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestTokenStoreReuse(t *testing.T) {
+	tests := []struct {
+		name  string
+		store TokenStore
+	}{
+		{
+			name:  "memory",
+			store: NewMemoryTokenStore(),
+		},
+		{
+			name:  "file",
+			store: NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := ultraocrtest.NewFakeClock(time.Now())
+			api := ultraocrtest.NewFakeAPI(clock)
+			jobID := api.AddJob("rg", common.STATUS_DONE)
+
+			for i := 0; i < 3; i++ {
+				client := newFakeClient(clock, api)
+				client.SetTokenStore(tt.store)
+
+				_, err := client.GetJobResult(context.Background(), jobID, jobID)
+				if err != nil {
+					t.Fatalf("client.GetJobResult() error = %v", err)
+				}
+			}
+
+			if api.AuthCount() != 1 {
+				t.Errorf("authentications = %v, want 1", api.AuthCount())
+			}
+
+			clock.Advance(2 * time.Hour)
+			client := newFakeClient(clock, api)
+			client.SetTokenStore(tt.store)
+
+			_, err := client.GetJobResult(context.Background(), jobID, jobID)
+			if err != nil {
+				t.Fatalf("client.GetJobResult() error = %v", err)
+			}
+			if api.AuthCount() != 2 {
+				t.Errorf("authentications = %v, want 2", api.AuthCount())
+			}
+		})
+	}
+}
+
+func TestFileTokenStoreConcurrentSaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	expiresAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			store := NewFileTokenStore(path)
+			err := store.Save(context.Background(), fmt.Sprint(i), CachedToken{Token: fmt.Sprint(i), ExpiresAt: expiresAt})
+			if err != nil {
+				t.Errorf("FileTokenStore.Save() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	store := NewFileTokenStore(path)
+	for i := 0; i < 20; i++ {
+		token, ok, err := store.Load(context.Background(), fmt.Sprint(i))
+		if err != nil || !ok || token.Token != fmt.Sprint(i) || !token.ExpiresAt.Equal(expiresAt) {
+			t.Errorf("FileTokenStore.Load() = %v, %v, %v", token, ok, err)
+		}
+	}
+
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// Tracker Follows submitted jobs and batches, preferring the API callbacks over polling.
+// Submissions register the Tracker callback URL, and waits only poll the API if no callback
+// arrives within the grace period after the submission, so lost callbacks are still handled.
+// Callbacks must be forwarded to JobDone and BatchDone, e.g. with the webhook package handler.
+type Tracker struct {
+	Client      *Client
+	CallbackURL string
+	GracePeriod time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*trackedEntry
+}
+
+type trackedEntry struct {
+	item  TrackedItem
+	done  chan struct{}
+	job   JobResultResponse
+	batch BatchStatusResponse
+}
+
+// NewTracker Creates a Tracker registering the callback URL on submissions and polling after the grace period.
+func NewTracker(client *Client, callbackURL string, gracePeriod time.Duration) *Tracker {
+	return &Tracker{
+		Client:      client,
+		CallbackURL: callbackURL,
+		GracePeriod: gracePeriod,
+		entries:     map[string]*trackedEntry{},
+	}
+}
+
+// SendJob Sends a job with the Tracker callback URL and tracks it.
+func (t *Tracker) SendJob(ctx context.Context,
+	service Service,
+	filePath,
+	facematchFilePath,
+	extraFilePath string,
+	metadata map[string]any,
+	opts JobOptions,
+) (CreatedResponse, error) {
+	opts.CallbackURL = t.CallbackURL
+	res, err := t.Client.SendJobWithOptions(ctx, service, filePath, facematchFilePath, extraFilePath, metadata, opts)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	t.Track(TrackedItem{Resource: common.RESOURCE_JOB, BatchID: res.Id, JobID: res.Id})
+	return res, nil
+}
+
+// SendBatch Sends a batch with the Tracker callback URL and tracks it.
+func (t *Tracker) SendBatch(ctx context.Context,
+	service Service,
+	filePath string,
+	metadata []map[string]any,
+	opts JobOptions,
+) (CreatedResponse, error) {
+	opts.CallbackURL = t.CallbackURL
+	res, err := t.Client.SendBatchWithOptions(ctx, service, filePath, metadata, opts)
+	if err != nil {
+		return CreatedResponse{}, err
+	}
+
+	t.Track(TrackedItem{Resource: common.RESOURCE_BATCH, BatchID: res.Id})
+	return res, nil
+}
+
+// Track Starts tracking a job or batch submitted elsewhere. A zero SubmittedAt means now.
+func (t *Tracker) Track(item TrackedItem) {
+	if item.SubmittedAt.IsZero() {
+		item.SubmittedAt = t.Client.clock().Now()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.entries == nil {
+		t.entries = map[string]*trackedEntry{}
+	}
+
+	if _, ok := t.entries[item.key()]; !ok {
+		t.entries[item.key()] = &trackedEntry{item: item, done: make(chan struct{})}
+	}
+}
+
+// Tracked Returns the jobs and batches not finished yet.
+func (t *Tracker) Tracked() []TrackedItem {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	items := []TrackedItem{}
+	for _, entry := range t.entries {
+		if !entry.finished() {
+			items = append(items, entry.item)
+		}
+	}
+
+	return items
+}
+
+// Export Writes the jobs and batches not finished yet as JSON, to hand them to another process
+// with Import, e.g. on blue/green deploys.
+func (t *Tracker) Export(w io.Writer) error {
+	items := t.Tracked()
+	slices.SortFunc(items, func(a, b TrackedItem) int {
+		if c := a.SubmittedAt.Compare(b.SubmittedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.key(), b.key())
+	})
+
+	return json.NewEncoder(w).Encode(TrackerState{Version: common.TRACKER_STATE_VERSION, Items: items})
+}
+
+// Import Tracks the jobs and batches written by Export, keeping their submission time, so the
+// grace period is not restarted. Nothing is tracked if the state is invalid.
+func (t *Tracker) Import(r io.Reader) error {
+	var state TrackerState
+	err := json.NewDecoder(r).Decode(&state)
+	if err != nil {
+		return fmt.Errorf("%w: %w", common.ErrInvalidTrackerState, err)
+	}
+
+	if state.Version != common.TRACKER_STATE_VERSION {
+		return fmt.Errorf("%w: unsupported version %d", common.ErrInvalidTrackerState, state.Version)
+	}
+
+	for i, item := range state.Items {
+		err = item.validate()
+		if err != nil {
+			return fmt.Errorf("%w: item %d: %w", common.ErrInvalidTrackerState, i, err)
+		}
+	}
+
+	for _, item := range state.Items {
+		t.Track(item)
+	}
+
+	return nil
+}
+
+// JobDone Delivers a job callback. Returns false if the job is not tracked.
+func (t *Tracker) JobDone(result JobResultResponse) bool {
+	return t.finish(result.JobID, func(entry *trackedEntry) {
+		entry.job = result
+	})
+}
+
+// BatchDone Delivers a batch callback. Returns false if the batch is not tracked.
+func (t *Tracker) BatchDone(status BatchStatusResponse) bool {
+	return t.finish(status.BatchID, func(entry *trackedEntry) {
+		entry.batch = status
+	})
+}
+
+// WaitJob Waits a tracked job callback, polling the API if it doesn't arrive within the grace period.
+// The job stops being tracked when the wait succeeds.
+func (t *Tracker) WaitJob(ctx context.Context, jobID string) (JobResultResponse, error) {
+	entry, err := t.wait(ctx, jobID, func(ctx context.Context, item TrackedItem) error {
+		result, err := t.Client.WaitForJobDone(ctx, item.BatchID, item.JobID)
+		if err == nil {
+			t.JobDone(result)
+		}
+		return err
+	})
+	if err != nil {
+		return JobResultResponse{}, err
+	}
+
+	return entry.job, nil
+}
+
+// WaitBatch Waits a tracked batch callback, polling the API if it doesn't arrive within the grace period.
+// The batch stops being tracked when the wait succeeds.
+func (t *Tracker) WaitBatch(ctx context.Context, batchID string) (BatchStatusResponse, error) {
+	entry, err := t.wait(ctx, batchID, func(ctx context.Context, item TrackedItem) error {
+		status, err := t.Client.WaitForBatchDone(ctx, item.BatchID, false)
+		if err == nil {
+			t.BatchDone(status)
+		}
+		return err
+	})
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	return entry.batch, nil
+}
+
+func (t *Tracker) wait(
+	ctx context.Context,
+	ID string,
+	poll func(ctx context.Context, item TrackedItem) error,
+) (*trackedEntry, error) {
+	t.mu.Lock()
+	entry, ok := t.entries[ID]
+	t.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", common.ErrNotTracked, ID)
+	}
+
+	grace := entry.item.SubmittedAt.Add(t.GracePeriod).Sub(t.Client.clock().Now())
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-t.Client.clock().After(max(grace, 0)):
+		pollCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		polled := make(chan error, 1)
+		go func() {
+			polled <- poll(pollCtx, entry.item)
+		}()
+
+		select {
+		case <-entry.done:
+		case err := <-polled:
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	t.mu.Lock()
+	delete(t.entries, ID)
+	t.mu.Unlock()
+
+	return entry, nil
+}
+
+// finish Saves the result of a tracked entry and wakes its waiters, only once.
+func (t *Tracker) finish(ID string, save func(entry *trackedEntry)) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[ID]
+	if !ok {
+		return false
+	}
+
+	if !entry.finished() {
+		save(entry)
+		close(entry.done)
+	}
+
+	return true
+}
+
+func (e *trackedEntry) finished() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// validate Checks the item has the IDs required by its resource.
+func (item TrackedItem) validate() error {
+	switch {
+	case item.Resource == common.RESOURCE_JOB && (item.JobID == "" || item.BatchID == ""):
+		return errors.New("job without batch and job IDs")
+	case item.Resource == common.RESOURCE_BATCH && item.BatchID == "":
+		return errors.New("batch without batch ID")
+	case item.Resource != common.RESOURCE_JOB && item.Resource != common.RESOURCE_BATCH:
+		return fmt.Errorf("unknown resource %q", item.Resource)
+	}
+
+	return nil
+}
+
+// key Identifies the item, by the job ID for jobs and by the batch ID for batches.
+func (item TrackedItem) key() string {
+	if item.Resource == common.RESOURCE_JOB {
+		return item.JobID
+	}
+
+	return item.BatchID
+}
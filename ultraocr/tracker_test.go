@@ -0,0 +1,176 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("callback before the grace period", func(t *testing.T) {
+		clock := ultraocrtest.NewFakeClock(start)
+		api := ultraocrtest.NewFakeAPI(clock)
+		api.ProcessingTime = time.Minute
+		client := newFakeClient(clock, api)
+		tracker := NewTracker(&client, "https://example.com/hook", 10*time.Minute)
+
+		created, err := tracker.SendBatch(context.Background(), "rg", "tracker_test.go", nil, JobOptions{})
+		if err != nil {
+			t.Fatalf("tracker.SendBatch() error = %v", err)
+		}
+		before := api.RequestCount()
+
+		results := make(chan BatchStatusResponse, 1)
+		go func() {
+			status, err := tracker.WaitBatch(context.Background(), created.Id)
+			if err != nil {
+				t.Errorf("tracker.WaitBatch() error = %v", err)
+			}
+			results <- status
+		}()
+
+		clock.BlockUntil(1)
+		if !tracker.BatchDone(BatchStatusResponse{BatchID: created.Id, Status: common.STATUS_DONE}) {
+			t.Fatalf("tracker.BatchDone() = false, want true")
+		}
+
+		status := <-results
+		if status.Status != common.STATUS_DONE {
+			t.Errorf("tracker.WaitBatch() status = %v, want %v", status.Status, common.STATUS_DONE)
+		}
+		if api.RequestCount() != before {
+			t.Errorf("tracker.WaitBatch() requests = %v, want no polling", api.RequestCount()-before)
+		}
+		if len(tracker.Tracked()) != 0 {
+			t.Errorf("tracker.Tracked() = %v, want empty", tracker.Tracked())
+		}
+	})
+
+	t.Run("polling after the grace period", func(t *testing.T) {
+		clock := ultraocrtest.NewAutoClock(start)
+		api := ultraocrtest.NewFakeAPI(clock)
+		api.ProcessingTime = time.Minute
+		client := newFakeClient(clock, api)
+		tracker := NewTracker(&client, "https://example.com/hook", 10*time.Minute)
+
+		created, err := tracker.SendJob(context.Background(), "rg", "tracker_test.go", "", "", nil, JobOptions{})
+		if err != nil {
+			t.Fatalf("tracker.SendJob() error = %v", err)
+		}
+
+		result, err := tracker.WaitJob(context.Background(), created.Id)
+		if err != nil {
+			t.Fatalf("tracker.WaitJob() error = %v", err)
+		}
+		if result.JobID != created.Id || result.Status != common.STATUS_DONE {
+			t.Errorf("tracker.WaitJob() = %+v, want job done", result)
+		}
+		if clock.Now().Before(start.Add(10 * time.Minute)) {
+			t.Errorf("tracker.WaitJob() polled before the grace period")
+		}
+		if tracker.JobDone(result) {
+			t.Errorf("tracker.JobDone() = true after the wait, want false")
+		}
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		client := NewClient()
+		tracker := NewTracker(&client, "https://example.com/hook", time.Minute)
+
+		_, err := tracker.WaitJob(context.Background(), "123")
+		if !errors.Is(err, common.ErrNotTracked) {
+			t.Errorf("tracker.WaitJob() error = %v, want %v", err, common.ErrNotTracked)
+		}
+	})
+}
+
+func TestTrackerExportImport(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ultraocrtest.NewFakeClock(start)
+	client := newFakeClient(clock, ultraocrtest.NewFakeAPI(clock))
+
+	old := NewTracker(&client, "https://example.com/hook", 10*time.Minute)
+	old.Track(TrackedItem{Resource: common.RESOURCE_BATCH, BatchID: "2", SubmittedAt: start.Add(time.Minute)})
+	old.Track(TrackedItem{Resource: common.RESOURCE_JOB, BatchID: "1", JobID: "1", SubmittedAt: start})
+	old.Track(TrackedItem{Resource: common.RESOURCE_JOB, BatchID: "3", JobID: "3"})
+	old.JobDone(JobResultResponse{JobID: "3", Status: common.STATUS_DONE})
+
+	var buf bytes.Buffer
+	err := old.Export(&buf)
+	if err != nil {
+		t.Fatalf("tracker.Export() error = %v", err)
+	}
+
+	want := `{"version":1,"items":[` +
+		`{"resource":"job","batch_ksuid":"1","job_ksuid":"1","submitted_at":"2024-01-01T00:00:00Z"},` +
+		`{"resource":"batch","batch_ksuid":"2","submitted_at":"2024-01-01T00:01:00Z"}]}` + "\n"
+	if buf.String() != want {
+		t.Errorf("tracker.Export() = %s, want %s", buf.String(), want)
+	}
+
+	clock.Advance(time.Hour)
+	imported := NewTracker(&client, "https://example.com/hook", 10*time.Minute)
+	err = imported.Import(&buf)
+	if err != nil {
+		t.Fatalf("tracker.Import() error = %v", err)
+	}
+
+	got := imported.Tracked()
+	if len(got) != 2 {
+		t.Fatalf("tracker.Tracked() = %v, want 2 items", got)
+	}
+	for _, item := range got {
+		if !item.SubmittedAt.Before(start.Add(time.Hour)) {
+			t.Errorf("tracker.Tracked() submitted at = %v, want kept from the export", item.SubmittedAt)
+		}
+	}
+	if !imported.JobDone(JobResultResponse{JobID: "1", Status: common.STATUS_DONE}) {
+		t.Errorf("tracker.JobDone() = false, want imported job tracked")
+	}
+}
+
+func TestTrackerImportInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+	}{
+		{
+			name:  "malformed",
+			state: `{"version":1,"items":[`,
+		},
+		{
+			name:  "unsupported version",
+			state: `{"version":2,"items":[]}`,
+		},
+		{
+			name:  "unknown resource",
+			state: `{"version":1,"items":[{"resource":"page","batch_ksuid":"1"}]}`,
+		},
+		{
+			name:  "job without ID",
+			state: `{"version":1,"items":[{"resource":"batch","batch_ksuid":"1"},{"resource":"job","batch_ksuid":"1"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient(nil, ultraocrtest.NewFakeAPI(nil))
+			tracker := NewTracker(&client, "https://example.com/hook", time.Minute)
+
+			err := tracker.Import(strings.NewReader(tt.state))
+			if !errors.Is(err, common.ErrInvalidTrackerState) {
+				t.Errorf("tracker.Import() error = %v, want %v", err, common.ErrInvalidTrackerState)
+			}
+			if len(tracker.Tracked()) != 0 {
+				t.Errorf("tracker.Tracked() = %v, want nothing imported", tracker.Tracked())
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package ultraocrtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// Fake API defaults.
+const (
+	UPLOAD_BASE_URL = "https://upload.ultraocr.test"
+	idLength        = 27
+)
+
+// FakeAPI In memory UltraOCR API, usable as the Client HttpClient.
+// Jobs and batches are processing until ProcessingTime passes on the Clock.
+type FakeAPI struct {
+	Clock          interface{ Now() time.Time }
+	ProcessingTime time.Duration
+	JobsPerBatch   int
+	Document       any
+
+	mu       sync.Mutex
+	nextID   int
+	auths    int
+	requests int
+	jobs     map[string]*fakeJob
+	batches  map[string]*fakeBatch
+}
+
+type fakeJob struct {
+	id      string
+	service string
+	created time.Time
+	readyAt time.Time
+	status  string
+}
+
+type fakeBatch struct {
+	id        string
+	service   string
+	created   time.Time
+	readyAt   time.Time
+	jobs      []string
+	cancelled bool
+}
+
+// NewFakeAPI Creates a fake API using the given clock (nil uses the system clock).
+func NewFakeAPI(clock interface{ Now() time.Time }) *FakeAPI {
+	return &FakeAPI{
+		Clock:        clock,
+		JobsPerBatch: 1,
+		jobs:         map[string]*fakeJob{},
+		batches:      map[string]*fakeBatch{},
+	}
+}
+
+// AuthCount Returns how many tokens were generated.
+func (f *FakeAPI) AuthCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.auths
+}
+
+// RequestCount Returns how many requests were received, including authentications and uploads.
+func (f *FakeAPI) RequestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests
+}
+
+// AddJob Adds a job finishing with the given status after the processing time.
+// Returns the job ID.
+func (f *FakeAPI) AddJob(service, status string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.addJob(service, status)
+}
+
+// SetJobStatus Changes the final status of a job.
+func (f *FakeAPI) SetJobStatus(jobID, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if job, ok := f.jobs[jobID]; ok {
+		job.status = status
+	}
+}
+
+// Do Handles a request as the UltraOCR API would.
+func (f *FakeAPI) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests += 1
+	body := []byte{}
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	path := req.URL.Path
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case req.Method == http.MethodPut:
+		return response(http.StatusOK, nil), nil
+	case req.Method == http.MethodPost && strings.HasSuffix(path, "/token"):
+		return f.token(body), nil
+	case req.Method == http.MethodPost && strings.Contains(path, "/ocr/job/cancel/"):
+		return f.cancelJob(parts[len(parts)-1]), nil
+	case req.Method == http.MethodPost && strings.Contains(path, "/ocr/batch/cancel/"):
+		return f.cancelBatch(parts[len(parts)-1]), nil
+	case req.Method == http.MethodDelete && strings.Contains(path, "/ocr/job/data/"):
+		return f.deleteJob(parts[len(parts)-1]), nil
+	case req.Method == http.MethodDelete && strings.Contains(path, "/ocr/batch/data/"):
+		return f.deleteBatch(parts[len(parts)-1]), nil
+	case req.Method == http.MethodPost && strings.Contains(path, "/ocr/job/send/"):
+		id := f.addJob(parts[len(parts)-1], common.STATUS_DONE)
+		return response(http.StatusOK, map[string]any{
+			"id":         id,
+			"status_url": fmt.Sprintf("%s://%s/v2/ocr/job/result/%s", req.URL.Scheme, req.URL.Host, id),
+		}), nil
+	case req.Method == http.MethodPost && strings.Contains(path, "/ocr/job/"):
+		id := f.addJob(parts[len(parts)-1], common.STATUS_DONE)
+		return f.signedURL(req, common.RESOURCE_JOB, id), nil
+	case req.Method == http.MethodPost && strings.Contains(path, "/ocr/batch/"):
+		id := f.addBatch(parts[len(parts)-1])
+		return f.signedURL(req, common.RESOURCE_BATCH, id), nil
+	case req.Method == http.MethodGet && strings.Contains(path, "/ocr/job/result/"):
+		return f.jobResult(parts[len(parts)-1]), nil
+	case req.Method == http.MethodGet && strings.Contains(path, "/ocr/batch/status/"):
+		return f.batchStatus(parts[len(parts)-1]), nil
+	default:
+		return response(http.StatusNotFound, map[string]any{"message": "not found"}), nil
+	}
+}
+
+func (f *FakeAPI) now() time.Time {
+	if f.Clock == nil {
+		return time.Now()
+	}
+
+	return f.Clock.Now()
+}
+
+func (f *FakeAPI) newID() string {
+	f.nextID += 1
+	return fmt.Sprintf("%0*d", idLength, f.nextID)
+}
+
+func (f *FakeAPI) addJob(service, status string) string {
+	now := f.now()
+	job := &fakeJob{
+		id:      f.newID(),
+		service: service,
+		created: now,
+		readyAt: now.Add(f.ProcessingTime),
+		status:  status,
+	}
+	f.jobs[job.id] = job
+
+	return job.id
+}
+
+func (f *FakeAPI) addBatch(service string) string {
+	now := f.now()
+	batch := &fakeBatch{
+		id:      f.newID(),
+		service: service,
+		created: now,
+		readyAt: now.Add(f.ProcessingTime),
+	}
+
+	for i := 0; i < f.JobsPerBatch; i++ {
+		batch.jobs = append(batch.jobs, f.addJob(service, common.STATUS_DONE))
+	}
+	f.batches[batch.id] = batch
+
+	return batch.id
+}
+
+// token Generates an unsigned JWT expiring on the requested minutes.
+func (f *FakeAPI) token(body []byte) *http.Response {
+	f.auths += 1
+
+	var auth struct {
+		ExpiresIn int
+	}
+	_ = json.Unmarshal(body, &auth)
+	if auth.ExpiresIn <= 0 {
+		auth.ExpiresIn = common.DEFAULT_EXPIRATION_TIME
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	claims, _ := json.Marshal(map[string]any{
+		"sub": fmt.Sprintf("fake-%d", f.auths),
+		"iat": f.now().Unix(),
+		"exp": f.now().Add(time.Duration(auth.ExpiresIn) * time.Minute).Unix(),
+	})
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+
+	return response(http.StatusOK, map[string]any{
+		"token": fmt.Sprintf("%s.%s.", header, payload),
+	})
+}
+
+func (f *FakeAPI) signedURL(req *http.Request, resource, id string) *http.Response {
+	statusPath := "job/result"
+	if resource == common.RESOURCE_BATCH {
+		statusPath = "batch/status"
+	}
+
+	return response(http.StatusOK, map[string]any{
+		"exp":        60000,
+		"id":         id,
+		"status_url": fmt.Sprintf("%s://%s/v2/ocr/%s/%s", req.URL.Scheme, req.URL.Host, statusPath, id),
+		"urls": map[string]string{
+			"document":       fmt.Sprintf("%s/%s/document", UPLOAD_BASE_URL, id),
+			"selfie":         fmt.Sprintf("%s/%s/selfie", UPLOAD_BASE_URL, id),
+			"extra_document": fmt.Sprintf("%s/%s/extra_document", UPLOAD_BASE_URL, id),
+		},
+	})
+}
+
+func (f *FakeAPI) jobStatus(job *fakeJob) string {
+	if f.now().Before(job.readyAt) {
+		return "processing"
+	}
+
+	return job.status
+}
+
+// cancelJob Cancels a processing job, failing with 409 for finished jobs.
+func (f *FakeAPI) cancelJob(id string) *http.Response {
+	job, ok := f.jobs[id]
+	if !ok {
+		return response(http.StatusNotFound, map[string]any{"message": "job not found"})
+	}
+
+	if !f.now().Before(job.readyAt) {
+		return response(http.StatusConflict, map[string]any{"message": "job already finished"})
+	}
+
+	job.status = common.STATUS_CANCELLED
+	job.readyAt = f.now()
+	return response(http.StatusOK, nil)
+}
+
+// cancelBatch Cancels a processing batch and its processing jobs, failing with 409 for finished batches.
+func (f *FakeAPI) cancelBatch(id string) *http.Response {
+	batch, ok := f.batches[id]
+	if !ok {
+		return response(http.StatusNotFound, map[string]any{"message": "batch not found"})
+	}
+
+	if batch.cancelled || !f.now().Before(batch.readyAt) {
+		return response(http.StatusConflict, map[string]any{"message": "batch already finished"})
+	}
+
+	batch.cancelled = true
+	for _, jobID := range batch.jobs {
+		job := f.jobs[jobID]
+		if f.now().Before(job.readyAt) {
+			job.status = common.STATUS_CANCELLED
+			job.readyAt = f.now()
+		}
+	}
+
+	return response(http.StatusOK, nil)
+}
+
+// deleteJob Erases a finished job, failing with 409 for processing jobs.
+func (f *FakeAPI) deleteJob(id string) *http.Response {
+	job, ok := f.jobs[id]
+	if !ok {
+		return response(http.StatusNotFound, map[string]any{"message": "job not found"})
+	}
+
+	if f.now().Before(job.readyAt) {
+		return response(http.StatusConflict, map[string]any{"message": "job still processing"})
+	}
+
+	delete(f.jobs, id)
+	return response(http.StatusNoContent, nil)
+}
+
+// deleteBatch Erases a finished batch and its jobs, failing with 409 for processing batches.
+func (f *FakeAPI) deleteBatch(id string) *http.Response {
+	batch, ok := f.batches[id]
+	if !ok {
+		return response(http.StatusNotFound, map[string]any{"message": "batch not found"})
+	}
+
+	if !batch.cancelled && f.now().Before(batch.readyAt) {
+		return response(http.StatusConflict, map[string]any{"message": "batch still processing"})
+	}
+
+	for _, jobID := range batch.jobs {
+		delete(f.jobs, jobID)
+	}
+	delete(f.batches, id)
+	return response(http.StatusNoContent, nil)
+}
+
+func (f *FakeAPI) jobResult(id string) *http.Response {
+	job, ok := f.jobs[id]
+	if !ok {
+		return response(http.StatusNotFound, map[string]any{"message": "job not found"})
+	}
+
+	res := map[string]any{
+		"job_ksuid":  job.id,
+		"created_at": job.created.UTC().Format(time.RFC3339),
+		"service":    job.service,
+		"status":     f.jobStatus(job),
+	}
+
+	switch res["status"] {
+	case common.STATUS_DONE:
+		res["result"] = map[string]any{
+			"Document": f.Document,
+			"Time":     fmt.Sprintf("%.2f", f.ProcessingTime.Seconds()),
+		}
+	case common.STATUS_ERROR:
+		res["error"] = "failed to process document"
+	}
+
+	return response(http.StatusOK, res)
+}
+
+func (f *FakeAPI) batchStatus(id string) *http.Response {
+	batch, ok := f.batches[id]
+	if !ok {
+		return response(http.StatusNotFound, map[string]any{"message": "batch not found"})
+	}
+
+	status := "processing"
+	if batch.cancelled {
+		status = common.STATUS_CANCELLED
+	} else if !f.now().Before(batch.readyAt) {
+		status = common.STATUS_DONE
+	}
+
+	jobs := []map[string]any{}
+	for _, jobID := range batch.jobs {
+		job := f.jobs[jobID]
+		jobs = append(jobs, map[string]any{
+			"job_ksuid":  job.id,
+			"created_at": job.created.UTC().Format(time.RFC3339),
+			"result_url": fmt.Sprintf("/v2/ocr/job/result/%s/%s", batch.id, job.id),
+			"status":     f.jobStatus(job),
+		})
+	}
+
+	return response(http.StatusOK, map[string]any{
+		"batch_ksuid": batch.id,
+		"created_at":  batch.created.UTC().Format(time.RFC3339),
+		"service":     batch.service,
+		"status":      status,
+		"jobs":        jobs,
+	})
+}
+
+func response(status int, body any) *http.Response {
+	data := []byte{}
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+	}
+}
@@ -0,0 +1,108 @@
+// Package ultraocrtest implements fakes to test code using the UltraOCR SDK.
+package ultraocrtest
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// FakeClock Clock controlled by the test, to fast-forward token expiration, pooling intervals and timeouts.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	auto    bool
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFakeClock Creates a clock stopped at the given time, moved only by Advance and Set.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// NewAutoClock Creates a clock that advances by the requested duration on every After call,
+// so pooling loops run without waiting while still seeing the time passing.
+func NewAutoClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, auto: true}
+}
+
+// Now Returns the clock current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After Returns a channel receiving the clock time once it is advanced by d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+
+	if c.auto {
+		c.set(c.now.Add(d))
+	} else {
+		c.fire()
+	}
+
+	return ch
+}
+
+// Advance Moves the clock forward, firing the due After channels.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+// Set Moves the clock to the given time, firing the due After channels.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(now)
+}
+
+// Waiters Returns how many After channels are still waiting.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waiters)
+}
+
+// BlockUntil Blocks until at least n After channels are waiting,
+// useful to advance the clock only after a goroutine starts waiting.
+func (c *FakeClock) BlockUntil(n int) {
+	for c.Waiters() < n {
+		runtime.Gosched()
+	}
+}
+
+func (c *FakeClock) set(now time.Time) {
+	c.now = now
+	c.fire()
+}
+
+func (c *FakeClock) fire() {
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+
+		w.ch <- c.now
+	}
+
+	c.waiters = pending
+}
@@ -0,0 +1,41 @@
+package ultraocrtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	ch := clock.After(time.Hour)
+	select {
+	case <-ch:
+		t.Fatal("After() fired before advancing the clock")
+	default:
+	}
+
+	clock.Advance(30 * time.Minute)
+	if clock.Waiters() != 1 {
+		t.Errorf("Waiters() = %v, want 1", clock.Waiters())
+	}
+
+	clock.Advance(30 * time.Minute)
+	if got := <-ch; !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("After() = %v, want %v", got, start.Add(time.Hour))
+	}
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters() = %v, want 0", clock.Waiters())
+	}
+}
+
+func TestAutoClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewAutoClock(start)
+
+	<-clock.After(2 * time.Hour)
+	if got := clock.Now(); !got.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(2*time.Hour))
+	}
+}
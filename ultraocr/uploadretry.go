@@ -0,0 +1,85 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// SetUploadRetry Changes the retries of failed uploads to the signed URLs (Default none).
+func (client *Client) SetUploadRetry(retry UploadRetry) {
+	client.UploadRetry = &retry
+}
+
+func (r *UploadRetry) maxAttempts() int {
+	if r.MaxAttempts <= 0 {
+		return common.DEFAULT_UPLOAD_ATTEMPTS
+	}
+
+	return r.MaxAttempts
+}
+
+func (r *UploadRetry) backoff(attempt int) time.Duration {
+	backoff := r.Backoff
+	if backoff <= 0 {
+		backoff = common.DEFAULT_UPLOAD_BACKOFF
+	}
+
+	return backoff << attempt
+}
+
+// retryUpload Runs the upload until it succeeds, fails with a permanent error or the attempts end,
+// renewing the URL on 403 if renew is set.
+func (client *Client) retryUpload(ctx context.Context, url string, renew bool, upload func(url string) error) error {
+	retry := client.UploadRetry
+	if retry == nil {
+		return upload(url)
+	}
+
+	var err error
+	for attempt := 0; attempt < retry.maxAttempts(); attempt++ {
+		if attempt > 0 {
+			delay := retry.backoff(attempt - 1)
+			retrying(client.Hooks, client.Metrics, RetryEvent{Phase: common.PHASE_UPLOAD, Target: stripQuery(url), Attempt: attempt - 1, Delay: delay, Err: err})
+
+			sleepErr := client.sleep(ctx, delay)
+			if sleepErr != nil {
+				return fmt.Errorf("%w: last upload: %w", sleepErr, err)
+			}
+		}
+
+		err = upload(url)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		var apiErr *common.APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden && renew && retry.RenewURL != nil:
+			renewed, renewErr := retry.RenewURL(ctx, url)
+			if renewErr != nil {
+				return renewErr
+			}
+
+			url = renewed
+		case !retryableUpload(err):
+			return err
+		}
+	}
+
+	return err
+}
+
+// retryableUpload Checks if an upload failure is transient: network errors and timeouts, 429 and 5xx.
+func retryableUpload(err error) bool {
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return errors.Is(err, common.ErrDoingRequest)
+}
@@ -0,0 +1,110 @@
+package ultraocr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+func TestUploadRetry(t *testing.T) {
+	errNetwork := errors.New("connection reset")
+
+	tests := []struct {
+		name      string
+		retry     *UploadRetry
+		responses []any
+		wantErr   error
+		wantURLs  []string
+		wantSlept time.Duration
+	}{
+		{
+			name:      "no retry",
+			responses: []any{503},
+			wantErr:   common.ErrInvalidStatusCode,
+			wantURLs:  []string{"/upload"},
+		},
+		{
+			name:      "server errors",
+			retry:     &UploadRetry{Backoff: time.Second},
+			responses: []any{503, errNetwork, 200},
+			wantURLs:  []string{"/upload", "/upload", "/upload"},
+			wantSlept: 3 * time.Second,
+		},
+		{
+			name:      "attempts exhausted",
+			retry:     &UploadRetry{MaxAttempts: 2},
+			responses: []any{500, 429, 200},
+			wantErr:   common.ErrInvalidStatusCode,
+			wantURLs:  []string{"/upload", "/upload"},
+			wantSlept: common.DEFAULT_UPLOAD_BACKOFF,
+		},
+		{
+			name:      "client error",
+			retry:     &UploadRetry{},
+			responses: []any{400, 200},
+			wantErr:   common.ErrInvalidStatusCode,
+			wantURLs:  []string{"/upload"},
+		},
+		{
+			name: "renewed url",
+			retry: &UploadRetry{
+				Backoff: time.Second,
+				RenewURL: func(ctx context.Context, url string) (string, error) {
+					return "https://bucket.example.com/renewed", nil
+				},
+			},
+			responses: []any{403, 200},
+			wantURLs:  []string{"/upload", "/renewed"},
+			wantSlept: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			clock := ultraocrtest.NewAutoClock(start)
+			urls := []string{}
+			bodies := []string{}
+
+			client := NewClient()
+			client.Clock = clock
+			client.UploadRetry = tt.retry
+			client.SetHttpClient(&ClientMock{
+				MockDo: func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					bodies = append(bodies, string(body))
+					urls = append(urls, req.URL.Path)
+
+					switch res := tt.responses[len(urls)-1].(type) {
+					case error:
+						return nil, res
+					default:
+						return &http.Response{StatusCode: res.(int), Body: http.NoBody}, nil
+					}
+				},
+			})
+
+			err := client.UploadSource(context.Background(), "https://bucket.example.com/upload", StringSource("doc", "document"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.UploadSource() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(urls, tt.wantURLs) {
+				t.Errorf("upload URLs = %v, want %v", urls, tt.wantURLs)
+			}
+			for _, body := range bodies {
+				if body != "document" {
+					t.Errorf("upload body = %q, want the whole document on every attempt", body)
+				}
+			}
+			if slept := clock.Now().Sub(start); slept != tt.wantSlept {
+				t.Errorf("slept = %v, want %v", slept, tt.wantSlept)
+			}
+		})
+	}
+}
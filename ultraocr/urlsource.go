@@ -0,0 +1,93 @@
+package ultraocr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// URLSource Creates a source streaming a document from an HTTP(S) URL, like a presigned link,
+// with the Client HTTP client. The document is downloaded again on each Open and never kept
+// in memory. Its size is unknown (-1) until the first Open reads the Content-Length.
+func (client *Client) URLSource(ctx context.Context, url string) Source {
+	return StreamSource(urlName(url), func() (io.ReadCloser, int64, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, 0, common.ErrMountingRequest
+		}
+
+		res, err := client.send(req, false)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %w", common.ErrDoingRequest, err)
+		}
+
+		if res.StatusCode != http.StatusOK {
+			defer res.Body.Close()
+			return nil, 0, client.newAPIError(res, stripQuery(url))
+		}
+
+		return res.Body, res.ContentLength, nil
+	})
+}
+
+// urlName Returns the file name on the URL path, or "document" without one.
+func urlName(url string) string {
+	parsed, err := neturl.Parse(url)
+	if err != nil || path.Base(parsed.Path) == "/" || path.Base(parsed.Path) == "." {
+		return "document"
+	}
+
+	return path.Base(parsed.Path)
+}
+
+// SendJobFromURL Sends a job streaming the files from HTTP(S) URLs, like presigned links, straight
+// to the signed upload URLs, without keeping them in memory or on disk.
+// Requires the service, the files (facematch and extra file if requested on params) URLs
+// and the required metadata and query params.
+func (client *Client) SendJobFromURL(ctx context.Context,
+	service Service,
+	sourceURL,
+	facematchSourceURL,
+	extraSourceURL string,
+	metadata map[string]any,
+	params map[string]string,
+) (CreatedResponse, error) {
+	urls := []string{sourceURL}
+	if params[common.KEY_FACEMATCH] == common.FLAG_TRUE {
+		urls = append(urls, facematchSourceURL)
+	}
+
+	if params[common.KEY_EXTRA] == common.FLAG_TRUE {
+		urls = append(urls, extraSourceURL)
+	}
+
+	for _, url := range urls {
+		err := validateSourceURL(url)
+		if err != nil {
+			return CreatedResponse{}, err
+		}
+	}
+
+	return client.submitJob(ctx, JobRequest{
+		Service:       service,
+		Document:      client.URLSource(ctx, sourceURL),
+		Selfie:        client.URLSource(ctx, facematchSourceURL),
+		ExtraDocument: client.URLSource(ctx, extraSourceURL),
+		Metadata:      metadata,
+	}, params)
+}
+
+// validateSourceURL Checks the URL is an absolute HTTP(S) URL.
+func validateSourceURL(url string) error {
+	parsed, err := neturl.Parse(url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %q", common.ErrInvalidSourceURL, stripQuery(url))
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestSendJobFromURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourceURL  string
+		status     int
+		wantErr    error
+		wantUpload string
+	}{
+		{name: "success", sourceURL: "https://files.example.com/docs/doc.pdf?sig=1", status: 200, wantUpload: "remote document"},
+		{name: "source not found", sourceURL: "https://files.example.com/docs/doc.pdf", status: 404, wantErr: common.ErrInvalidStatusCode},
+		{name: "invalid URL", sourceURL: "file:///docs/doc.pdf", wantErr: common.ErrInvalidSourceURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests int
+			var uploaded string
+			var uploadedLength int64
+			client := &Client{
+				Token:     "123",
+				ExpiresAt: time.Now().Add(time.Hour),
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						mu.Lock()
+						defer mu.Unlock()
+
+						requests += 1
+						switch req.Method {
+						case http.MethodGet:
+							return &http.Response{
+								StatusCode:    tt.status,
+								ContentLength: int64(len("remote document")),
+								Body:          io.NopCloser(strings.NewReader("remote document")),
+							}, nil
+						case http.MethodPut:
+							body, _ := io.ReadAll(req.Body)
+							uploaded, uploadedLength = string(body), req.ContentLength
+							return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+						default:
+							return &http.Response{
+								StatusCode: 200,
+								Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"123","status_url":"url/123","urls":{"document":"https://bucket/document"}}`))),
+							}, nil
+						}
+					},
+				},
+			}
+
+			_, err := client.SendJobFromURL(context.Background(), ServiceRG, tt.sourceURL, "", "", nil, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.SendJobFromURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == common.ErrInvalidSourceURL && requests != 0 {
+				t.Errorf("client.SendJobFromURL() did %d requests, want 0", requests)
+			}
+			if uploaded != tt.wantUpload {
+				t.Errorf("uploaded = %q, want %q", uploaded, tt.wantUpload)
+			}
+			if tt.wantUpload != "" && uploadedLength != int64(len(tt.wantUpload)) {
+				t.Errorf("uploaded Content-Length = %d, want %d", uploadedLength, len(tt.wantUpload))
+			}
+		})
+	}
+}
+
+func TestURLSourceName(t *testing.T) {
+	client := NewClient()
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://files.example.com/docs/doc.pdf?sig=1", want: "doc.pdf"},
+		{url: "https://files.example.com/", want: "document"},
+		{url: "https://files.example.com", want: "document"},
+	}
+	for _, tt := range tests {
+		if got := client.URLSource(context.Background(), tt.url).Name(); got != tt.want {
+			t.Errorf("URLSource(%q).Name() = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
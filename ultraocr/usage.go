@@ -0,0 +1,46 @@
+package ultraocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// GetUsage Gets the documents processed on a month and the remaining quota.
+// Requires the month in 2006-01 format.
+func (client *Client) GetUsage(ctx context.Context, period string) (UsageResponse, error) {
+	_, err := time.Parse(common.MONTH_FORMAT, period)
+	if err != nil {
+		return UsageResponse{}, fmt.Errorf("%w: period %q", common.ErrInvalidDateRange, period)
+	}
+
+	url := fmt.Sprintf("%s/ocr/usage", client.BaseURL)
+	response, err := client.get(ctx, url, map[string]string{"period": period})
+	if err != nil {
+		return UsageResponse{}, err
+	}
+
+	if response.status != 200 {
+		return UsageResponse{}, response.apiError()
+	}
+
+	var res UsageResponse
+	err = json.Unmarshal(response.body, &res)
+	if err != nil {
+		return UsageResponse{}, common.ErrParsingResponse
+	}
+
+	return res, nil
+}
+
+// QuotaUsed Returns the fraction of the quota used, from 0 to 1, or 0 without a quota.
+func (u UsageResponse) QuotaUsed() float64 {
+	if u.Quota <= 0 {
+		return 0
+	}
+
+	return float64(u.Quota-u.Remaining) / float64(u.Quota)
+}
@@ -0,0 +1,93 @@
+package ultraocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestGetUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   string
+		status   int
+		body     string
+		want     UsageResponse
+		wantUsed float64
+		wantErr  error
+	}{
+		{
+			name:   "success",
+			period: "2024-01",
+			status: 200,
+			body:   `{"period":"2024-01","processed":750,"services":{"rg":500,"cnh":250},"quota":1000,"remaining":250}`,
+			want: UsageResponse{
+				Period:    "2024-01",
+				Processed: 750,
+				Services:  map[Service]int{"rg": 500, "cnh": 250},
+				Quota:     1000,
+				Remaining: 250,
+			},
+			wantUsed: 0.75,
+		},
+		{
+			name:   "no quota",
+			period: "2024-02",
+			status: 200,
+			body:   `{"period":"2024-02","processed":10}`,
+			want:   UsageResponse{Period: "2024-02", Processed: 10},
+		},
+		{
+			name:    "invalid period",
+			period:  "2024-01-01",
+			wantErr: common.ErrInvalidDateRange,
+		},
+		{
+			name:    "invalid status",
+			period:  "2024-01",
+			status:  403,
+			wantErr: common.ErrInvalidStatusCode,
+		},
+		{
+			name:    "invalid body",
+			period:  "2024-01",
+			status:  200,
+			body:    `[`,
+			wantErr: common.ErrParsingResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				HttpClient: &ClientMock{
+					MockDo: func(req *http.Request) (*http.Response, error) {
+						if got := req.URL.Query().Get("period"); got != tt.period {
+							t.Errorf("period param = %v, want %v", got, tt.period)
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+						}, nil
+					},
+				},
+			}
+
+			got, err := client.GetUsage(context.Background(), tt.period)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.GetUsage() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.GetUsage() = %+v, want %+v", got, tt.want)
+			}
+			if used := got.QuotaUsed(); used != tt.wantUsed {
+				t.Errorf("UsageResponse.QuotaUsed() = %v, want %v", used, tt.wantUsed)
+			}
+		})
+	}
+}
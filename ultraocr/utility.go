@@ -1,5 +1,16 @@
 package ultraocr
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
 func isNil(value any) bool {
 	switch value := value.(type) {
 	case nil:
@@ -12,3 +23,65 @@ func isNil(value any) bool {
 		return false
 	}
 }
+
+// tokenLifetime Returns how long a JWT is valid from its "iat" claim, or from issued when it has none,
+// to its "exp" claim, without verifying its signature. Both are on the server clock, so the lifetime
+// can be added to the local clock without the clocks difference.
+func tokenLifetime(token string, issued time.Time) (time.Duration, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return 0, false
+	}
+
+	var claims struct {
+		Exp json.Number `json:"exp"`
+		Iat json.Number `json:"iat"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil || claims.Exp == "" {
+		return 0, false
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return 0, false
+	}
+
+	if claims.Iat != "" {
+		iat, err := claims.Iat.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		issued = time.Unix(int64(iat), 0)
+	}
+
+	return time.Unix(int64(exp), 0).Sub(issued), true
+}
+
+// normalizeBaseURL Validates a base URL (absolute http or https URL, without query and fragment),
+// removing the path trailing slashes.
+func normalizeBaseURL(rawURL string) (string, error) {
+	u, err := neturl.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", common.ErrInvalidBaseURL, rawURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: %q: must be an absolute http or https URL", common.ErrInvalidBaseURL, rawURL)
+	}
+
+	if u.RawQuery != "" || u.ForceQuery || u.Fragment != "" {
+		return "", fmt.Errorf("%w: %q: must not have query or fragment", common.ErrInvalidBaseURL, rawURL)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+
+	return u.String(), nil
+}
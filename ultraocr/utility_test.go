@@ -0,0 +1,143 @@
+package ultraocr
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+func TestTokenLifetime(t *testing.T) {
+	encode := func(payload string) string {
+		return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	issued := time.Unix(1704063600, 0)
+	tests := []struct {
+		name   string
+		token  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			name:   "issued at",
+			token:  encode(`{"sub":"123","iat":1704066000,"exp":1704067200}`),
+			want:   20 * time.Minute,
+			wantOk: true,
+		},
+		{
+			name:   "issued on response",
+			token:  encode(`{"sub":"123","exp":1704067200}`),
+			want:   time.Hour,
+			wantOk: true,
+		},
+		{
+			name:   "float expiration",
+			token:  encode(`{"exp":1704067200.5}`),
+			want:   time.Hour,
+			wantOk: true,
+		},
+		{
+			name:  "missing expiration",
+			token: encode(`{"sub":"123"}`),
+		},
+		{
+			name:  "invalid issued at",
+			token: encode(`{"iat":"now","exp":1704067200}`),
+		},
+		{
+			name:  "invalid payload",
+			token: "a.b$.c",
+		},
+		{
+			name:  "not a jwt",
+			token: "123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tokenLifetime(tt.token, issued)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("tokenLifetime() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSetBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			url:  common.BASE_URL,
+			want: common.BASE_URL,
+		},
+		{
+			name: "trailing slashes",
+			url:  "https://ultraocr.apis.nuveo.ai/v2//",
+			want: "https://ultraocr.apis.nuveo.ai/v2",
+		},
+		{
+			name: "gateway prefix",
+			url:  " http://gateway.internal:8080/apis/ultraocr/v2/ ",
+			want: "http://gateway.internal:8080/apis/ultraocr/v2",
+		},
+		{
+			name: "host only",
+			url:  "https://ultraocr.apis.nuveo.ai/",
+			want: "https://ultraocr.apis.nuveo.ai",
+		},
+		{
+			name:    "missing scheme",
+			url:     "ultraocr.apis.nuveo.ai/v2",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			url:     "ftp://ultraocr.apis.nuveo.ai/v2",
+			wantErr: true,
+		},
+		{
+			name:    "missing host",
+			url:     "https:///v2",
+			wantErr: true,
+		},
+		{
+			name:    "query",
+			url:     "https://ultraocr.apis.nuveo.ai/v2?key=value",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			url:     "https://ultraocr apis/%zz",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			err := client.SetBaseURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("client.SetBaseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, common.ErrInvalidBaseURL) {
+					t.Errorf("client.SetBaseURL() error = %v, want %v", err, common.ErrInvalidBaseURL)
+				}
+				if client.BaseURL != common.BASE_URL {
+					t.Errorf("client.BaseURL = %v, want unchanged %v", client.BaseURL, common.BASE_URL)
+				}
+				return
+			}
+			if client.BaseURL != tt.want {
+				t.Errorf("client.BaseURL = %v, want %v", client.BaseURL, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// Package watch implements a hot folder: a directory watched for new files, each one submitted
+// as a job and then moved to a done or error folder. The directory is polled, so it works on
+// network shares and without file system notification dependencies.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/common"
+)
+
+// Watch defaults.
+const (
+	DEFAULT_INTERVAL = 2 * time.Second
+	DONE_DIR         = "done"
+	ERROR_DIR        = "error"
+)
+
+// ErrJobFailed Error of events whose job finished with the error status.
+var ErrJobFailed = errors.New("job failed")
+
+// Event The outcome of a file of the folder.
+// Result is only set when the Watcher waits the jobs.
+type Event struct {
+	Path   string
+	Moved  string
+	JobID  string
+	Result *ultraocr.JobResultResponse
+	Err    error
+}
+
+// Watcher Submits the files added to Dir as jobs of Service, moving them to DoneDir (default Dir/done)
+// or ErrorDir (default Dir/error) after. A file is submitted once its size and modification time
+// are the same on two scans, so files still being copied are not sent. Hidden files are ignored.
+// With Wait, files are moved only after their jobs finish, to ErrorDir when the job fails.
+// The settled files of a scan are processed up to Concurrency at a time (default the Client
+// jobs concurrency), so a slow job doesn't hold the others. Files that couldn't be moved are
+// not submitted again, their moves are retried on the next scans.
+type Watcher struct {
+	Client      *ultraocr.Client
+	Service     ultraocr.Service
+	Dir         string
+	DoneDir     string
+	ErrorDir    string
+	Interval    time.Duration
+	Options     ultraocr.JobOptions
+	Metadata    map[string]any
+	Wait        bool
+	Concurrency int
+	OnEvent     func(Event)
+
+	seen      map[string]fileState
+	processed map[string]processedFile
+	mu        sync.Mutex
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// processedFile A file submitted but not moved, with the event of its job.
+type processedFile struct {
+	state fileState
+	event Event
+}
+
+func (w *Watcher) concurrency() int {
+	if w.Concurrency > 0 {
+		return w.Concurrency
+	}
+
+	if w.Client.JobsConcurrency > 0 {
+		return w.Client.JobsConcurrency
+	}
+
+	return common.DEFAULT_JOBS_CONCURRENCY
+}
+
+// Run Scans the folder on every Interval (default 2s) until the context is done.
+// Only failures to read the folder stop it; file failures are reported on OnEvent.
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DEFAULT_INTERVAL
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := w.Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan Scans the folder once, submitting and moving the files settled since the last scan.
+// Returns the events of the processed files, in the folder order.
+func (w *Watcher) Scan(ctx context.Context) ([]Event, error) {
+	entries, err := os.ReadDir(w.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.seen == nil {
+		w.seen = map[string]fileState{}
+		w.processed = map[string]processedFile{}
+	}
+
+	var settled []string
+	states := map[string]fileState{}
+	current := map[string]fileState{}
+	present := map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		present[filepath.Join(w.Dir, entry.Name())] = true
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		state := fileState{size: info.Size(), modTime: info.ModTime()}
+		if previous, ok := w.seen[entry.Name()]; !ok || previous != state {
+			current[entry.Name()] = state
+			continue
+		}
+
+		settled = append(settled, entry.Name())
+		states[entry.Name()] = state
+	}
+
+	// files removed from the folder are not moved anymore
+	for path := range w.processed {
+		if !present[path] {
+			delete(w.processed, path)
+		}
+	}
+
+	events := make([]Event, len(settled))
+	slots := make(chan struct{}, w.concurrency())
+	var wg sync.WaitGroup
+
+files:
+	for i, name := range settled {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break files
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			event := w.process(ctx, filepath.Join(w.Dir, name), states[name])
+			if ctx.Err() != nil {
+				return
+			}
+
+			events[i] = event
+			if w.OnEvent != nil {
+				w.mu.Lock()
+				w.OnEvent(event)
+				w.mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	events = slices.DeleteFunc(events, func(event Event) bool { return event.Path == "" })
+	if ctx.Err() != nil {
+		return events, ctx.Err()
+	}
+
+	w.seen = current
+	return events, nil
+}
+
+// process Submits the file, waiting the job with Wait, and moves it. A file already submitted
+// and not moved, with the same size and modification time, is only moved.
+func (w *Watcher) process(ctx context.Context, path string, state fileState) Event {
+	w.mu.Lock()
+	processed, ok := w.processed[path]
+	w.mu.Unlock()
+
+	event := processed.event
+	if !ok || processed.state != state {
+		event = w.submit(ctx, path)
+		if ctx.Err() != nil {
+			return event
+		}
+	}
+
+	dir := w.dir(w.DoneDir, DONE_DIR)
+	if event.Err != nil {
+		dir = w.dir(w.ErrorDir, ERROR_DIR)
+	}
+
+	moved, err := move(path, dir, event.JobID)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		w.processed[path] = processedFile{state: state, event: event}
+		if event.Err == nil {
+			event.Err = err
+		}
+
+		return event
+	}
+
+	delete(w.processed, path)
+	event.Moved = moved
+	return event
+}
+
+// submit Submits the file, waiting the job with Wait.
+func (w *Watcher) submit(ctx context.Context, path string) Event {
+	event := Event{Path: path}
+
+	created, err := w.Client.SubmitJob(ctx, ultraocr.JobRequest{
+		Service:  w.Service,
+		Document: ultraocr.FileSource(path),
+		Metadata: w.Metadata,
+		Options:  w.Options,
+	})
+	event.JobID = created.Id
+	event.Err = err
+
+	if err == nil && w.Wait {
+		result, err := w.Client.WaitForJob(ctx, created.Id)
+		event.Err = err
+		if err == nil {
+			event.Result = &result
+			if result.Status.IsError() {
+				event.Err = fmt.Errorf("%w: %s", ErrJobFailed, result.Error)
+			}
+		}
+	}
+
+	return event
+}
+
+func (w *Watcher) dir(dir, name string) string {
+	if dir == "" {
+		return filepath.Join(w.Dir, name)
+	}
+
+	return dir
+}
+
+// move Moves the file to the directory, prefixing it with the job ID (or the time, without a job)
+// if the name is taken.
+func move(path, dir, jobID string) (string, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(dir, filepath.Base(path))
+	if _, err := os.Stat(target); err == nil {
+		prefix := jobID
+		if prefix == "" {
+			prefix = fmt.Sprint(time.Now().UnixNano())
+		}
+
+		target = filepath.Join(dir, fmt.Sprintf("%s_%s", prefix, filepath.Base(path)))
+	}
+
+	err = os.Rename(path, target)
+	if errors.Is(err, syscall.EXDEV) {
+		// the directory is on another mount
+		err = copyFile(path, target)
+		if err == nil {
+			err = os.Remove(path)
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return target, nil
+}
+
+// copyFile Copies the file to the target, removing the partial copy on failures.
+func copyFile(path, target string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(target)
+	}
+
+	return err
+}
@@ -0,0 +1,155 @@
+package watch
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr/ultraocrtest"
+)
+
+type failingAPI struct{}
+
+func (failingAPI) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+}
+
+type countingAPI struct {
+	api  ultraocr.HttpClient
+	jobs atomic.Int32
+}
+
+func (c *countingAPI) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/ocr/job/") {
+		c.jobs.Add(1)
+	}
+
+	return c.api.Do(req)
+}
+
+func TestWatcher(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		api      ultraocr.HttpClient
+		wait     bool
+		wantDir  string
+		wantErrs bool
+	}{
+		{name: "submitted", api: ultraocrtest.NewFakeAPI(clock), wantDir: DONE_DIR},
+		{name: "waited", api: ultraocrtest.NewFakeAPI(clock), wait: true, wantDir: DONE_DIR},
+		{name: "failed", api: failingAPI{}, wantDir: ERROR_DIR, wantErrs: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.WriteFile(filepath.Join(dir, "a.pdf"), []byte("a"), 0o600)
+			_ = os.WriteFile(filepath.Join(dir, ".hidden"), []byte("h"), 0o600)
+
+			client := ultraocr.NewClient()
+			client.SetClock(clock)
+			client.SetHttpClient(tt.api)
+			client.SetAutoRefresh("id", "secret", 60)
+			client.Token = "token"
+			client.ExpiresAt = clock.Now().Add(time.Hour)
+
+			var notified int
+			w := &Watcher{
+				Client:  &client,
+				Service: ultraocr.ServiceRG,
+				Dir:     dir,
+				Wait:    tt.wait,
+				OnEvent: func(Event) { notified += 1 },
+			}
+
+			events, err := w.Scan(context.Background())
+			if err != nil || len(events) != 0 {
+				t.Fatalf("Watcher.Scan() = %v, %v, want no events on the first scan", events, err)
+			}
+
+			_ = os.WriteFile(filepath.Join(dir, "b.pdf"), []byte("b"), 0o600)
+			events, err = w.Scan(context.Background())
+			if err != nil {
+				t.Fatalf("Watcher.Scan() error = %v", err)
+			}
+			if len(events) != 1 || notified != 1 {
+				t.Fatalf("Watcher.Scan() = %v events, %d notified, want the settled file only", len(events), notified)
+			}
+
+			event := events[0]
+			if (event.Err != nil) != tt.wantErrs {
+				t.Errorf("Event.Err = %v, wantErr %v", event.Err, tt.wantErrs)
+			}
+			if (event.Result != nil) != tt.wait {
+				t.Errorf("Event.Result = %v, want set %v", event.Result, tt.wait)
+			}
+			if want := filepath.Join(dir, tt.wantDir, "a.pdf"); event.Moved != want {
+				t.Errorf("Event.Moved = %v, want %v", event.Moved, want)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "a.pdf")); !os.IsNotExist(err) {
+				t.Errorf("file was not moved out of the folder")
+			}
+
+			events, _ = w.Scan(context.Background())
+			if len(events) != 1 || filepath.Base(events[0].Path) != "b.pdf" {
+				t.Errorf("Watcher.Scan() = %v, want b.pdf settled", events)
+			}
+		})
+	}
+}
+
+func TestWatcherMoveFailure(t *testing.T) {
+	clock := ultraocrtest.NewAutoClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	api := ultraocrtest.NewFakeAPI(clock)
+
+	counter := &countingAPI{api: api}
+	client := ultraocr.NewClient()
+	client.SetClock(clock)
+	client.SetHttpClient(counter)
+	client.SetAutoRefresh("id", "secret", 60)
+	client.Token = "token"
+	client.ExpiresAt = clock.Now().Add(time.Hour)
+
+	dir := t.TempDir()
+	doneDir := filepath.Join(t.TempDir(), "done")
+	_ = os.WriteFile(filepath.Join(dir, "a.pdf"), []byte("a"), 0o600)
+	// a file where the done directory should be, so the moves fail
+	_ = os.WriteFile(doneDir, []byte("blocker"), 0o600)
+
+	w := &Watcher{Client: &client, Service: ultraocr.ServiceRG, Dir: dir, DoneDir: doneDir}
+
+	var moved []Event
+	for i := 0; i < 8; i++ {
+		if i == 5 {
+			_ = os.Remove(doneDir)
+		}
+
+		events, err := w.Scan(context.Background())
+		if err != nil {
+			t.Fatalf("Watcher.Scan() error = %v", err)
+		}
+
+		for _, event := range events {
+			if event.JobID == "" {
+				t.Errorf("Event.JobID = empty, want the submitted job")
+			}
+			if event.Moved != "" {
+				moved = append(moved, event)
+			}
+		}
+	}
+
+	if counter.jobs.Load() != 1 {
+		t.Errorf("Watcher submitted the file %d times, want 1", counter.jobs.Load())
+	}
+	if len(moved) != 1 || moved[0].Err != nil || moved[0].Moved != filepath.Join(doneDir, "a.pdf") {
+		t.Errorf("Watcher moved events = %+v, want a.pdf moved once", moved)
+	}
+}
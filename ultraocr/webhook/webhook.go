@@ -0,0 +1,223 @@
+// Package webhook implements a receiver for UltraOCR job and batch completion callbacks.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+// Webhook constants.
+const (
+	HEADER_SIGNATURE = "X-UltraOCR-Signature"
+	SIGNATURE_PREFIX = "sha256="
+	MAX_BODY_SIZE    = 10 << 20
+)
+
+// Webhook errors.
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// JobDone Event received when a job finishes, successfully or not.
+type JobDone struct {
+	ultraocr.JobResultResponse
+	BatchID string `json:"batch_ksuid,omitempty"`
+}
+
+// BatchDone Event received when a batch finishes, successfully or not.
+type BatchDone struct {
+	ultraocr.BatchStatusResponse
+}
+
+// JobDoneFunc Handles a job completion event.
+type JobDoneFunc func(ctx context.Context, event JobDone) error
+
+// BatchDoneFunc Handles a batch completion event.
+type BatchDoneFunc func(ctx context.Context, event BatchDone) error
+
+// Handler http.Handler receiving UltraOCR callbacks and dispatching them to the registered handlers.
+// Requests are acknowledged with 204 after all handlers succeed; failures answer 500 so the callback is retried.
+// Failed requests only get the status text, their errors are given to ErrorHandler, if set.
+type Handler struct {
+	Secret       string
+	MaxBodySize  int64
+	ErrorHandler func(r *http.Request, err error)
+
+	mu            sync.RWMutex
+	jobHandlers   []JobDoneFunc
+	batchHandlers []BatchDoneFunc
+}
+
+// NewHandler Creates a webhook handler. If secret is not empty, the payloads signature is verified.
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		Secret:      secret,
+		MaxBodySize: MAX_BODY_SIZE,
+	}
+}
+
+// OnJobDone Registers a handler for job completion events.
+func (h *Handler) OnJobDone(fn JobDoneFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.jobHandlers = append(h.jobHandlers, fn)
+}
+
+// OnBatchDone Registers a handler for batch completion events.
+func (h *Handler) OnBatchDone(fn BatchDoneFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.batchHandlers = append(h.batchHandlers, fn)
+}
+
+// Forward Registers handlers delivering the events to a Tracker, so its waits don't poll the API.
+func (h *Handler) Forward(tracker *ultraocr.Tracker) {
+	h.OnJobDone(func(ctx context.Context, event JobDone) error {
+		tracker.JobDone(event.JobResultResponse)
+		return nil
+	})
+	h.OnBatchDone(func(ctx context.Context, event BatchDone) error {
+		tracker.BatchDone(event.BatchStatusResponse)
+		return nil
+	})
+}
+
+// ServeHTTP Verifies, parses and dispatches a callback request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxSize := h.MaxBodySize
+	if maxSize <= 0 {
+		maxSize = MAX_BODY_SIZE
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		h.fail(w, r, status, fmt.Errorf("%w: %w", ErrInvalidPayload, err))
+		return
+	}
+
+	if h.Secret != "" && !Verify(h.Secret, body, r.Header.Get(HEADER_SIGNATURE)) {
+		h.fail(w, r, http.StatusUnauthorized, ErrInvalidSignature)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), body); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidPayload) {
+			status = http.StatusBadRequest
+		}
+
+		h.fail(w, r, status, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fail Answers the status text, so the errors of the handlers aren't sent to the caller,
+// giving the error to ErrorHandler.
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.ErrorHandler != nil {
+		h.ErrorHandler(r, err)
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
+
+// Dispatch Parses a callback payload and calls the registered handlers, without checking the signature.
+// Payloads with a batch_ksuid and jobs list are batch events, payloads with a job_ksuid are job events.
+func (h *Handler) Dispatch(ctx context.Context, body []byte) error {
+	var kind struct {
+		BatchID string            `json:"batch_ksuid"`
+		JobID   string            `json:"job_ksuid"`
+		Jobs    []json.RawMessage `json:"jobs"`
+	}
+
+	if err := json.Unmarshal(body, &kind); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	h.mu.RLock()
+	jobHandlers := h.jobHandlers
+	batchHandlers := h.batchHandlers
+	h.mu.RUnlock()
+
+	switch {
+	case kind.JobID != "":
+		var event JobDone
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		}
+
+		for _, fn := range jobHandlers {
+			if err := fn(ctx, event); err != nil {
+				return err
+			}
+		}
+	case kind.BatchID != "" && kind.Jobs != nil:
+		var event BatchDone
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		}
+
+		for _, fn := range batchHandlers {
+			if err := fn(ctx, event); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown event", ErrInvalidPayload)
+	}
+
+	return nil
+}
+
+// Sign Returns the signature of a payload, as sent on the signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return SIGNATURE_PREFIX + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify Checks a payload signature in constant time.
+func Verify(secret string, body []byte, signature string) bool {
+	signature = strings.TrimSpace(signature)
+	if !strings.HasPrefix(signature, SIGNATURE_PREFIX) {
+		return false
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, SIGNATURE_PREFIX))
+	if err != nil {
+		return false
+	}
+
+	want, _ := hex.DecodeString(strings.TrimPrefix(Sign(secret, body), SIGNATURE_PREFIX))
+
+	return hmac.Equal(got, want)
+}
@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/nuveo/ultraocr-sdk-go/ultraocr"
+)
+
+const (
+	jobPayload   = `{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","batch_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL4","service":"rg","status":"done","result":{"Time":"1.00"}}`
+	batchPayload = `{"batch_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL4","service":"rg","status":"done","jobs":[{"job_ksuid":"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3","status":"done"}]}`
+)
+
+func TestHandler(t *testing.T) {
+	errHandler := errors.New("database password rejected")
+
+	tests := []struct {
+		name       string
+		secret     string
+		method     string
+		body       string
+		signature  string
+		handlerErr error
+		reader     io.Reader
+		wantStatus int
+		wantErr    error
+		wantJobs   []string
+		wantBatch  []string
+	}{
+		{
+			name:       "job done",
+			method:     http.MethodPost,
+			body:       jobPayload,
+			wantStatus: http.StatusNoContent,
+			wantJobs:   []string{"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3/2AwrSd7bxEMbPrQ5jZHGDzQ4qL4"},
+		},
+		{
+			name:       "batch done",
+			method:     http.MethodPost,
+			body:       batchPayload,
+			wantStatus: http.StatusNoContent,
+			wantBatch:  []string{"2AwrSd7bxEMbPrQ5jZHGDzQ4qL4/1"},
+		},
+		{
+			name:       "signed payload",
+			secret:     "secret",
+			method:     http.MethodPost,
+			body:       jobPayload,
+			signature:  Sign("secret", []byte(jobPayload)),
+			wantStatus: http.StatusNoContent,
+			wantJobs:   []string{"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3/2AwrSd7bxEMbPrQ5jZHGDzQ4qL4"},
+		},
+		{
+			name:       "invalid signature",
+			secret:     "secret",
+			method:     http.MethodPost,
+			body:       jobPayload,
+			signature:  Sign("other", []byte(jobPayload)),
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    ErrInvalidSignature,
+		},
+		{
+			name:       "missing signature",
+			secret:     "secret",
+			method:     http.MethodPost,
+			body:       jobPayload,
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    ErrInvalidSignature,
+		},
+		{
+			name:       "invalid method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid payload",
+			method:     http.MethodPost,
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+			wantErr:    ErrInvalidPayload,
+		},
+		{
+			name:       "body too large",
+			method:     http.MethodPost,
+			body:       strings.Repeat(" ", MAX_BODY_SIZE+1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantErr:    ErrInvalidPayload,
+		},
+		{
+			name:       "body read failure",
+			method:     http.MethodPost,
+			reader:     iotest.ErrReader(errors.New("connection reset")),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    ErrInvalidPayload,
+		},
+		{
+			name:       "unknown event",
+			method:     http.MethodPost,
+			body:       `{"status":"done"}`,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    ErrInvalidPayload,
+		},
+		{
+			name:       "handler failure",
+			method:     http.MethodPost,
+			body:       jobPayload,
+			handlerErr: errHandler,
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    errHandler,
+			wantJobs:   []string{"2AwrSd7bxEMbPrQ5jZHGDzQ4qL3/2AwrSd7bxEMbPrQ5jZHGDzQ4qL4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jobs, batches []string
+			var gotErr error
+
+			h := NewHandler(tt.secret)
+			h.ErrorHandler = func(r *http.Request, err error) {
+				gotErr = err
+			}
+			h.OnJobDone(func(ctx context.Context, event JobDone) error {
+				jobs = append(jobs, event.JobID+"/"+event.BatchID)
+				return tt.handlerErr
+			})
+			h.OnBatchDone(func(ctx context.Context, event BatchDone) error {
+				batches = append(batches, fmt.Sprintf("%s/%d", event.BatchID, len(event.Jobs)))
+				return tt.handlerErr
+			})
+
+			var body io.Reader = bytes.NewReader([]byte(tt.body))
+			if tt.reader != nil {
+				body = tt.reader
+			}
+			req := httptest.NewRequest(tt.method, "/webhook", body)
+			if tt.signature != "" {
+				req.Header.Set(HEADER_SIGNATURE, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Handler.ServeHTTP() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if rec.Code != http.StatusNoContent && strings.TrimSpace(rec.Body.String()) != http.StatusText(rec.Code) {
+				t.Errorf("Handler.ServeHTTP() body = %q, want the status text", rec.Body.String())
+			}
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("Handler.ErrorHandler() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(jobs, tt.wantJobs) {
+				t.Errorf("Handler.ServeHTTP() jobs = %v, want %v", jobs, tt.wantJobs)
+			}
+			if !reflect.DeepEqual(batches, tt.wantBatch) {
+				t.Errorf("Handler.ServeHTTP() batches = %v, want %v", batches, tt.wantBatch)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(jobPayload)
+
+	tests := []struct {
+		name      string
+		signature string
+		want      bool
+	}{
+		{name: "valid", signature: Sign("secret", body), want: true},
+		{name: "wrong secret", signature: Sign("other", body)},
+		{name: "missing prefix", signature: Sign("secret", body)[len(SIGNATURE_PREFIX):]},
+		{name: "not hex", signature: SIGNATURE_PREFIX + "zz"},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify("secret", body, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForward(t *testing.T) {
+	client := ultraocr.NewClient()
+	tracker := ultraocr.NewTracker(&client, "https://example.com/hook", time.Hour)
+	tracker.Track(ultraocr.TrackedItem{Resource: "batch", BatchID: "2AwrSd7bxEMbPrQ5jZHGDzQ4qL4"})
+
+	h := NewHandler("")
+	h.Forward(tracker)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader([]byte(batchPayload))))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Handler.ServeHTTP() status = %v, want %v", rec.Code, http.StatusNoContent)
+	}
+
+	status, err := tracker.WaitBatch(context.Background(), "2AwrSd7bxEMbPrQ5jZHGDzQ4qL4")
+	if err != nil {
+		t.Fatalf("tracker.WaitBatch() error = %v", err)
+	}
+	if status.Status != "done" || len(status.Jobs) != 1 {
+		t.Errorf("tracker.WaitBatch() = %+v, want the forwarded batch", status)
+	}
+}